@@ -8,6 +8,11 @@ import (
 	bubbletea "github.com/charmbracelet/bubbletea"
 	"github.com/dloss/podji/internal/app"
 	"github.com/dloss/podji/internal/buildinfo"
+	"github.com/dloss/podji/internal/cli"
+	"github.com/dloss/podji/internal/columnconfig"
+	"github.com/dloss/podji/internal/config"
+	"github.com/dloss/podji/internal/data"
+	"github.com/dloss/podji/internal/session"
 )
 
 func main() {
@@ -15,8 +20,11 @@ func main() {
 		out := flag.CommandLine.Output()
 		_, _ = fmt.Fprintf(out, "Podji - Kubernetes navigation TUI\n\n")
 		_, _ = fmt.Fprintf(out, "Usage:\n")
-		_, _ = fmt.Fprintf(out, "  %s [flags]\n\n", os.Args[0])
-		_, _ = fmt.Fprintf(out, "Flags:\n")
+		_, _ = fmt.Fprintf(out, "  %s [flags]\n", os.Args[0])
+		_, _ = fmt.Fprintf(out, "  %s [flags] <command> [args] [-o table|wide|json|yaml] [-n ns | -A] [--context name]\n\n", os.Args[0])
+		_, _ = fmt.Fprintf(out, "Commands:\n")
+		cli.PrintCommands(out)
+		_, _ = fmt.Fprintf(out, "\nFlags:\n")
 		flag.PrintDefaults()
 		_, _ = fmt.Fprintf(out, "\nEnvironment:\n")
 		_, _ = fmt.Fprintf(out, "  PODJI_MOCK=1                  Force mock mode\n")
@@ -24,6 +32,7 @@ func main() {
 		_, _ = fmt.Fprintf(out, "  PODJI_SCENARIO=<name>         Legacy fallback for scenario\n")
 		_, _ = fmt.Fprintf(out, "  PODJI_STRESS=1                Enable synthetic stress expansion in mock mode\n")
 		_, _ = fmt.Fprintf(out, "  PODJI_DEBUG_DATA=1            Log startup/data timing debug lines\n")
+		_, _ = fmt.Fprintf(out, "  XDG_CONFIG_HOME=<dir>         Read <dir>/podji/config.yaml (default ~/.config)\n")
 	}
 
 	mockFlag := flag.Bool("mock", false, "run with mock data")
@@ -36,19 +45,61 @@ func main() {
 		return
 	}
 
-	if *mockFlag {
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		_, _ = os.Stderr.WriteString(err.Error() + "\n")
+		os.Exit(1)
+	}
+
+	if *mockFlag || (cfg.Mode == "mock" && os.Getenv("PODJI_MOCK") == "") {
 		_ = os.Setenv("PODJI_MOCK", "1")
 	}
 
-	model, err := app.NewFromEnv()
+	if flag.NArg() > 0 {
+		if !cli.IsCommand(flag.Arg(0)) {
+			_, _ = fmt.Fprintf(os.Stderr, "podji: unknown command %q\n\n", flag.Arg(0))
+			flag.Usage()
+			os.Exit(cli.ExitUsage)
+		}
+		os.Exit(cli.Run(flag.Args(), data.NewStoreFromEnv, os.Stdout, os.Stderr))
+	}
+
+	columnsErr := openColumnLayouts()
+	model, err := app.NewFromEnvWithConfig(cfg)
 	if err != nil {
 		_, _ = os.Stderr.WriteString(err.Error() + "\n")
 		os.Exit(1)
 	}
+	if columnsErr != nil {
+		model.SetStatus(columnsErr.Error() + "; column changes will not be saved")
+	}
+	if path, err := session.DefaultPath(); err == nil {
+		if err := model.OpenSession(path, cfg.RestoreSession); err != nil {
+			model.SetStatus(err.Error() + "; bookmarks will not be saved")
+		}
+	}
 
 	program := bubbletea.NewProgram(model, bubbletea.WithAltScreen())
-	if _, err := program.Run(); err != nil {
+	final, err := program.Run()
+	if err != nil {
 		_, _ = os.Stderr.WriteString(err.Error() + "\n")
 		os.Exit(1)
 	}
+	if final, ok := final.(app.Model); ok {
+		if err := final.SaveSession(); err != nil {
+			_, _ = os.Stderr.WriteString(err.Error() + "\n")
+		}
+	}
+}
+
+// openColumnLayouts loads the saved column layouts so picker changes persist
+// across sessions.
+func openColumnLayouts() error {
+	path, err := config.StatePath("columns.yaml")
+	if err != nil {
+		return err
+	}
+	store, err := columnconfig.Open(path)
+	columnconfig.SetDefault(store)
+	return err
 }
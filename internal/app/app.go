@@ -1,7 +1,9 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -10,8 +12,12 @@ import (
 	bubbletea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/columnconfig"
+	"github.com/dloss/podji/internal/config"
 	"github.com/dloss/podji/internal/data"
+	"github.com/dloss/podji/internal/plugin"
 	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/session"
 	"github.com/dloss/podji/internal/ui/columnpicker"
 	"github.com/dloss/podji/internal/ui/commandbar"
 	"github.com/dloss/podji/internal/ui/describeview"
@@ -25,17 +31,9 @@ import (
 	"github.com/dloss/podji/internal/ui/style"
 	"github.com/dloss/podji/internal/ui/viewstate"
 	"github.com/dloss/podji/internal/ui/yamlview"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
-// Bookmark captures a full navigation state: the complete view stack, breadcrumbs,
-// namespace, and context, so a jump restores the exact screen where it was set.
-type Bookmark struct {
-	stack     []viewstate.View
-	crumbs    []string
-	namespace string
-	context   string
-}
-
 type Model struct {
 	store             data.Store
 	registry          *resources.Registry
@@ -52,17 +50,43 @@ type Model struct {
 	storeStatus       data.StoreStatus
 	errorMsg          string
 	statusMsg         string
-	bookmarks         [9]*Bookmark
+	bookmarks         [9]*session.Nav
 	bookmarkMode      bool
 	activeResourceKey rune
 	width             int
 	height            int
+
+	grepCancel context.CancelFunc
+	grepID     int
+
+	plugins      []*plugin.Plugin
+	pluginCancel context.CancelFunc
+	pluginID     int
+
+	compareMark *compareMark
+
+	keys *config.Keymap
+
+	sessionPath string       // session state file; "" keeps bookmarks in memory
+	lastNav     *session.Nav // screen saved at the previous exit
 }
 
 type globalKeySuppresser interface {
 	SuppressGlobalKeys() bool
 }
 
+// keyCapturer is implemented by views with prompts or key modes that read
+// the next keys literally, such as a search being typed.
+type keyCapturer interface {
+	CapturesKeys() bool
+}
+
+// resumer is implemented by views with periodic work that stops while another
+// view covers them, so it can restart when they are back on top.
+type resumer interface {
+	Resume() bubbletea.Cmd
+}
+
 // bodyRowProvider is implemented by views that report the visual line (within
 // their own View() output) at which the selected row appears.
 type bodyRowProvider interface {
@@ -86,11 +110,10 @@ var newStoreFromEnvFn = data.NewStoreFromEnv
 
 func NewFromEnv() (Model, error) {
 	started := time.Now()
-	store, err := newStoreFromEnvFn()
+	model, err := NewFromEnvWithConfig(config.Default())
 	if err != nil {
 		return Model{}, err
 	}
-	model := NewWithStore(store)
 	debugAppf("startup_ms=%d store=%T warning=%t context=%s namespace=%s",
 		time.Since(started).Milliseconds(),
 		model.store,
@@ -107,6 +130,7 @@ func NewWithStore(store data.Store) Model {
 	}
 	registry := store.Registry()
 	scope := store.Scope()
+	columnconfig.Default().SetContext(scope.Context)
 	workloads := store.AdaptResource(registry.ResourceByKey('W'))
 	root := listview.New(workloads, registry)
 	rootCrumb := normalizeBreadcrumbPart(root.Breadcrumb())
@@ -125,6 +149,11 @@ func NewWithStore(store data.Store) Model {
 	}
 }
 
+// SetStatus shows msg in the status line until the next key press.
+func (m *Model) SetStatus(msg string) {
+	m.statusMsg = msg
+}
+
 func storeMode(store data.Store) string {
 	switch store.(type) {
 	case *data.KubeStore:
@@ -236,6 +265,23 @@ func (m *Model) handleTopLevelMsg(msg bubbletea.Msg) (bubbletea.Msg, bool, bubbl
 			m.cmdBar = nil
 			return msg, true, bubbletea.Quit
 		}
+		if pattern, ok := grepCommand(trimmed); ok {
+			if pattern == "" {
+				m.cmdBar.SetError("usage: grep <pattern>")
+				return msg, true, nil
+			}
+			m.cmdBar = nil
+			return msg, true, m.startGrep(pattern)
+		}
+		if p := m.pluginNamed(strings.ToLower(trimmed)); p != nil {
+			cmd, err := m.runPlugin(p)
+			if err != "" {
+				m.cmdBar.SetError(err)
+				return msg, true, nil
+			}
+			m.cmdBar = nil
+			return msg, true, cmd
+		}
 		if err := m.runCommand(msg.Value); err != "" {
 			m.cmdBar.SetError(err)
 			return msg, true, nil
@@ -243,15 +289,42 @@ func (m *Model) handleTopLevelMsg(msg bubbletea.Msg) (bubbletea.Msg, bool, bubbl
 		m.cmdBar = nil
 		return msg, true, nil
 
+	case grepResultMsg:
+		m.handleGrepResult(msg)
+		return msg, true, nil
+
+	case pluginDoneMsg:
+		m.handlePluginDone(msg)
+		return msg, true, nil
+
+	case pluginOutputMsg:
+		m.handlePluginOutput(msg)
+		return msg, true, nil
+
+	case listview.CompareMsg:
+		m.handleCompare(msg)
+		return msg, true, nil
+
+	case listview.ExportMsg:
+		dir, err := os.Getwd()
+		if err == nil {
+			m.statusMsg, err = msg.Write(m.context, dir, time.Now())
+		}
+		if err != nil {
+			m.statusMsg = "export failed: " + err.Error()
+		}
+		return msg, true, nil
+
 	case listview.OpenColumnPickerMsg:
 		picker := columnpicker.New(msg.ResourceName, msg.Pool, msg.LabelPool, msg.Current)
+		picker.SetContext(m.context, msg.ContextOnly)
 		picker.SetSize(m.width, m.height-1)
 		m.colPicker = picker
 		return msg, true, nil
 
 	case columnpicker.PickedMsg:
 		if lv, ok := m.top().(*listview.View); ok {
-			lv.ApplyColumnConfig(msg.ResourceName, msg.Visible)
+			return msg, true, lv.ApplyColumnConfig(msg.ResourceName, msg.Visible, msg.ContextOnly)
 		}
 		return msg, true, nil
 
@@ -277,6 +350,7 @@ func (m *Model) handleTopLevelMsg(msg bubbletea.Msg) (bubbletea.Msg, bool, bubbl
 				m.context = msg.Value
 			}
 		}
+		columnconfig.Default().SetContext(m.context)
 		m.syncStoreStatus()
 		// Choose the best resource using the fallback chain:
 		// current resource → parent resource(s) → workloads (W) → first registry resource.
@@ -315,8 +389,19 @@ func (m *Model) handleTopLevelMsg(msg bubbletea.Msg) (bubbletea.Msg, bool, bubbl
 
 func (m *Model) handleGlobalKeyMsg(msg bubbletea.KeyMsg) (bubbletea.Msg, bool, bubbletea.Cmd) {
 	m.statusMsg = ""
+	if m.grepCancel != nil && msg.String() == "esc" {
+		m.cancelGrep()
+		m.statusMsg = "grep canceled"
+		return msg, true, nil
+	}
+	if m.pluginCancel != nil && msg.String() == "esc" {
+		m.cancelPlugin()
+		m.statusMsg = "plugin canceled"
+		return msg, true, nil
+	}
 	if suppresser, ok := m.top().(globalKeySuppresser); ok && suppresser.SuppressGlobalKeys() && msg.String() != "ctrl+c" {
-		return msg, false, nil
+		msg, bound := m.translateSuppressedKey(msg)
+		return msg, !bound, nil
 	}
 	msg = normalizeGlobalKey(msg)
 
@@ -326,14 +411,30 @@ func (m *Model) handleGlobalKeyMsg(msg bubbletea.KeyMsg) (bubbletea.Msg, bool, b
 		runes := []rune(msg.String())
 		if len(runes) == 1 && runes[0] >= '1' && runes[0] <= '9' {
 			slot := int(runes[0] - '1')
-			m.bookmarks[slot] = &Bookmark{
-				stack:     append([]viewstate.View{}, m.stack...),
-				crumbs:    append([]string{}, m.crumbs...),
-				namespace: m.namespace,
-				context:   m.context,
+			nav, ok := m.captureNav()
+			if !ok {
+				m.statusMsg = "This screen cannot be bookmarked"
+				return msg, true, nil
 			}
+			m.bookmarks[slot] = &nav
 			m.statusMsg = fmt.Sprintf("Bookmark %d set", slot+1)
+			if err := m.saveSession(m.lastNav); err != nil {
+				m.statusMsg += " (not saved: " + err.Error() + ")"
+			}
+		}
+		return msg, true, nil
+	}
+	// Plugin keys come before the list's own keys; config validation keeps
+	// them off the remappable actions.
+	if p := m.pluginForKey(msg.String()); p != nil {
+		cmd, err := m.runPlugin(p)
+		if err != "" {
+			m.statusMsg = err
 		}
+		return msg, true, cmd
+	}
+	msg, bound := m.remapKey(msg)
+	if !bound {
 		return msg, true, nil
 	}
 
@@ -347,8 +448,7 @@ func (m *Model) handleGlobalKeyMsg(msg bubbletea.KeyMsg) (bubbletea.Msg, bool, b
 	case "q", "ctrl+c":
 		return msg, true, bubbletea.Quit
 	case "esc", "backspace", "h", "left":
-		m.popView()
-		return msg, true, nil
+		return msg, true, m.popView()
 	case "N":
 		items := resources.NamespaceNames()
 		if m.store != nil {
@@ -436,27 +536,12 @@ func (m *Model) handleGlobalKeyMsg(msg bubbletea.KeyMsg) (bubbletea.Msg, bool, b
 			if m.bookmarks[slot] == nil {
 				m.statusMsg = fmt.Sprintf("Bookmark %d not set", slot+1)
 			} else {
-				b := m.bookmarks[slot]
-				m.context = b.context
-				m.namespace = b.namespace
-				m.rememberSingleNamespace(m.namespace)
-				if m.store != nil {
-					scope := m.store.Scope()
-					scope.Context = b.context
-					scope.Namespace = b.namespace
-					m.store.SetScope(scope)
-					m.context = scope.Context
-					m.namespace = scope.Namespace
-					m.rememberSingleNamespace(m.namespace)
-				} else {
-					m.registry.SetNamespace(b.namespace)
-				}
-				m.disposeStack(m.stack)
-				m.stack = append([]viewstate.View{}, b.stack...)
-				m.crumbs = append([]string{}, b.crumbs...)
-				m.top().SetSize(m.width, m.availableHeight())
-				m.activeResourceKey = m.rootResourceKey()
+				cmd, problem := m.restoreNav(*m.bookmarks[slot])
 				m.statusMsg = fmt.Sprintf("Bookmark %d", slot+1)
+				if problem != "" {
+					m.statusMsg += ": " + problem
+				}
+				return msg, true, cmd
 			}
 			return msg, true, nil
 		}
@@ -560,7 +645,7 @@ func (m *Model) applyViewUpdate(update viewstate.Update) bubbletea.Cmd {
 		m.crumbs = append(m.crumbs, normalizeBreadcrumbPart(update.Next.Breadcrumb()))
 		resultCmd = batchCmds(update.Cmd, update.Next.Init())
 	case viewstate.Pop:
-		m.popView()
+		resultCmd = batchCmds(update.Cmd, m.popView())
 	case viewstate.Replace:
 		update.Next.SetSize(m.width, m.availableHeight())
 		m.disposeView(m.stack[len(m.stack)-1])
@@ -575,14 +660,21 @@ func (m *Model) applyViewUpdate(update viewstate.Update) bubbletea.Cmd {
 	return resultCmd
 }
 
-func (m *Model) popView() {
+func (m *Model) popView() bubbletea.Cmd {
 	if len(m.stack) <= 1 {
-		return
+		return nil
 	}
 	m.disposeView(m.stack[len(m.stack)-1])
 	m.stack = m.stack[:len(m.stack)-1]
 	m.crumbs = m.crumbs[:len(m.crumbs)-1]
 	m.crumbs[len(m.crumbs)-1] = normalizeBreadcrumbPart(m.top().Breadcrumb())
+	// Views below the top miss resizes, and restored stacks are built
+	// before the first window size arrives.
+	m.top().SetSize(m.width, m.availableHeight())
+	if r, ok := m.top().(resumer); ok {
+		return r.Resume()
+	}
+	return nil
 }
 
 func (m *Model) openRelatedPicker() {
@@ -721,21 +813,6 @@ func (m Model) top() viewstate.View {
 	return m.stack[len(m.stack)-1]
 }
 
-// rootResourceKey returns the key of the root-level registered resource in the
-// current view stack. It walks from the bottom up to find the first listview
-// whose resource has a non-zero key (i.e. a top-level resource, not a
-// sub-resource like containers). Falls back to m.activeResourceKey.
-func (m Model) rootResourceKey() rune {
-	for i := 0; i < len(m.stack); i++ {
-		if lv, ok := m.stack[i].(*listview.View); ok {
-			if key := lv.Resource().Key(); key != 0 {
-				return key
-			}
-		}
-	}
-	return m.activeResourceKey
-}
-
 // bestResourceForScope returns the best resource to show after a scope change.
 // It walks the view stack from top to bottom looking for a list view, then
 // falls back to workloads ('W'), and finally to the first registered resource.
@@ -758,20 +835,10 @@ func (m Model) bestResourceForScope() resources.ResourceType {
 
 // contextTier classifies a context name for styling purposes.
 // Returns "prod" for production contexts, "local" for local/default contexts,
-// and "remote" for everything else (dev, staging, etc.).
+// and "remote" for everything else (dev, staging, etc.). The name patterns
+// come from the contexts section of the config file.
 func contextTier(name string) string {
-	lower := strings.ToLower(name)
-	if strings.Contains(lower, "prod") {
-		return "prod"
-	}
-	switch lower {
-	case "default", "minikube", "docker-desktop", "rancher-desktop":
-		return "local"
-	}
-	if strings.HasPrefix(lower, "kind-") || strings.HasPrefix(lower, "k3d-") {
-		return "local"
-	}
-	return "remote"
+	return data.ContextTier(name)
 }
 
 func (m Model) scopeLine() string {
@@ -988,15 +1055,11 @@ func (m *Model) runCommand(raw string) string {
 		return "unknown command"
 	}
 	if cmd.selector != "" {
-		if cmd.subview != "" {
-			return "unknown command"
-		}
-		var filtered []resources.ResourceItem
-		for _, it := range m.listResourceItems(res) {
-			if resources.MatchesLabelSelector(it, cmd.selector) {
-				filtered = append(filtered, it)
-			}
+		selector, err := labels.Parse(cmd.selector)
+		if err != nil {
+			return "invalid selector: " + err.Error()
 		}
+		filtered := m.listMatchingItems(res, selector)
 		view := listview.New(resources.NewQueryResource(res.Name(), filtered, res), m.registry)
 		view.SetSize(m.width, m.availableHeight())
 		m.stack = append(m.stack, view)
@@ -1082,18 +1145,29 @@ func detailViewFor(item resources.ResourceItem, res resources.ResourceType, regi
 	return dv
 }
 
+// parseCommand splits "kind [name [subview]]", "kind selector" or
+// "kind -l selector". Everything after the kind is a label selector when it
+// uses selector syntax (=, !=, !, a comma or an in/notin set) or follows -l,
+// which a bare "key" exists selector needs to not read as a name. The
+// selector keeps its case and spacing.
 func parseCommand(raw string) parsedCommand {
-	toks := strings.Fields(strings.ToLower(strings.TrimSpace(raw)))
+	raw = strings.TrimSpace(raw)
+	toks := strings.Fields(strings.ToLower(raw))
 	if len(toks) == 0 {
 		return parsedCommand{}
 	}
 	cmd := parsedCommand{kindToken: toks[0]}
+	rest := strings.TrimSpace(raw[len(strings.Fields(raw)[0]):])
+	if len(toks) >= 2 && toks[1] == "-l" {
+		cmd.selector = strings.TrimSpace(rest[len("-l"):])
+		return cmd
+	}
+	if strings.ContainsAny(rest, "=!,(") {
+		cmd.selector = rest
+		return cmd
+	}
 	if len(toks) >= 2 {
-		if strings.Contains(toks[1], "=") {
-			cmd.selector = toks[1]
-		} else {
-			cmd.name = toks[1]
-		}
+		cmd.name = toks[1]
 	}
 	if len(toks) >= 3 {
 		cmd.subview = toks[2]
@@ -1115,12 +1189,8 @@ func nameMatches(items []resources.ResourceItem, frag string) []resources.Resour
 }
 
 func (m Model) commandResource(token string) resources.ResourceType {
-	aliases := map[string]string{"po": "pods", "pods": "pods", "deploy": "deployments", "deployments": "deployments", "svc": "services", "services": "services", "cm": "configmaps", "configmaps": "configmaps", "secret": "secrets", "sec": "secrets", "secrets": "secrets", "node": "nodes", "nodes": "nodes", "ing": "ingresses", "ingresses": "ingresses", "pvc": "pvcs", "pvcs": "pvcs", "ev": "events", "events": "events", "ns": "namespaces", "namespaces": "namespaces"}
-	name := aliases[token]
-	if name != "" {
-		if res := m.registry.ByName(name); res != nil {
-			return res
-		}
+	if res := m.registry.Lookup(token); res != nil {
+		return res
 	}
 	for _, meta := range resources.StubCRDs() {
 		if matchesCRDToken(token, meta) {
@@ -1151,7 +1221,7 @@ func matchesCRDToken(token string, meta resources.CRDMeta) bool {
 }
 
 func commandKindTokens() []string {
-	base := []string{"po", "deploy", "svc", "cm", "sec", "node", "ing", "pvc", "ev", "ns", "unhealthy", "restarts"}
+	base := []string{"po", "deploy", "svc", "cm", "sec", "node", "ing", "pvc", "ev", "ns", "unhealthy", "restarts", "grep"}
 	seen := make(map[string]bool, len(base))
 	out := make([]string, 0, len(base)+len(resources.StubCRDs()))
 	for _, token := range base {
@@ -1241,6 +1311,26 @@ func (m Model) listResourceItems(res resources.ResourceType) []resources.Resourc
 	return res.Items()
 }
 
+// listMatchingItems lists the items of res whose labels match selector,
+// letting the store filter in its caches when it can.
+func (m Model) listMatchingItems(res resources.ResourceType, selector labels.Selector) []resources.ResourceItem {
+	if m.store != nil {
+		if read := m.store.ReadModel(); read != nil {
+			items, err := data.ListMatching(read, res.Name(), data.Scope{Context: m.context, Namespace: m.namespace}, selector)
+			if err == nil {
+				return items
+			}
+		}
+	}
+	var out []resources.ResourceItem
+	for _, item := range res.Items() {
+		if selector.Matches(labels.Set(item.Labels)) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 func (m Model) adaptResource(res resources.ResourceType) resources.ResourceType {
 	if m.store != nil {
 		return m.store.AdaptResource(res)
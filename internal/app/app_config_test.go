@@ -0,0 +1,141 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/config"
+	"github.com/dloss/podji/internal/data"
+	"github.com/dloss/podji/internal/ui/listview"
+	"github.com/dloss/podji/internal/ui/style"
+)
+
+func newFromConfig(t *testing.T, cfg config.Config) (Model, error) {
+	t.Helper()
+	prev := newStoreFromEnvFn
+	newStoreFromEnvFn = func() (data.Store, error) {
+		return data.NewMockStore(), nil
+	}
+	t.Cleanup(func() {
+		newStoreFromEnvFn = prev
+		applyConfig(config.Default(), data.NewMockStore())
+		style.Apply(style.Dark, lipgloss.ColorProfile())
+	})
+	return NewFromEnvWithConfig(cfg)
+}
+
+func TestNewFromEnvWithConfigStartsOnConfiguredResourceAndNamespace(t *testing.T) {
+	cfg := config.Default()
+	cfg.Resource = "deploy"
+	cfg.Namespace = "kube-system"
+	m, err := newFromConfig(t, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.namespace != "kube-system" {
+		t.Fatalf("expected configured namespace, got %q", m.namespace)
+	}
+	lv, ok := m.top().(*listview.View)
+	if !ok || lv.Resource().Name() != "deployments" {
+		t.Fatalf("expected deployments list, got %T", m.top())
+	}
+}
+
+func TestNewFromEnvWithConfigRejectsUnknownResource(t *testing.T) {
+	cfg := config.Default()
+	cfg.Resource = "gizmos"
+	if _, err := newFromConfig(t, cfg); err == nil {
+		t.Fatal("expected error for unknown start resource")
+	}
+}
+
+func TestRemappedGlobalKeyReplacesDefault(t *testing.T) {
+	cfg := config.Default()
+	cfg.Keys.Global = map[string]string{"namespace": "ctrl+n"}
+	m, err := newFromConfig(t, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, _ := m.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'N'}})
+	if updated.(Model).overlay != nil {
+		t.Fatal("expected N to be unbound after remapping")
+	}
+	updated, _ = m.Update(bubbletea.KeyMsg{Type: bubbletea.KeyCtrlN})
+	if updated.(Model).overlay == nil {
+		t.Fatal("expected ctrl+n to open the namespace overlay")
+	}
+}
+
+func TestThemeFromConfigAppliesColorOverrides(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	cfg := config.Default()
+	cfg.Theme = "light"
+	cfg.Colors = map[string]string{"warning": "208"}
+	if _, err := newFromConfig(t, cfg); err != nil {
+		t.Fatal(err)
+	}
+	theme := style.Current()
+	if theme.Name != style.ThemeLight || theme.Warning.Full != "208" {
+		t.Fatalf("expected light theme with warning override, got %s / %+v", theme.Name, theme.Warning)
+	}
+
+	cfg.Colors = map[string]string{"warnings": "208"}
+	if _, err := newFromConfig(t, cfg); err == nil || !strings.Contains(err.Error(), "colors.warnings") {
+		t.Fatalf("expected unknown color error, got %v", err)
+	}
+}
+
+func TestNoColorSelectsMonoTheme(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	cfg := config.Default()
+	cfg.Theme = "high-contrast"
+	if _, err := newFromConfig(t, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !style.Current().Mono {
+		t.Fatalf("expected mono theme under NO_COLOR, got %s", style.Current().Name)
+	}
+}
+
+func TestRemappedListKeyWorksInFilteredList(t *testing.T) {
+	cfg := config.Default()
+	cfg.Keys.List = map[string]string{"export": "ctrl+x"}
+	m, err := newFromConfig(t, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.width, m.height = 120, 40
+
+	var updated bubbletea.Model = m
+	for _, msg := range []bubbletea.KeyMsg{
+		{Type: bubbletea.KeyRunes, Runes: []rune{'&'}},
+		{Type: bubbletea.KeyRunes, Runes: []rune{'a'}},
+		{Type: bubbletea.KeyEnter},
+		{Type: bubbletea.KeyCtrlX},
+	} {
+		updated, _ = updated.Update(msg)
+	}
+	lv, ok := updated.(Model).top().(*listview.View)
+	if !ok {
+		t.Fatalf("expected to stay in the list, got %T", updated.(Model).top())
+	}
+	if !lv.SuppressGlobalKeys() {
+		t.Fatal("expected the filtered list to suppress global keys")
+	}
+	if footer := ansi.Strip(lv.Footer()); !strings.Contains(footer, "markdown") {
+		t.Fatalf("expected ctrl+x to open the export prompt, got %q", footer)
+	}
+}
+
+func TestRemapOntoResourceHotkeyIsRejected(t *testing.T) {
+	cfg := config.Default()
+	cfg.Keys.List = map[string]string{"describe": "S"}
+	_, err := newFromConfig(t, cfg)
+	if err == nil || !strings.Contains(err.Error(), "services") {
+		t.Fatalf("expected a conflict with the services hotkey, got %v", err)
+	}
+}
@@ -12,8 +12,10 @@ import (
 	"github.com/dloss/podji/internal/ui/describeview"
 	"github.com/dloss/podji/internal/ui/detailview"
 	"github.com/dloss/podji/internal/ui/listview"
+	"github.com/dloss/podji/internal/ui/logview"
 	"github.com/dloss/podji/internal/ui/overlaypicker"
 	"github.com/dloss/podji/internal/ui/viewstate"
+	"github.com/dloss/podji/internal/ui/yamlview"
 )
 
 type overflowView struct{}
@@ -264,6 +266,21 @@ func TestNKeyOpensNamespaceOverlay(t *testing.T) {
 	}
 }
 
+func TestExportKeyOpensExportPromptInUnfilteredList(t *testing.T) {
+	m := New()
+	m.width, m.height = 120, 40
+
+	updated, _ := m.Update(bubbletea.KeyMsg{Type: bubbletea.KeyCtrlE})
+	got := updated.(Model)
+	lv, ok := got.top().(*listview.View)
+	if !ok {
+		t.Fatalf("expected to stay in the list, got %T", got.top())
+	}
+	if footer := ansi.Strip(lv.Footer()); !strings.Contains(footer, "markdown") {
+		t.Fatalf("expected the export prompt, got %q", footer)
+	}
+}
+
 func TestXKeyOpensContextOverlay(t *testing.T) {
 	m := New()
 
@@ -336,6 +353,39 @@ func TestBackNavigationDisposesPoppedView(t *testing.T) {
 	}
 }
 
+type resumeSpyView struct{ keySpyView }
+type resumedMsg struct{}
+
+func (*resumeSpyView) Resume() bubbletea.Cmd {
+	return func() bubbletea.Msg { return resumedMsg{} }
+}
+
+func TestBackNavigationResumesUncoveredView(t *testing.T) {
+	m := New()
+	m.stack = append(m.stack, &resumeSpyView{}, &disposableSpyView{})
+	m.crumbs = append(m.crumbs, "workloads", "logs")
+
+	_, cmd := m.Update(bubbletea.KeyMsg{Type: bubbletea.KeyBackspace})
+	if cmd == nil {
+		t.Fatal("expected a command from the uncovered view")
+	}
+	msgs := []bubbletea.Msg{cmd()}
+	if batch, ok := msgs[0].(bubbletea.BatchMsg); ok {
+		msgs = msgs[:0]
+		for _, c := range batch {
+			if c != nil {
+				msgs = append(msgs, c())
+			}
+		}
+	}
+	for _, msg := range msgs {
+		if _, ok := msg.(resumedMsg); ok {
+			return
+		}
+	}
+	t.Fatalf("expected the uncovered view to resume, got %v", msgs)
+}
+
 func TestPushBatchesNextInitCommand(t *testing.T) {
 	m := Model{
 		stack:     []viewstate.View{initPushView{}},
@@ -730,8 +780,8 @@ func TestBookmarkSetAndJump(t *testing.T) {
 	if afterSet.bookmarks[0] == nil {
 		t.Fatal("expected bookmark 0 to be set")
 	}
-	if len(afterSet.bookmarks[0].stack) == 0 {
-		t.Fatal("expected bookmark to capture the view stack")
+	if afterSet.bookmarks[0].Resource != "workloads" {
+		t.Fatalf("expected bookmark to describe the workloads list, got %+v", afterSet.bookmarks[0])
 	}
 
 	// Navigate to Pods.
@@ -817,3 +867,134 @@ func TestCompositeOverlayKeepsAnchorOnShortHeaderLines(t *testing.T) {
 		t.Fatalf("expected second overlaid line to honor anchor, got %q", lines[1])
 	}
 }
+
+func TestCommandBarGrepPushesHitsAndOpensLogsAtLine(t *testing.T) {
+	m := New()
+	m.width = 120
+	m.height = 40
+
+	updated, cmd := m.Update(commandbar.SubmitMsg{Value: "grep buffer ALLOCATION"})
+	m = updated.(Model)
+	if cmd == nil || m.grepCancel == nil {
+		t.Fatal("expected grep to start in the background")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	lv, ok := m.top().(*listview.View)
+	if !ok {
+		t.Fatalf("expected grep hits list on top, got %T", m.top())
+	}
+	grep, ok := lv.Resource().(*resources.LogGrepResource)
+	if !ok || len(grep.Items()) == 0 {
+		t.Fatalf("expected grep hits resource with items, got %T", lv.Resource())
+	}
+	if got := m.crumbs[len(m.crumbs)-1]; !strings.Contains(got, "grep") {
+		t.Fatalf("expected grep breadcrumb, got %q", got)
+	}
+
+	updated, _ = m.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	m = updated.(Model)
+	if _, ok := m.top().(*logview.View); !ok {
+		t.Fatalf("expected enter on a hit to open logs, got %T", m.top())
+	}
+}
+
+func TestCommandBarGrepRequiresPattern(t *testing.T) {
+	m := New()
+	m.cmdBar = commandbar.New()
+	updated, cmd := m.Update(commandbar.SubmitMsg{Value: "grep"})
+	m = updated.(Model)
+	if cmd != nil || m.cmdBar == nil || !strings.Contains(m.cmdBar.View(""), "usage") {
+		t.Fatal("expected usage error for grep without a pattern")
+	}
+}
+
+func TestCompareMarksThenOpensSideBySideDiff(t *testing.T) {
+	m := New()
+	m.width = 120
+	m.height = 40
+	deployments := resources.NewDeployments()
+	items := deployments.Items()
+
+	updated, _ := m.Update(listview.CompareMsg{Item: items[0], Resource: deployments})
+	m = updated.(Model)
+	if m.compareMark == nil || !strings.Contains(m.statusMsg, "marked") {
+		t.Fatalf("expected first pick to mark, got status %q", m.statusMsg)
+	}
+
+	pods := resources.NewPods()
+	updated, _ = m.Update(listview.CompareMsg{Item: pods.Items()[0], Resource: pods})
+	m = updated.(Model)
+	if !strings.Contains(m.statusMsg, "cannot compare") || m.compareMark == nil {
+		t.Fatalf("expected kind mismatch to keep the mark, got status %q", m.statusMsg)
+	}
+
+	updated, _ = m.Update(listview.CompareMsg{Item: items[1], Resource: deployments})
+	m = updated.(Model)
+	if _, ok := m.top().(*yamlview.CompareView); !ok {
+		t.Fatalf("expected compare view on top, got %T", m.top())
+	}
+	if m.compareMark != nil {
+		t.Fatal("expected the mark to be consumed")
+	}
+	if got := m.crumbs[len(m.crumbs)-1]; !strings.Contains(got, "compare") {
+		t.Fatalf("expected compare breadcrumb, got %q", got)
+	}
+}
+
+func TestParseCommandKeepsSelectorSyntax(t *testing.T) {
+	cases := map[string]parsedCommand{
+		"po app=api":                  {kindToken: "po", selector: "app=api"},
+		"PO tier in (Web, api),!beta": {kindToken: "po", selector: "tier in (Web, api),!beta"},
+		"po !canary":                  {kindToken: "po", selector: "!canary"},
+		"po -l app":                   {kindToken: "po", selector: "app"},
+		"po -l Tier in (web)":         {kindToken: "po", selector: "Tier in (web)"},
+		"po app":                      {kindToken: "po", name: "app"},
+		"deploy API logs":             {kindToken: "deploy", name: "api", subview: "logs"},
+	}
+	for raw, want := range cases {
+		if got := parseCommand(raw); got != want {
+			t.Errorf("parseCommand(%q) = %+v, want %+v", raw, got, want)
+		}
+	}
+}
+
+func TestCommandBarSelectorFiltersByLabels(t *testing.T) {
+	m := New()
+	m.width = 120
+	m.height = 40
+	if err := m.runCommand("pods app in (api,frontend),env!=dev"); err != "" {
+		t.Fatalf("expected no error, got %q", err)
+	}
+	lv, ok := m.top().(*listview.View)
+	if !ok {
+		t.Fatalf("expected filtered list, got %T", m.top())
+	}
+	items := lv.Resource().Items()
+	if len(items) == 0 {
+		t.Fatal("expected matching pods")
+	}
+	for _, item := range items {
+		if app := item.Labels["app"]; app != "api" && app != "frontend" {
+			t.Fatalf("unexpected pod %s with app=%q", item.Name, app)
+		}
+	}
+
+	if err := m.runCommand("pods -l app"); err != "" {
+		t.Fatalf("expected exists selector, got %q", err)
+	}
+	lv = m.top().(*listview.View)
+	if crumb := m.crumbs[len(m.crumbs)-1]; !strings.HasSuffix(crumb, ": app") {
+		t.Fatalf("expected selector breadcrumb, got %q", crumb)
+	}
+	for _, item := range lv.Resource().Items() {
+		if _, ok := item.Labels["app"]; !ok {
+			t.Fatalf("unexpected pod %s without an app label", item.Name)
+		}
+	}
+
+	if err := m.runCommand("pods app in (api"); !strings.HasPrefix(err, "invalid selector") {
+		t.Fatalf("expected selector error, got %q", err)
+	}
+}
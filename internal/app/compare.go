@@ -0,0 +1,66 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/ui/listview"
+	"github.com/dloss/podji/internal/ui/yamlview"
+)
+
+// compareMark is the first object of a pending comparison. Its YAML is read
+// when it is marked, so the mark survives namespace and context switches.
+type compareMark struct {
+	kind string
+	side yamlview.CompareSide
+}
+
+// handleCompare marks the first item, then opens the comparison when an item
+// of the same kind is picked. Picking the marked item again clears the mark.
+func (m *Model) handleCompare(msg listview.CompareMsg) {
+	kind := strings.ToLower(msg.Item.Kind)
+	if kind == "" {
+		kind = resources.SingularName(msg.Resource.Name())
+	}
+	side := yamlview.CompareSide{
+		Label: m.compareLabel(kind, msg.Item, msg.Resource),
+		YAML:  msg.Resource.YAML(msg.Item),
+	}
+
+	mark := m.compareMark
+	switch {
+	case mark == nil:
+		m.compareMark = &compareMark{kind: kind, side: side}
+		m.statusMsg = "compare: marked " + side.Label + "; press = on another " + kind
+		return
+	case mark.side.Label == side.Label:
+		m.compareMark = nil
+		m.statusMsg = "compare: mark cleared"
+		return
+	case mark.kind != kind:
+		m.statusMsg = "compare: cannot compare " + kind + " with marked " + mark.kind
+		return
+	}
+
+	m.compareMark = nil
+	view := yamlview.NewCompare(mark.side, side)
+	view.SetSize(m.width, m.availableHeight())
+	m.stack = append(m.stack, view)
+	m.crumbs = append(m.crumbs, normalizeBreadcrumbPart("compare: "+kind))
+}
+
+// compareLabel names an object with the context and namespace it was read
+// from, since the two sides may come from different clusters.
+func (m *Model) compareLabel(kind string, item resources.ResourceItem, res resources.ResourceType) string {
+	ns := item.Namespace
+	if ns == "" {
+		if scoped, ok := res.(resources.NamespaceScoped); ok {
+			ns = scoped.Namespace()
+		}
+	}
+	where := m.context
+	if ns != "" && ns != resources.AllNamespaces {
+		where += "/" + ns
+	}
+	return kind + "/" + item.Name + " (" + where + ")"
+}
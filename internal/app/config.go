@@ -0,0 +1,155 @@
+package app
+
+import (
+	"fmt"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dloss/podji/internal/config"
+	"github.com/dloss/podji/internal/data"
+	"github.com/dloss/podji/internal/ui/listview"
+	"github.com/dloss/podji/internal/ui/logview"
+	"github.com/dloss/podji/internal/ui/style"
+	"github.com/dloss/podji/internal/ui/viewstate"
+	"github.com/muesli/termenv"
+)
+
+// NewFromEnvWithConfig is NewFromEnv with the user's configuration applied:
+// the start namespace and resource, view defaults, context tiers,
+// confirmation policies, key bindings, the color theme and plugins.
+func NewFromEnvWithConfig(cfg config.Config) (Model, error) {
+	store, err := newStoreFromEnvFn()
+	if err != nil {
+		return Model{}, err
+	}
+	applyConfig(cfg, store)
+	if err := applyTheme(cfg); err != nil {
+		return Model{}, fmt.Errorf("config: %w", err)
+	}
+	if cfg.Namespace != "" {
+		scope := store.Scope()
+		scope.Namespace = cfg.Namespace
+		store.SetScope(scope)
+	}
+	model := NewWithStore(store)
+	keys, err := cfg.Keys.Keymap()
+	if err != nil {
+		return Model{}, fmt.Errorf("config: %w", err)
+	}
+	if err := cfg.Keys.CheckResourceKeys(model.resourceHotkeys()); err != nil {
+		return Model{}, fmt.Errorf("config: %w", err)
+	}
+	model.keys = keys
+	if err := model.loadPlugins(cfg.Plugins); err != nil {
+		return Model{}, fmt.Errorf("config: %w", err)
+	}
+	if cfg.Resource != "" {
+		res := model.registry.Lookup(cfg.Resource)
+		if res == nil {
+			return Model{}, fmt.Errorf("config: resource: unknown resource %q", cfg.Resource)
+		}
+		root := listview.New(model.adaptResource(res), model.registry)
+		model.disposeStack(model.stack)
+		model.stack = []viewstate.View{root}
+		model.crumbs = []string{normalizeBreadcrumbPart(root.Breadcrumb())}
+		model.activeResourceKey = res.Key()
+	}
+	return model, nil
+}
+
+// applyConfig sets the package-level defaults of the views and the data
+// layer. Confirmation policies follow the store's current context.
+func applyConfig(cfg config.Config, store data.Store) {
+	data.SetContextTierPatterns(cfg.Contexts.Prod, cfg.Contexts.Local)
+	logview.Defaults = logview.Options{
+		Since:      cfg.Logs.Since,
+		Follow:     cfg.Logs.Follow,
+		Wrap:       cfg.Logs.Wrap,
+		Timestamps: cfg.Logs.Timestamps,
+	}
+	listview.DefaultDebugImage = cfg.DebugImage
+	listview.ConfirmAction = func(action string) bool {
+		return cfg.Confirm.Required(action, data.ProtectedContext(store.Scope().Context))
+	}
+}
+
+// applyTheme selects the configured theme and color overrides. NO_COLOR
+// wins over the config file and selects the mono theme; auto follows the
+// terminal background.
+func applyTheme(cfg config.Config) error {
+	name := cfg.Theme
+	switch {
+	case termenv.EnvNoColor():
+		name = style.ThemeMono
+	case name == config.ThemeAuto:
+		name = style.ThemeDark
+		if !lipgloss.HasDarkBackground() {
+			name = style.ThemeLight
+		}
+	}
+	theme, ok := style.ThemeNamed(name)
+	if !ok {
+		return fmt.Errorf("theme: unknown theme %q", name)
+	}
+	if !termenv.EnvNoColor() {
+		var err error
+		if theme, err = theme.Override(cfg.Colors); err != nil {
+			return err
+		}
+	}
+	style.Apply(theme, lipgloss.ColorProfile())
+	return nil
+}
+
+// keyScope returns the key binding scope of a view.
+func keyScope(view viewstate.View) string {
+	switch view.(type) {
+	case *listview.View:
+		return config.ScopeList
+	case *logview.View:
+		return config.ScopeLogs
+	}
+	return config.ScopeGlobal
+}
+
+// remapKey translates a pressed key through the configured bindings of the
+// top view. It reports false for a default key whose action was moved.
+func (m *Model) remapKey(msg bubbletea.KeyMsg) (bubbletea.KeyMsg, bool) {
+	pressed := msg.String()
+	switch key := m.keys.Translate(keyScope(m.top()), pressed); key {
+	case pressed:
+		return msg, true
+	case "":
+		return msg, false
+	default:
+		return bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune(key)}, true
+	}
+}
+
+// resourceHotkeys maps the keys that switch to a resource list to the
+// resource's name. Keys a global action claims first, such as N for the
+// namespace picker, are left out.
+func (m *Model) resourceHotkeys() map[string]string {
+	claimed := map[string]bool{}
+	for _, key := range config.DefaultKeys[config.ScopeGlobal] {
+		claimed[key] = true
+	}
+	out := map[string]string{}
+	for _, res := range m.registry.Resources() {
+		if key := string(res.Key()); res.Key() != 0 && !claimed[key] {
+			out[key] = res.Name()
+		}
+	}
+	return out
+}
+
+// translateSuppressedKey remaps a key for a view that suppresses global keys
+// only because it is filtered or shows search matches, so remapped actions
+// keep working there. Views taking keys literally, such as a prompt being
+// typed into, get them untranslated.
+func (m *Model) translateSuppressedKey(msg bubbletea.KeyMsg) (bubbletea.KeyMsg, bool) {
+	if capturer, ok := m.top().(keyCapturer); ok && capturer.CapturesKeys() {
+		return msg, true
+	}
+	return m.remapKey(msg)
+}
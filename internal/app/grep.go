@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/dloss/podji/internal/data"
+	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/ui/listview"
+)
+
+// grepTimeout caps a cross-pod grep; slow or stuck log requests are cut off
+// rather than holding the search open.
+const grepTimeout = 30 * time.Second
+
+type grepResultMsg struct {
+	id      int
+	pattern string
+	result  data.LogGrepResult
+	err     error
+}
+
+// grepCommand recognises ":grep <pattern>". The pattern keeps its case and
+// spacing, unlike the other commands which are lowercased and tokenised.
+func grepCommand(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	head, rest, _ := strings.Cut(raw, " ")
+	if !strings.EqualFold(head, "grep") {
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
+
+// startGrep searches recent logs of every pod in scope in the background. A
+// new grep or esc cancels the one in flight.
+func (m *Model) startGrep(pattern string) bubbletea.Cmd {
+	m.cancelGrep()
+	ctx, cancel := context.WithTimeout(context.Background(), grepTimeout)
+	m.grepCancel = cancel
+	m.grepID++
+	id := m.grepID
+	read := m.store.ReadModel()
+	scope := m.store.Scope()
+	m.statusMsg = "grep: searching pod logs for " + pattern + " (esc cancels)"
+	return func() bubbletea.Msg {
+		result, err := data.GrepLogs(ctx, read, scope, pattern)
+		return grepResultMsg{id: id, pattern: pattern, result: result, err: err}
+	}
+}
+
+func (m *Model) cancelGrep() {
+	if m.grepCancel != nil {
+		m.grepCancel()
+		m.grepCancel = nil
+	}
+}
+
+func (m *Model) handleGrepResult(msg grepResultMsg) {
+	if msg.id != m.grepID {
+		return
+	}
+	m.cancelGrep()
+	if msg.err != nil {
+		switch {
+		case errors.Is(msg.err, context.Canceled):
+		case errors.Is(msg.err, context.DeadlineExceeded):
+			m.statusMsg = "grep timed out"
+		default:
+			m.statusMsg = "grep failed: " + msg.err.Error()
+		}
+		return
+	}
+	if len(msg.result.Hits) == 0 {
+		m.statusMsg = fmt.Sprintf("grep: no matches in %d pods", msg.result.Pods)
+		if msg.result.Skipped > 0 {
+			m.statusMsg += fmt.Sprintf(" (%d unreadable)", msg.result.Skipped)
+		}
+		return
+	}
+	pods := m.adaptResource(m.registry.ByName("pods"))
+	view := listview.New(resources.NewLogGrepResource(msg.pattern, msg.result.Hits, pods), m.registry)
+	view.SetSize(m.width, m.availableHeight())
+	m.stack = append(m.stack, view)
+	m.crumbs = append(m.crumbs, normalizeBreadcrumbPart("grep: "+msg.pattern))
+	if msg.result.Skipped > 0 {
+		m.statusMsg = fmt.Sprintf("grep: %d pods could not be read", msg.result.Skipped)
+	}
+}
@@ -0,0 +1,211 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/dloss/podji/internal/columnconfig"
+	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/session"
+	"github.com/dloss/podji/internal/ui/describeview"
+	"github.com/dloss/podji/internal/ui/detailview"
+	"github.com/dloss/podji/internal/ui/eventview"
+	"github.com/dloss/podji/internal/ui/listview"
+	"github.com/dloss/podji/internal/ui/logview"
+	"github.com/dloss/podji/internal/ui/viewstate"
+	"github.com/dloss/podji/internal/ui/yamlview"
+)
+
+// captureNav describes the current screen as a session.Nav. The stack must
+// start at a registry resource list; it is described up to the first view
+// that cannot be reopened by a key, such as one opened from the related
+// picker. It reports false when even the root cannot be described.
+func (m Model) captureNav() (session.Nav, bool) {
+	root, ok := m.stack[0].(*listview.View)
+	if !ok || m.registry.ByName(root.Resource().Name()) == nil {
+		return session.Nav{}, false
+	}
+	nav := session.Nav{Context: m.context, Namespace: m.namespace, Resource: root.Resource().Name()}
+	for i, view := range m.stack {
+		var step session.Step
+		if lv, ok := view.(*listview.View); ok {
+			if item := lv.SelectedItem(); item.Name != "" {
+				step.Item = session.ItemOf(item)
+			}
+		}
+		if i+1 < len(m.stack) {
+			next := m.stack[i+1]
+			step.Key = openKey(view, next)
+			if step.Key != "" {
+				step.View = next.Breadcrumb()
+			}
+		}
+		if step.Item.Name != "" || step.Key != "" {
+			nav.Steps = append(nav.Steps, step)
+		}
+		if step.Key == "" {
+			break
+		}
+	}
+	return nav, true
+}
+
+// openKey returns the key that opens next from view, or "" when next was not
+// opened by a key of view.
+func openKey(view, next viewstate.View) string {
+	_, fromList := view.(*listview.View)
+	_, fromDetail := view.(*detailview.View)
+	if !fromList && !fromDetail {
+		return ""
+	}
+	switch next.(type) {
+	case *yamlview.View:
+		return "y"
+	case *eventview.View:
+		return "e"
+	case *describeview.View:
+		return "d"
+	case *detailview.View:
+		if fromList {
+			return "enter"
+		}
+	case *logview.View:
+		if fromDetail || opensLogsWithO(view) {
+			return "o"
+		}
+		return "enter"
+	case *listview.View:
+		if fromDetail {
+			return "o"
+		}
+		return "enter"
+	}
+	return ""
+}
+
+// opensLogsWithO reports whether a list opens logs with o rather than enter:
+// workload lists open their pods on enter.
+func opensLogsWithO(view viewstate.View) bool {
+	lv, ok := view.(*listview.View)
+	if !ok {
+		return false
+	}
+	if _, ok := lv.Resource().(*resources.CronJobJobs); ok {
+		return true
+	}
+	return strings.EqualFold(lv.Resource().Name(), "workloads")
+}
+
+// restoreNav rebuilds the view stack described by nav. It stops at the first
+// step that cannot be replayed, such as an item that no longer exists, and
+// returns a description of the problem for the status line.
+func (m *Model) restoreNav(nav session.Nav) (bubbletea.Cmd, string) {
+	res := m.registry.ByName(nav.Resource)
+	if res == nil {
+		return nil, nav.Resource + " is not available"
+	}
+	m.applyScope(nav.Context, nav.Namespace)
+	root := listview.New(m.adaptResource(res), m.registry)
+	root.SetSize(m.width, m.availableHeight())
+	m.disposeStack(m.stack)
+	m.stack = []viewstate.View{root}
+	m.crumbs = []string{normalizeBreadcrumbPart(root.Breadcrumb())}
+	m.activeResourceKey = res.Key()
+
+	var cmd bubbletea.Cmd
+	for _, step := range nav.Steps {
+		if step.Item.Name != "" {
+			lv, ok := m.top().(*listview.View)
+			if !ok || !lv.SelectItem(step.Item.Matches) {
+				return cmd, step.Item.String() + " no longer exists"
+			}
+		}
+		if step.Key == "" {
+			break
+		}
+		update := m.top().Update(navKeyMsg(step.Key))
+		if update.Action != viewstate.Push || update.Next == nil || (step.View != "" && update.Next.Breadcrumb() != step.View) {
+			if update.Next != nil && update.Next != m.top() {
+				m.disposeView(update.Next)
+			}
+			return cmd, fmt.Sprintf("could not reopen %q", step.View)
+		}
+		cmd = batchCmds(cmd, m.applyViewUpdate(update))
+	}
+	return cmd, ""
+}
+
+func navKeyMsg(key string) bubbletea.KeyMsg {
+	if key == "enter" {
+		return bubbletea.KeyMsg{Type: bubbletea.KeyEnter}
+	}
+	return bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune(key)}
+}
+
+// applyScope switches the store to a context and namespace, as the pickers
+// and bookmarks do.
+func (m *Model) applyScope(context, namespace string) {
+	m.context = context
+	m.namespace = namespace
+	if m.store != nil {
+		scope := m.store.Scope()
+		scope.Context = context
+		scope.Namespace = namespace
+		m.store.SetScope(scope)
+		m.context = scope.Context
+		m.namespace = scope.Namespace
+	} else {
+		m.registry.SetNamespace(namespace)
+	}
+	m.rememberSingleNamespace(m.namespace)
+	columnconfig.Default().SetContext(m.context)
+	m.syncStoreStatus()
+}
+
+// OpenSession loads bookmarks and the last screen from the session file at
+// path and saves bookmarks there from now on. With restore, the last screen
+// is reopened; problems doing so are shown in the status line.
+func (m *Model) OpenSession(path string, restore bool) error {
+	f, err := session.Load(path)
+	if err != nil {
+		return err
+	}
+	m.sessionPath = path
+	for slot, nav := range f.Bookmarks {
+		m.bookmarks[slot-1] = &nav
+	}
+	m.lastNav = f.Last
+	if restore && f.Last != nil {
+		if _, problem := m.restoreNav(*f.Last); problem != "" {
+			m.statusMsg = "Last session: " + problem
+		}
+	}
+	return nil
+}
+
+// SaveSession records the current screen as the last session, together with
+// the bookmarks. It does nothing without a session file.
+func (m Model) SaveSession() error {
+	nav, ok := m.captureNav()
+	if !ok {
+		return m.saveSession(m.lastNav)
+	}
+	return m.saveSession(&nav)
+}
+
+func (m Model) saveSession(last *session.Nav) error {
+	if m.sessionPath == "" {
+		return nil
+	}
+	f := session.File{Last: last}
+	for i, nav := range m.bookmarks {
+		if nav != nil {
+			if f.Bookmarks == nil {
+				f.Bookmarks = map[int]session.Nav{}
+			}
+			f.Bookmarks[i+1] = *nav
+		}
+	}
+	return session.Save(m.sessionPath, f)
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/dloss/podji/internal/ui/listview"
+	"github.com/dloss/podji/internal/ui/yamlview"
+)
+
+func pressKeys(m Model, keys ...bubbletea.KeyMsg) Model {
+	for _, key := range keys {
+		updated, _ := m.Update(key)
+		m = updated.(Model)
+	}
+	return m
+}
+
+func runeKey(r rune) bubbletea.KeyMsg {
+	return bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestCaptureAndRestoreNavRebuildsStack(t *testing.T) {
+	m := pressKeys(New(), runeKey('P'), bubbletea.KeyMsg{Type: bubbletea.KeyDown}, runeKey('y'))
+	if _, ok := m.top().(*yamlview.View); !ok {
+		t.Fatalf("expected yaml view, got %T", m.top())
+	}
+	selected := m.stack[0].(*listview.View).SelectedItem()
+
+	nav, ok := m.captureNav()
+	if !ok {
+		t.Fatal("expected screen to be describable")
+	}
+	if nav.Resource != "pods" || len(nav.Steps) != 1 || nav.Steps[0].Key != "y" || nav.Steps[0].Item.Name != selected.Name {
+		t.Fatalf("unexpected descriptor %+v", nav)
+	}
+
+	restored := New()
+	if _, problem := restored.restoreNav(nav); problem != "" {
+		t.Fatalf("unexpected problem: %s", problem)
+	}
+	if _, ok := restored.top().(*yamlview.View); !ok || len(restored.stack) != 2 {
+		t.Fatalf("expected pods > yaml stack, got %d views ending in %T", len(restored.stack), restored.top())
+	}
+	if got := restored.stack[0].(*listview.View).SelectedItem().Name; got != selected.Name {
+		t.Fatalf("expected %q selected, got %q", selected.Name, got)
+	}
+}
+
+func TestRestoreNavReportsMissingItem(t *testing.T) {
+	m := pressKeys(New(), runeKey('P'), bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	nav, _ := m.captureNav()
+	nav.Steps[0].Item.Name = "gone-123"
+
+	restored := New()
+	_, problem := restored.restoreNav(nav)
+	if !strings.Contains(problem, "gone-123 no longer exists") {
+		t.Fatalf("expected missing item to be reported, got %q", problem)
+	}
+	lv, ok := restored.top().(*listview.View)
+	if !ok || lv.Resource().Name() != "pods" || len(restored.stack) != 1 {
+		t.Fatalf("expected to stop on the pods list, got %T", restored.top())
+	}
+}
+
+func TestBookmarksAndLastScreenPersistInSessionFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.yaml")
+	m := New()
+	if err := m.OpenSession(path, false); err != nil {
+		t.Fatal(err)
+	}
+	m = pressKeys(m, runeKey('D'), runeKey('m'), runeKey('3'), runeKey('P'))
+	if err := m.SaveSession(); err != nil {
+		t.Fatal(err)
+	}
+
+	next := New()
+	if err := next.OpenSession(path, true); err != nil {
+		t.Fatal(err)
+	}
+	if lv, ok := next.top().(*listview.View); !ok || lv.Resource().Name() != "pods" {
+		t.Fatalf("expected last screen restored to pods, got %T", next.top())
+	}
+	next = pressKeys(next, runeKey('3'))
+	if lv, ok := next.top().(*listview.View); !ok || lv.Resource().Name() != "deployments" {
+		t.Fatalf("expected bookmark 3 to open deployments, got %T", next.top())
+	}
+}
@@ -0,0 +1,162 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/dloss/podji/internal/config"
+	"github.com/dloss/podji/internal/plugin"
+	"github.com/dloss/podji/internal/ui/listview"
+	"github.com/dloss/podji/internal/ui/outputview"
+)
+
+// pluginTimeout caps a background plugin; one that hangs is killed rather
+// than left running unseen.
+const pluginTimeout = 2 * time.Minute
+
+// builtinCommands are the command bar words that are not resource names.
+var builtinCommands = map[string]bool{"q": true, "quit": true, "grep": true, "unhealthy": true, "restarts": true}
+
+type pluginDoneMsg struct {
+	name string
+	err  error
+}
+
+type pluginOutputMsg struct {
+	id      int
+	name    string
+	command string
+	output  []byte
+	err     error
+}
+
+// loadPlugins compiles the configured plugins. A plugin name may not shadow
+// a built-in command or a resource, since both are typed at the command bar,
+// and its key may not shadow a resource hotkey, since plugin keys run first.
+func (m *Model) loadPlugins(plugins []config.Plugin) error {
+	m.plugins = nil
+	hotkeys := m.resourceHotkeys()
+	for _, p := range plugins {
+		if builtinCommands[p.Name] || m.registry.Lookup(p.Name) != nil {
+			return fmt.Errorf("plugins.%s.name: shadows the built-in :%s command", p.Name, p.Name)
+		}
+		if name, ok := hotkeys[p.Key]; ok {
+			return fmt.Errorf("plugins.%s.key: %q is also bound to the %s list", p.Name, p.Key, name)
+		}
+		compiled, err := plugin.Compile(p, m.registry)
+		if err != nil {
+			return err
+		}
+		m.plugins = append(m.plugins, compiled)
+	}
+	return nil
+}
+
+// pluginForKey returns the plugin bound to key for the top view, which must
+// be a list with a selected item.
+func (m *Model) pluginForKey(key string) *plugin.Plugin {
+	lv, ok := m.top().(*listview.View)
+	if !ok || lv.SelectedItem().Name == "" {
+		return nil
+	}
+	for _, p := range m.plugins {
+		if p.Key == key && p.Applies(lv.Resource()) {
+			return p
+		}
+	}
+	return nil
+}
+
+// pluginNamed returns the plugin run by ":name".
+func (m *Model) pluginNamed(name string) *plugin.Plugin {
+	for _, p := range m.plugins {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// runPlugin runs p on the item selected in the top list. Foreground plugins
+// take over the terminal; background ones report their output in a view. The
+// returned string is an error for the command bar.
+func (m *Model) runPlugin(p *plugin.Plugin) (bubbletea.Cmd, string) {
+	lv, ok := m.top().(*listview.View)
+	if !ok || !p.Applies(lv.Resource()) {
+		return nil, p.Name + " does not apply to this list"
+	}
+	item := lv.SelectedItem()
+	if item.Name == "" {
+		return nil, "nothing selected"
+	}
+	args, err := p.Args(plugin.VarsFor(item, lv.Resource(), m.context, m.namespace))
+	if err != nil {
+		return nil, p.Name + ": " + err.Error()
+	}
+	if !p.Background {
+		c := exec.Command(p.Command, args...)
+		return bubbletea.ExecProcess(c, func(err error) bubbletea.Msg {
+			return pluginDoneMsg{name: p.Name, err: err}
+		}), ""
+	}
+	m.cancelPlugin()
+	ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+	m.pluginCancel = cancel
+	m.pluginID++
+	id := m.pluginID
+	line := plugin.CommandLine(p.Command, args)
+	m.statusMsg = p.Name + ": running " + line + " (esc cancels)"
+	return func() bubbletea.Msg {
+		output, err := exec.CommandContext(ctx, p.Command, args...).CombinedOutput()
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return pluginOutputMsg{id: id, name: p.Name, command: line, output: output, err: err}
+	}, ""
+}
+
+func (m *Model) cancelPlugin() {
+	if m.pluginCancel != nil {
+		m.pluginCancel()
+		m.pluginCancel = nil
+	}
+}
+
+func (m *Model) handlePluginDone(msg pluginDoneMsg) {
+	if msg.err != nil {
+		m.statusMsg = msg.name + " failed: " + msg.err.Error()
+		return
+	}
+	m.statusMsg = msg.name + " finished"
+}
+
+func (m *Model) handlePluginOutput(msg pluginOutputMsg) {
+	if msg.id != m.pluginID {
+		return
+	}
+	m.cancelPlugin()
+	switch {
+	case errors.Is(msg.err, context.Canceled):
+		return
+	case errors.Is(msg.err, context.DeadlineExceeded):
+		m.statusMsg = msg.name + " timed out"
+		return
+	}
+	var exitErr *exec.ExitError
+	if msg.err != nil && !errors.As(msg.err, &exitErr) {
+		// The command could not be started, so there is no output to show.
+		m.statusMsg = msg.name + " failed: " + msg.err.Error()
+		return
+	}
+	view := outputview.New(msg.name, msg.command, msg.output, msg.err)
+	view.SetSize(m.width, m.availableHeight())
+	m.stack = append(m.stack, view)
+	m.crumbs = append(m.crumbs, normalizeBreadcrumbPart(msg.name))
+	if msg.err != nil {
+		m.statusMsg = msg.name + ": " + msg.err.Error()
+	}
+}
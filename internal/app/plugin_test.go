@@ -0,0 +1,110 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/dloss/podji/internal/config"
+	"github.com/dloss/podji/internal/ui/commandbar"
+	"github.com/dloss/podji/internal/ui/listview"
+	"github.com/dloss/podji/internal/ui/outputview"
+)
+
+func pluginModel(t *testing.T, plugins ...config.Plugin) Model {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Resource = "pods"
+	cfg.Plugins = plugins
+	m, err := newFromConfig(t, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.width, m.height = 120, 40
+	return m
+}
+
+func TestBackgroundPluginKeyShowsOutput(t *testing.T) {
+	m := pluginModel(t, config.Plugin{
+		Name: "hello", Key: "H", Kinds: []string{"pod"}, Background: true,
+		Command: "echo", Args: []string{"{{.Namespace}}/{{.Name}}"},
+	})
+	selected := m.top().(*listview.View).SelectedItem()
+
+	updated, cmd := m.Update(runeKey('H'))
+	m = updated.(Model)
+	if cmd == nil || !strings.Contains(m.statusMsg, "esc cancels") {
+		t.Fatalf("expected plugin to start, status %q", m.statusMsg)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	view, ok := m.top().(*outputview.View)
+	if !ok {
+		t.Fatalf("expected output view, got %T (status %q)", m.top(), m.statusMsg)
+	}
+	if !strings.Contains(view.View(), m.namespace+"/"+selected.Name) {
+		t.Fatalf("expected echoed item in output, got %q", view.View())
+	}
+	if m.crumbs[len(m.crumbs)-1] != "hello" {
+		t.Fatalf("expected plugin breadcrumb, got %v", m.crumbs)
+	}
+}
+
+func TestPluginKeyOnlyAppliesToItsKinds(t *testing.T) {
+	m := pluginModel(t, config.Plugin{Name: "hello", Key: "H", Kinds: []string{"svc"}, Command: "echo"})
+	if p := m.pluginForKey("H"); p != nil {
+		t.Fatalf("expected no plugin for pods, got %s", p.Name)
+	}
+}
+
+func TestPluginCommandRunsFromCommandBar(t *testing.T) {
+	m := pluginModel(t, config.Plugin{Name: "hello", Kinds: []string{"*"}, Background: true, Command: "echo", Args: []string{"hi"}})
+	m.cmdBar = commandbar.New()
+
+	updated, cmd := m.Update(commandbar.SubmitMsg{Value: "hello"})
+	m = updated.(Model)
+	if m.cmdBar != nil || cmd == nil {
+		t.Fatal("expected :hello to close the command bar and run the plugin")
+	}
+	updated, _ = m.Update(cmd())
+	if _, ok := updated.(Model).top().(*outputview.View); !ok {
+		t.Fatalf("expected output view, got %T", updated.(Model).top())
+	}
+}
+
+func TestCanceledPluginShowsNothing(t *testing.T) {
+	m := pluginModel(t, config.Plugin{Name: "slow", Key: "H", Kinds: []string{"pods"}, Background: true, Command: "sleep", Args: []string{"5"}})
+	updated, cmd := m.Update(runeKey('H'))
+	m = updated.(Model)
+	updated, _ = m.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEsc})
+	m = updated.(Model)
+	if m.statusMsg != "plugin canceled" {
+		t.Fatalf("expected esc to cancel the plugin, status %q", m.statusMsg)
+	}
+	updated, _ = m.Update(cmd())
+	if _, ok := updated.(Model).top().(*listview.View); !ok {
+		t.Fatalf("expected to stay on the list, got %T", updated.(Model).top())
+	}
+}
+
+func TestPluginNameMayNotShadowCommands(t *testing.T) {
+	for _, name := range []string{"grep", "pods", "deploy"} {
+		cfg := config.Default()
+		cfg.Plugins = []config.Plugin{{Name: name, Kinds: []string{"*"}, Command: "true"}}
+		_, err := newFromConfig(t, cfg)
+		if err == nil || !strings.Contains(err.Error(), "shadows the built-in :"+name) {
+			t.Errorf("plugin %q: expected shadowing error, got %v", name, err)
+		}
+	}
+}
+
+func TestPluginKeyMayNotShadowResourceHotkeys(t *testing.T) {
+	for key, name := range map[string]string{"S": "services", "D": "deployments", "W": "workloads"} {
+		cfg := config.Default()
+		cfg.Plugins = []config.Plugin{{Name: "tool", Key: key, Kinds: []string{"*"}, Command: "true"}}
+		_, err := newFromConfig(t, cfg)
+		if err == nil || !strings.Contains(err.Error(), "also bound to the "+name+" list") {
+			t.Errorf("key %q: expected a conflict with the %s hotkey, got %v", key, name, err)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+// Package cli implements podji's non-interactive subcommands. They read
+// through the same data.Store as the TUI, so scripts and CI jobs see the
+// same items, columns and health rules as the list views.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dloss/podji/internal/data"
+	"github.com/dloss/podji/internal/resources"
+)
+
+// Exit codes returned by Run.
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(env *env, args []string) error
+}
+
+var commands = []command{
+	{name: "get", usage: "get <kind> [name]", summary: "List resources of a kind, or one resource by name", run: runGet},
+	{name: "unhealthy", usage: "unhealthy", summary: "List unhealthy pods, deployments and PVCs", run: runUnhealthy},
+	{name: "restarts", usage: "restarts", summary: "List pods with restarts, most restarts first", run: runRestarts},
+}
+
+// IsCommand reports whether name is a subcommand.
+func IsCommand(name string) bool {
+	_, ok := lookupCommand(name)
+	return ok
+}
+
+// PrintCommands writes the subcommand summary for the top-level usage text.
+func PrintCommands(w io.Writer) {
+	for _, cmd := range commands {
+		_, _ = fmt.Fprintf(w, "  %-30s%s\n", cmd.usage, cmd.summary)
+	}
+}
+
+func lookupCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// errUsage marks errors caused by bad arguments; they exit with ExitUsage.
+var errUsage = errors.New("usage")
+
+// env is what a subcommand runs against.
+type env struct {
+	newStore func() (data.Store, error)
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+// Run executes the subcommand named by args[0] with the remaining args and
+// returns the process exit code. newStore is only called once the arguments
+// are valid.
+func Run(args []string, newStore func() (data.Store, error), stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprintln(stderr, "podji: missing command")
+		return ExitUsage
+	}
+	cmd, ok := lookupCommand(args[0])
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "podji: unknown command %q\n", args[0])
+		return ExitUsage
+	}
+	err := cmd.run(&env{newStore: newStore, stdout: stdout, stderr: stderr}, args[1:])
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.Is(err, errUsage):
+		_, _ = fmt.Fprintf(stderr, "podji %s: %s\nUsage: podji %s [flags]\n", cmd.name, strings.TrimPrefix(err.Error(), errUsage.Error()+": "), cmd.usage)
+		return ExitUsage
+	default:
+		_, _ = fmt.Fprintf(stderr, "podji %s: %v\n", cmd.name, err)
+		return ExitError
+	}
+}
+
+func usageErrorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+// options are the flags shared by all subcommands.
+type options struct {
+	output        string
+	namespace     string
+	allNamespaces bool
+	context       string
+}
+
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *options) {
+	opts := &options{output: "table"}
+	fs := flag.NewFlagSet("podji "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.output, "o", opts.output, "output format: table, wide, json or yaml")
+	fs.StringVar(&opts.output, "output", opts.output, "output format: table, wide, json or yaml")
+	fs.StringVar(&opts.namespace, "n", "", "namespace (default: the store's namespace)")
+	fs.StringVar(&opts.namespace, "namespace", "", "namespace (default: the store's namespace)")
+	fs.BoolVar(&opts.allNamespaces, "A", false, "all namespaces")
+	fs.BoolVar(&opts.allNamespaces, "all-namespaces", false, "all namespaces")
+	fs.StringVar(&opts.context, "context", "", "kubeconfig context (default: the current context)")
+	return fs, opts
+}
+
+// parseArgs parses flags before, between and after positional arguments, so
+// "get pods api -o json" works like kubectl.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, usageErrorf("%v", err)
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func (o *options) validate() error {
+	switch o.output {
+	case "table", "wide", "json", "yaml":
+	default:
+		return usageErrorf("unknown output format %q (want table, wide, json or yaml)", o.output)
+	}
+	if o.allNamespaces && o.namespace != "" {
+		return usageErrorf("-n and -A are mutually exclusive")
+	}
+	return nil
+}
+
+// openStore creates the store and applies the context and namespace flags.
+func (e *env) openStore(opts *options) (data.Store, error) {
+	store, err := e.newStore()
+	if err != nil {
+		return nil, err
+	}
+	scope := store.Scope()
+	if opts.context != "" {
+		scope.Context = opts.context
+	}
+	switch {
+	case opts.allNamespaces:
+		scope.Namespace = resources.AllNamespaces
+	case opts.namespace != "":
+		scope.Namespace = opts.namespace
+	}
+	store.SetScope(scope)
+	return store, nil
+}
+
+// checkStatus turns a failed store read into an error. Partial data is
+// reported on stderr but still printed.
+func (e *env) checkStatus(store data.Store) error {
+	status := store.Status()
+	switch status.State {
+	case data.StoreStateForbidden, data.StoreStateUnreachable, data.StoreStateDegraded:
+		return fmt.Errorf("%s: %s", status.State, status.Message)
+	case data.StoreStatePartial:
+		_, _ = fmt.Fprintf(e.stderr, "warning: %s\n", status.Message)
+	}
+	return nil
+}
+
+func runGet(e *env, args []string) error {
+	fs, opts := newFlagSet("get", e.stderr)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	if len(positional) == 0 || len(positional) > 2 {
+		return usageErrorf("expected a kind and an optional name")
+	}
+	store, err := e.openStore(opts)
+	if err != nil {
+		return err
+	}
+	res := store.Registry().Lookup(positional[0])
+	if res == nil {
+		return usageErrorf("unknown kind %q", positional[0])
+	}
+	items, err := store.ReadModel().List(res.Name(), store.Scope())
+	if err != nil {
+		return err
+	}
+	if err := e.checkStatus(store); err != nil {
+		return err
+	}
+	res.Sort(items)
+	tbl := tableFor(res, opts.output == "wide")
+	if len(positional) == 2 {
+		name := positional[1]
+		var matched []resources.ResourceItem
+		for _, item := range items {
+			if item.Name == name {
+				matched = append(matched, item)
+			}
+		}
+		if len(matched) == 0 {
+			return fmt.Errorf("%s %q not found", resources.SingularName(res.Name()), name)
+		}
+		if len(matched) == 1 && (opts.output == "json" || opts.output == "yaml") {
+			return writeRecord(e.stdout, opts.output, tbl.record(matched[0]))
+		}
+		items = matched
+	}
+	return e.write(opts.output, tbl, items)
+}
+
+func runUnhealthy(e *env, args []string) error {
+	return runQuery(e, "unhealthy", args, func(store data.Store, wide bool) ([]resources.ResourceItem, table) {
+		return store.UnhealthyItems(), mixedKindTable(store.Scope().Namespace)
+	})
+}
+
+func runRestarts(e *env, args []string) error {
+	return runQuery(e, "restarts", args, func(store data.Store, wide bool) ([]resources.ResourceItem, table) {
+		return store.PodsByRestarts(), tableFor(store.Registry().ByName("pods"), wide)
+	})
+}
+
+// runQuery prints one of the store's cross-resource queries.
+func runQuery(e *env, name string, args []string, query func(store data.Store, wide bool) ([]resources.ResourceItem, table)) error {
+	fs, opts := newFlagSet(name, e.stderr)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageErrorf("unexpected argument %q", positional[0])
+	}
+	store, err := e.openStore(opts)
+	if err != nil {
+		return err
+	}
+	items, tbl := query(store, opts.output == "wide")
+	if err := e.checkStatus(store); err != nil {
+		return err
+	}
+	return e.write(opts.output, tbl, items)
+}
+
+// write prints items; an empty table gets a note on stderr instead of a bare
+// header, like kubectl.
+func (e *env) write(output string, tbl table, items []resources.ResourceItem) error {
+	if len(items) == 0 && (output == "table" || output == "wide") {
+		_, _ = fmt.Fprintln(e.stderr, "No resources found.")
+		return nil
+	}
+	return writeItems(e.stdout, output, tbl, items)
+}
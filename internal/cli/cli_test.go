@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dloss/podji/internal/data"
+)
+
+func runMock(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := Run(args, func() (data.Store, error) { return data.NewMockStore(), nil }, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestGetPrintsResourceTableColumns(t *testing.T) {
+	code, out, _ := runMock(t, "get", "po")
+	if code != ExitOK {
+		t.Fatalf("exit %d", code)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if !strings.HasPrefix(lines[0], "NAME") || !strings.Contains(lines[0], "RESTARTS") {
+		t.Fatalf("unexpected header %q", lines[0])
+	}
+	if !strings.Contains(out, "api-7c6c8d5f7d-x8p2k") {
+		t.Fatalf("expected mock pod in output:\n%s", out)
+	}
+
+	code, out, _ = runMock(t, "get", "deployments", "-A")
+	if code != ExitOK || !strings.HasPrefix(out, "NAMESPACE") {
+		t.Fatalf("expected namespace column with -A, got exit %d:\n%s", code, out)
+	}
+}
+
+func TestGetByNameAsJSON(t *testing.T) {
+	code, out, _ := runMock(t, "get", "pods", "api-7c6c8d5f7d-x8p2k", "-o", "json")
+	if code != ExitOK {
+		t.Fatalf("exit %d", code)
+	}
+	var rec record
+	if err := json.Unmarshal([]byte(out), &rec); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, out)
+	}
+	if rec.Kind != "pod" || rec.Status != "CrashLoop" || rec.Columns["node"] == "" {
+		t.Fatalf("unexpected record %+v", rec)
+	}
+
+	code, _, errOut := runMock(t, "get", "pods", "missing")
+	if code != ExitError || !strings.Contains(errOut, `pod "missing" not found`) {
+		t.Fatalf("expected not found, got exit %d: %s", code, errOut)
+	}
+}
+
+func TestQueriesUseStoreHealthLogic(t *testing.T) {
+	code, out, _ := runMock(t, "unhealthy")
+	if code != ExitOK || !strings.HasPrefix(out, "KIND") || !strings.Contains(out, "CrashLoop") {
+		t.Fatalf("unexpected unhealthy output (exit %d):\n%s", code, out)
+	}
+
+	code, out, _ = runMock(t, "restarts", "-o", "json")
+	if code != ExitOK {
+		t.Fatalf("exit %d", code)
+	}
+	var recs []record
+	if err := json.Unmarshal([]byte(out), &recs); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, out)
+	}
+	want := data.NewMockStore().PodsByRestarts()
+	if len(recs) != len(want) || recs[0].Name != want[0].Name {
+		t.Fatalf("expected %d pods led by %s, got %+v", len(want), want[0].Name, recs)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"get"},
+		{"get", "widgets"},
+		{"get", "pods", "-o", "xml"},
+		{"get", "pods", "-n", "shop", "-A"},
+		{"unhealthy", "extra"},
+		{"frobnicate"},
+	} {
+		if code, _, _ := runMock(t, args...); code != ExitUsage {
+			t.Fatalf("%v: expected usage exit, got %d", args, code)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dloss/podji/internal/resources"
+	"sigs.k8s.io/yaml"
+)
+
+// table describes how items of one list are printed: the visible columns,
+// the cell values per column ID, and every column a JSON or YAML record
+// carries.
+type table struct {
+	kind    string
+	columns []resources.TableColumn
+	fields  []resources.TableColumn
+	row     func(resources.ResourceItem) map[string]string
+}
+
+var defaultColumns = []resources.TableColumn{
+	{ID: "name", Name: "NAME", Default: true},
+	{ID: "status", Name: "STATUS", Default: true},
+	{ID: "ready", Name: "READY", Default: true},
+	{ID: "restarts", Name: "RESTARTS", Default: true},
+	{ID: "age", Name: "AGE", Default: true},
+}
+
+func defaultRow(item resources.ResourceItem) map[string]string {
+	return map[string]string{
+		"namespace": item.Namespace,
+		"kind":      item.Kind,
+		"name":      item.Name,
+		"status":    item.Status,
+		"ready":     item.Ready,
+		"restarts":  item.Restarts,
+		"age":       item.Age,
+	}
+}
+
+// tableFor uses a resource's own columns, as the list view does: the default
+// columns, or the wide set with -o wide.
+func tableFor(res resources.ResourceType, wide bool) table {
+	t := table{kind: resources.SingularName(res.Name()), columns: defaultColumns, fields: defaultColumns, row: defaultRow}
+	tr, ok := res.(resources.TableResource)
+	if !ok {
+		return t
+	}
+	t.columns, t.fields, t.row = nil, tr.TableColumns(), tr.TableRow
+	for _, col := range t.fields {
+		if col.Default {
+			t.columns = append(t.columns, col)
+		}
+	}
+	if w, ok := res.(resources.WideResource); ok {
+		t.row = w.TableRowWide
+		seen := map[string]bool{}
+		for _, col := range t.fields {
+			seen[col.ID] = true
+		}
+		for _, col := range w.TableColumnsWide() {
+			if !seen[col.ID] {
+				t.fields = append(t.fields, col)
+			}
+		}
+		if wide {
+			t.columns = w.TableColumnsWide()
+		}
+	}
+	return t
+}
+
+// mixedKindTable prints results that span kinds, such as the unhealthy query.
+func mixedKindTable(namespace string) table {
+	columns := []resources.TableColumn{{ID: "kind", Name: "KIND", Default: true}}
+	if namespace == resources.AllNamespaces {
+		columns = append(columns, resources.TableColumn{ID: "namespace", Name: "NAMESPACE", Default: true})
+	}
+	columns = append(columns, defaultColumns...)
+	return table{columns: columns, fields: columns, row: defaultRow}
+}
+
+// record is the JSON and YAML form of an item. Columns holds every table
+// cell by column ID, so scripts see the same values as the list view.
+type record struct {
+	Kind      string            `json:"kind"`
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	Ready     string            `json:"ready,omitempty"`
+	Restarts  string            `json:"restarts,omitempty"`
+	Age       string            `json:"age,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Columns   map[string]string `json:"columns,omitempty"`
+}
+
+func (t table) record(item resources.ResourceItem) record {
+	kind := item.Kind
+	if kind == "" {
+		kind = t.kind
+	}
+	row := t.row(item)
+	columns := make(map[string]string, len(t.fields))
+	for _, col := range t.fields {
+		if value := row[col.ID]; value != "" {
+			columns[col.ID] = value
+		}
+	}
+	return record{
+		Kind:      kind,
+		Name:      item.Name,
+		Namespace: item.Namespace,
+		Status:    item.Status,
+		Ready:     item.Ready,
+		Restarts:  item.Restarts,
+		Age:       item.Age,
+		Labels:    item.Labels,
+		Columns:   columns,
+	}
+}
+
+func writeItems(w io.Writer, output string, t table, items []resources.ResourceItem) error {
+	if output == "json" || output == "yaml" {
+		records := make([]record, 0, len(items))
+		for _, item := range items {
+			records = append(records, t.record(item))
+		}
+		return writeRecord(w, output, records)
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	headers := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		headers[i] = col.Name
+	}
+	_, _ = fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, item := range items {
+		row := t.row(item)
+		cells := make([]string, len(t.columns))
+		for i, col := range t.columns {
+			cells[i] = row[col.ID]
+			if cells[i] == "" {
+				cells[i] = "-"
+			}
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+func writeRecord(w io.Writer, output string, v any) error {
+	if output == "yaml" {
+		out, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -1,10 +1,15 @@
 package columnconfig
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"strings"
 	"sync"
 
+	"github.com/dloss/podji/internal/config"
 	"github.com/dloss/podji/internal/resources"
+	"gopkg.in/yaml.v3"
 )
 
 // ColumnConfig stores the user-chosen visible column IDs for one resource type.
@@ -12,10 +17,15 @@ type ColumnConfig struct {
 	Visible []string // column IDs in display order
 }
 
-// Store holds per-resource column visibility configs. Thread-safe.
+// Store holds per-resource column visibility configs, with optional
+// overrides for single kube contexts. A store opened from a file writes every
+// change back to it. Thread-safe.
 type Store struct {
-	mu      sync.RWMutex
-	configs map[string]ColumnConfig // keyed by resource.Name()
+	mu       sync.RWMutex
+	configs  map[string]ColumnConfig            // keyed by resource.Name()
+	contexts map[string]map[string]ColumnConfig // context → resource → config
+	context  string                             // context whose overrides apply
+	path     string                             // state file; "" keeps changes in memory
 }
 
 var defaultStore = &Store{configs: make(map[string]ColumnConfig)}
@@ -25,20 +35,92 @@ func Default() *Store {
 	return defaultStore
 }
 
+// SetDefault replaces the package-level shared store, e.g. with one opened
+// from the state file at startup.
+func SetDefault(s *Store) {
+	defaultStore = s
+}
+
+// stateFile is the on-disk form of a Store.
+type stateFile struct {
+	Columns  map[string][]string            `yaml:"columns,omitempty"`
+	Contexts map[string]map[string][]string `yaml:"contexts,omitempty"`
+}
+
+// Open loads the column layouts saved at path and returns a store that saves
+// changes there. A missing file yields an empty store. A file that cannot be
+// read yields an empty in-memory store along with the error, so the broken
+// file is left alone for the user to inspect.
+func Open(path string) (*Store, error) {
+	s := &Store{configs: make(map[string]ColumnConfig)}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.path = path
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("reading column layouts: %w", err)
+	}
+	var state stateFile
+	if err := yaml.Unmarshal(raw, &state); err != nil {
+		return s, fmt.Errorf("reading column layouts %s: %w", path, err)
+	}
+	for name, visible := range state.Columns {
+		s.configs[name] = ColumnConfig{Visible: visible}
+	}
+	for context, configs := range state.Contexts {
+		for name, visible := range configs {
+			s.contextConfigs(context)[name] = ColumnConfig{Visible: visible}
+		}
+	}
+	s.path = path
+	return s, nil
+}
+
+// SetContext selects the kube context whose overrides Get applies.
+func (s *Store) SetContext(name string) {
+	s.mu.Lock()
+	s.context = name
+	s.mu.Unlock()
+}
+
+// contextConfigs returns the overrides of a context, creating the map.
+// Callers hold the write lock.
+func (s *Store) contextConfigs(context string) map[string]ColumnConfig {
+	if s.contexts == nil {
+		s.contexts = make(map[string]map[string]ColumnConfig)
+	}
+	if s.contexts[context] == nil {
+		s.contexts[context] = make(map[string]ColumnConfig)
+	}
+	return s.contexts[context]
+}
+
+// lookup returns the config for a resource: the current context's override
+// if there is one, else the shared config. Callers hold the read lock.
+func (s *Store) lookup(resourceName string) (ColumnConfig, bool) {
+	if config, ok := s.contexts[s.context][resourceName]; ok {
+		return config, true
+	}
+	config, ok := s.configs[resourceName]
+	return config, ok
+}
+
 // Get returns the active column list for a resource. If no config is set,
-// returns columns with Default=true from pool, in pool order.
+// returns columns with Default=true from pool, in pool order. Saved IDs that
+// are no longer in the pool are skipped; if none are left, the defaults apply.
 func (s *Store) Get(resourceName string, pool []resources.TableColumn) []resources.TableColumn {
 	s.mu.RLock()
-	config, exists := s.configs[resourceName]
+	config, exists := s.lookup(resourceName)
 	s.mu.RUnlock()
 
-	if !exists {
-		var defaults []resources.TableColumn
-		for _, col := range pool {
-			if col.Default {
-				defaults = append(defaults, col)
-			}
+	var defaults []resources.TableColumn
+	for _, col := range pool {
+		if col.Default {
+			defaults = append(defaults, col)
 		}
+	}
+	if !exists {
 		return defaults
 	}
 
@@ -49,7 +131,12 @@ func (s *Store) Get(resourceName string, pool []resources.TableColumn) []resourc
 	}
 
 	var result []resources.TableColumn
+	seen := make(map[string]bool, len(config.Visible))
 	for _, id := range config.Visible {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
 		if col, ok := poolByID[id]; ok {
 			result = append(result, col)
 		} else if strings.HasPrefix(id, "label:") {
@@ -70,28 +157,93 @@ func (s *Store) Get(resourceName string, pool []resources.TableColumn) []resourc
 			})
 		}
 	}
+	if len(result) == 0 && len(config.Visible) > 0 {
+		return defaults
+	}
 
 	return result
 }
 
-// Set stores user-chosen visible column IDs for a resource.
-func (s *Store) Set(resourceName string, visible []string) {
+// Set stores user-chosen visible column IDs for a resource. It replaces any
+// override for the current context, so the choice is what the user sees.
+func (s *Store) Set(resourceName string, visible []string) error {
 	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.configs[resourceName] = ColumnConfig{Visible: visible}
-	s.mu.Unlock()
+	delete(s.contexts[s.context], resourceName)
+	return s.save()
+}
+
+// SetForContext stores visible column IDs for a resource in the current
+// context only.
+func (s *Store) SetForContext(resourceName string, visible []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.contextConfigs(s.context)[resourceName] = ColumnConfig{Visible: visible}
+	return s.save()
 }
 
 // Reset removes user config for resourceName, reverting to defaults.
-func (s *Store) Reset(resourceName string) {
+func (s *Store) Reset(resourceName string) error {
 	s.mu.Lock()
+	defer s.mu.Unlock()
 	delete(s.configs, resourceName)
-	s.mu.Unlock()
+	delete(s.contexts[s.context], resourceName)
+	return s.save()
 }
 
 // IsCustom reports whether the user has a non-default config for resourceName.
 func (s *Store) IsCustom(resourceName string) bool {
 	s.mu.RLock()
-	_, exists := s.configs[resourceName]
+	_, exists := s.lookup(resourceName)
+	s.mu.RUnlock()
+	return exists
+}
+
+// HasContextOverride reports whether resourceName has columns saved for the
+// current context only.
+func (s *Store) HasContextOverride(resourceName string) bool {
+	s.mu.RLock()
+	_, exists := s.contexts[s.context][resourceName]
 	s.mu.RUnlock()
 	return exists
 }
+
+// save writes the store to its file atomically. Callers hold the write lock.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+	state := stateFile{Columns: make(map[string][]string, len(s.configs))}
+	for name, cfg := range s.configs {
+		state.Columns[name] = nonNil(cfg.Visible)
+	}
+	for context, configs := range s.contexts {
+		if len(configs) == 0 {
+			continue
+		}
+		if state.Contexts == nil {
+			state.Contexts = make(map[string]map[string][]string)
+		}
+		state.Contexts[context] = make(map[string][]string, len(configs))
+		for name, cfg := range configs {
+			state.Contexts[context][name] = nonNil(cfg.Visible)
+		}
+	}
+	raw, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := config.WriteStateFile(s.path, raw); err != nil {
+		return fmt.Errorf("saving column layouts: %w", err)
+	}
+	return nil
+}
+
+// nonNil keeps an empty selection as [] in the file instead of null.
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
@@ -1,6 +1,9 @@
 package columnconfig
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/dloss/podji/internal/resources"
@@ -40,3 +43,94 @@ func TestSetGetPreservesExplicitOrderWithoutInjectingName(t *testing.T) {
 		}
 	}
 }
+
+func TestOpenRestoresSavedLayouts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "podji", "columns.yaml")
+	pool := []resources.TableColumn{
+		{ID: "name", Name: "NAME", Default: true},
+		{ID: "status", Name: "STATUS", Default: true},
+		{ID: "age", Name: "AGE", Default: false},
+	}
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("pods", []string{"age", "label:app"}); err != nil {
+		t.Fatal(err)
+	}
+	store.SetContext("prod")
+	if err := store.SetForContext("pods", []string{"name"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := columnIDs(reopened.Get("pods", pool)); strings.Join(got, ",") != "age,label:app" {
+		t.Fatalf("expected saved shared layout, got %v", got)
+	}
+	reopened.SetContext("prod")
+	if got := columnIDs(reopened.Get("pods", pool)); strings.Join(got, ",") != "name" {
+		t.Fatalf("expected context override, got %v", got)
+	}
+	if !reopened.HasContextOverride("pods") {
+		t.Fatal("expected context override to be reported")
+	}
+
+	// A shared choice made in the context replaces its override.
+	if err := reopened.Set("pods", []string{"status"}); err != nil {
+		t.Fatal(err)
+	}
+	if reopened.HasContextOverride("pods") {
+		t.Fatal("expected override to be cleared")
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Fatalf("expected only the state file after atomic writes, got %d entries", len(entries))
+	}
+}
+
+func TestGetFallsBackToDefaultsWhenSavedColumnsAreGone(t *testing.T) {
+	store := &Store{configs: make(map[string]ColumnConfig)}
+	pool := []resources.TableColumn{
+		{ID: "name", Name: "NAME", Default: true},
+		{ID: "age", Name: "AGE", Default: true},
+	}
+
+	store.Set("pods", []string{"gone", "age", "age"})
+	if got := columnIDs(store.Get("pods", pool)); strings.Join(got, ",") != "age" {
+		t.Fatalf("expected stale and duplicate IDs to be skipped, got %v", got)
+	}
+	store.Set("pods", []string{"gone", "removed"})
+	if got := columnIDs(store.Get("pods", pool)); strings.Join(got, ",") != "name,age" {
+		t.Fatalf("expected defaults when no saved column remains, got %v", got)
+	}
+}
+
+func TestOpenKeepsUnreadableFileUntouched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "columns.yaml")
+	if err := os.WriteFile(path, []byte("columns: [oops\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := Open(path)
+	if err == nil {
+		t.Fatal("expected error for unreadable file")
+	}
+	if err := store.Set("pods", []string{"name"}); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(path)
+	if string(raw) != "columns: [oops\n" {
+		t.Fatalf("expected broken file to be left alone, got %q", raw)
+	}
+}
+
+func columnIDs(cols []resources.TableColumn) []string {
+	ids := make([]string, len(cols))
+	for i, col := range cols {
+		ids[i] = col.ID
+	}
+	return ids
+}
@@ -0,0 +1,274 @@
+// Package config loads podji's user configuration file: startup defaults,
+// log view defaults, context tiers, confirmation policies, key bindings, the
+// color theme and plugin commands.
+// A missing file is not an error; every setting has a built-in default.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the content of config.yaml. Zero-valued fields are filled from
+// Default before the file is decoded, so a file only lists what it changes.
+type Config struct {
+	// Mode is "mock" or "kube"; empty picks kube unless PODJI_MOCK is set.
+	Mode string `yaml:"mode"`
+	// Namespace is the namespace podji starts in instead of the context's.
+	Namespace string `yaml:"namespace"`
+	// Resource is the list podji starts on, by name or alias ("pods", "deploy").
+	Resource string `yaml:"resource"`
+	// RestoreSession reopens the screen podji showed when it last exited.
+	RestoreSession bool   `yaml:"restoreSession"`
+	DebugImage     string `yaml:"debugImage"`
+	// Theme is auto, dark, light, high-contrast or mono. Auto picks dark or
+	// light from the terminal background; NO_COLOR always selects mono.
+	Theme string `yaml:"theme"`
+	// Colors override single theme colors by name, e.g. {warning: "208"}.
+	Colors   map[string]string `yaml:"colors"`
+	Logs     Logs              `yaml:"logs"`
+	Contexts Contexts          `yaml:"contexts"`
+	Confirm  Confirm           `yaml:"confirm"`
+	Keys     Keys              `yaml:"keys"`
+	Plugins  []Plugin          `yaml:"plugins"`
+}
+
+// ThemeAuto picks the dark or light theme from the terminal background.
+const ThemeAuto = "auto"
+
+// Themes are the accepted theme values.
+var Themes = []string{ThemeAuto, "dark", "light", "high-contrast", "mono"}
+
+// Logs are the initial settings of a new log view.
+type Logs struct {
+	Since      string `yaml:"since"`
+	Follow     bool   `yaml:"follow"`
+	Wrap       bool   `yaml:"wrap"`
+	Timestamps bool   `yaml:"timestamps"`
+}
+
+// Contexts classify kube contexts by name with patterns where * matches any
+// text and ? one character, ignoring case. Prod contexts are highlighted and
+// hide secret values; local contexts are shown dimmed. Everything else is
+// remote.
+type Contexts struct {
+	Prod  []string `yaml:"prod"`
+	Local []string `yaml:"local"`
+}
+
+// Confirmation policies.
+const (
+	ConfirmAlways    = "always"
+	ConfirmProtected = "protected"
+	ConfirmNever     = "never"
+)
+
+// Confirm sets when destructive actions ask before running: always, only in
+// prod contexts ("protected"), or never.
+type Confirm struct {
+	Delete  string `yaml:"delete"`
+	Restart string `yaml:"restart"`
+	Drain   string `yaml:"drain"`
+}
+
+// Required reports whether action needs a confirmation under the policy,
+// given whether the current context is a prod context.
+func (c Confirm) Required(action string, protected bool) bool {
+	var policy string
+	switch action {
+	case "delete":
+		policy = c.Delete
+	case "restart":
+		policy = c.Restart
+	case "drain":
+		policy = c.Drain
+	}
+	switch policy {
+	case ConfirmNever:
+		return false
+	case ConfirmProtected:
+		return protected
+	}
+	return true
+}
+
+// LogSinceWindows are the accepted logs.since values, in the order the log
+// view cycles through them.
+var LogSinceWindows = []string{"1m", "5m", "15m", "1h", "all"}
+
+// Default returns the built-in configuration.
+func Default() Config {
+	return Config{
+		DebugImage: "busybox:1.36",
+		Theme:      ThemeAuto,
+		Logs:       Logs{Since: "5m", Follow: true, Wrap: true, Timestamps: true},
+		Contexts: Contexts{
+			Prod:  []string{"*prod*"},
+			Local: []string{"default", "minikube", "docker-desktop", "rancher-desktop", "kind-*", "k3d-*"},
+		},
+		Confirm: Confirm{Delete: ConfirmAlways, Restart: ConfirmAlways, Drain: ConfirmAlways},
+	}
+}
+
+// Path returns the config file location: $XDG_CONFIG_HOME/podji/config.yaml,
+// or ~/.config/podji/config.yaml when XDG_CONFIG_HOME is unset.
+func Path() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "podji", "config.yaml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locating config file: %w", err)
+	}
+	return filepath.Join(home, ".config", "podji", "config.yaml"), nil
+}
+
+// StatePath returns the location of a state file podji writes itself, such
+// as saved column layouts: $XDG_STATE_HOME/podji/<name>, or
+// ~/.local/state/podji/<name> when XDG_STATE_HOME is unset.
+func StatePath(name string) (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "podji", name), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locating state file: %w", err)
+	}
+	return filepath.Join(home, ".local", "state", "podji", name), nil
+}
+
+// WriteStateFile writes a state file atomically: a temporary file in the
+// same directory is renamed over the old one, so a crash never leaves a
+// partial file. Missing directories are created.
+func WriteStateFile(p string, data []byte) error {
+	dir := filepath.Dir(p)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(p)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p)
+}
+
+// LoadDefault loads the config file from Path. A missing file yields Default.
+func LoadDefault() (Config, error) {
+	p, err := Path()
+	if err != nil {
+		return Config{}, err
+	}
+	return Load(p)
+}
+
+// Load reads and validates the config file at p. A missing file yields
+// Default; errors name the file and the offending setting.
+func Load(p string) (Config, error) {
+	raw, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return Default(), nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("config: %w", err)
+	}
+	cfg, err := Parse(raw)
+	if err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", p, err)
+	}
+	return cfg, nil
+}
+
+// Parse decodes and validates config YAML on top of Default. Unknown
+// settings are rejected so typos do not go unnoticed.
+func Parse(raw []byte) (Config, error) {
+	cfg := Default()
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, decodeError(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// decodeError strips yaml's Go type names, which mean nothing to users.
+func decodeError(err error) error {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+	msgs := make([]string, len(typeErr.Errors))
+	for i, msg := range typeErr.Errors {
+		if at := strings.Index(msg, " in type config."); at >= 0 {
+			msg = msg[:at]
+		}
+		msgs[i] = strings.Replace(msg, "field ", "unknown setting ", 1)
+		msgs[i] = strings.Replace(msgs[i], " not found", "", 1)
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// Validate checks values the YAML types cannot express.
+func (c Config) Validate() error {
+	switch c.Mode {
+	case "", "mock", "kube":
+	default:
+		return fmt.Errorf("mode: %q is not one of mock, kube", c.Mode)
+	}
+	if !contains(LogSinceWindows, c.Logs.Since) {
+		return fmt.Errorf("logs.since: %q is not one of %s", c.Logs.Since, strings.Join(LogSinceWindows, ", "))
+	}
+	if strings.TrimSpace(c.DebugImage) == "" {
+		return errors.New("debugImage: must not be empty")
+	}
+	if !contains(Themes, c.Theme) {
+		return fmt.Errorf("theme: %q is not one of %s", c.Theme, strings.Join(Themes, ", "))
+	}
+	for _, pattern := range append(append([]string(nil), c.Contexts.Prod...), c.Contexts.Local...) {
+		if strings.TrimSpace(pattern) == "" {
+			return errors.New("contexts: patterns must not be empty")
+		}
+	}
+	policies := [][2]string{{"delete", c.Confirm.Delete}, {"restart", c.Confirm.Restart}, {"drain", c.Confirm.Drain}}
+	for _, policy := range policies {
+		switch policy[1] {
+		case ConfirmAlways, ConfirmProtected, ConfirmNever:
+		default:
+			return fmt.Errorf("confirm.%s: %q is not one of always, protected, never", policy[0], policy[1])
+		}
+	}
+	if _, err := c.Keys.Keymap(); err != nil {
+		return err
+	}
+	return c.validatePlugins()
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
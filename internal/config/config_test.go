@@ -0,0 +1,144 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseOverlaysDefaults(t *testing.T) {
+	cfg, err := Parse([]byte("namespace: payments\nlogs:\n  since: 1h\n  follow: false\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Namespace != "payments" || cfg.Logs.Since != "1h" || cfg.Logs.Follow {
+		t.Fatalf("expected file values, got %+v", cfg)
+	}
+	if !cfg.Logs.Wrap || !cfg.Logs.Timestamps || cfg.Confirm.Delete != ConfirmAlways {
+		t.Fatalf("expected defaults for unset values, got %+v", cfg)
+	}
+}
+
+func TestParseEmptyFileIsDefault(t *testing.T) {
+	cfg, err := Parse(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Logs != Default().Logs || cfg.DebugImage != Default().DebugImage {
+		t.Fatalf("expected defaults, got %+v", cfg)
+	}
+}
+
+func TestParseErrorsNameTheSetting(t *testing.T) {
+	cases := map[string]string{
+		"namepsace: x\n":                                                                             "unknown setting namepsace",
+		"logs:\n  since: 7m\n":                                                                       "logs.since",
+		"mode: cluster\n":                                                                            "mode",
+		"theme: solarized\n":                                                                         "theme",
+		"confirm:\n  delete: sometimes\n":                                                            "confirm.delete",
+		"keys:\n  list:\n    frobnicate: F\n":                                                        "keys.list.frobnicate: unknown action",
+		"keys:\n  list:\n    describe: ctrl+\n":                                                      `"ctrl+" is not a key`,
+		"keys:\n  global:\n    help: esc\n":                                                          `"esc" is reserved`,
+		"keys:\n  list:\n    describe: N\n":                                                          "also bound to global.namespace",
+		"plugins:\n- name: Stern\n":                                                                  "plugins[0].name",
+		"plugins:\n- name: stern\n  kinds: [pods]\n":                                                 "plugins.stern.command",
+		"plugins:\n- name: stern\n  command: stern\n":                                                "plugins.stern.kinds",
+		"plugins:\n- name: stern\n  command: stern\n  kinds: [pods]\n  key: d\n":                     "also bound to keys.list.describe",
+		"plugins:\n- name: stern\n  command: stern\n  kinds: [pods]\n  key: enter\n":                 `"enter" is reserved`,
+		"plugins:\n- name: stern\n  command: stern\n  kinds: [pods]\n  key: j\n":                     `"j" is a navigation key`,
+		"plugins:\n- name: stern\n  command: stern\n  kinds: [pods]\n  key: G\n":                     `"G" is a navigation key`,
+		"plugins:\n- name: stern\n  command: stern\n  kinds: [pods]\n  key: left\n":                  `"left" is a navigation key`,
+		"plugins:\n- name: stern\n  command: stern\n  kinds: [pods]\n  key: tab\n":                   `"tab" is a navigation key`,
+		"plugins:\n- {name: a, command: a, kinds: [pods]}\n- {name: a, command: b, kinds: [pods]}\n": "plugins.a: defined twice",
+		"plugins:\n- {name: a, command: a, kinds: [pods], key: S}\n- {name: b, command: b, kinds: [pods], key: S}\n": "also bound to plugins.a",
+	}
+	for raw, want := range cases {
+		_, err := Parse([]byte(raw))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) = %v, want error containing %q", raw, err, want)
+		}
+	}
+}
+
+func TestParsePluginKeyMayTakeAMovedActionsKey(t *testing.T) {
+	raw := "keys:\n  list:\n    describe: D\nplugins:\n- name: dive\n  command: dive\n  args: ['{{.Image}}']\n  kinds: [pods, deploy]\n  key: d\n  background: true\n"
+	cfg, err := Parse([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Plugins) != 1 || cfg.Plugins[0].Key != "d" || !cfg.Plugins[0].Background || len(cfg.Plugins[0].Kinds) != 2 {
+		t.Fatalf("unexpected plugins %+v", cfg.Plugins)
+	}
+}
+
+func TestKeymapTranslatesAndUnbindsMovedKeys(t *testing.T) {
+	keys := Keys{
+		Global: map[string]string{"namespace": "ctrl+n"},
+		List:   map[string]string{"describe": "e", "events": "d"},
+	}
+	m, err := keys.Keymap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct{ scope, key, want string }{
+		{ScopeList, "e", "d"},
+		{ScopeList, "d", "e"},
+		{ScopeList, "ctrl+n", "N"},
+		{ScopeList, "N", ""},
+		{ScopeLogs, "d", "d"},
+		{ScopeLogs, "ctrl+n", "N"},
+		{ScopeGlobal, "y", "y"},
+	}
+	for _, tc := range cases {
+		if got := m.Translate(tc.scope, tc.key); got != tc.want {
+			t.Errorf("Translate(%s, %q) = %q, want %q", tc.scope, tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestConfirmRequired(t *testing.T) {
+	c := Confirm{Delete: ConfirmProtected, Restart: ConfirmNever, Drain: ConfirmAlways}
+	if c.Required("delete", false) || !c.Required("delete", true) {
+		t.Fatal("protected policy should only confirm in prod contexts")
+	}
+	if c.Required("restart", true) {
+		t.Fatal("never policy should not confirm")
+	}
+	if !c.Required("drain", false) {
+		t.Fatal("always policy should confirm")
+	}
+}
+
+func TestPathHonorsXDGConfigHome(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	got, err := Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "podji", "config.yaml"); got != want {
+		t.Fatalf("Path() = %q, want %q", got, want)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", dir)
+	got, _ = Path()
+	if want := filepath.Join(dir, ".config", "podji", "config.yaml"); got != want {
+		t.Fatalf("Path() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadMissingFileIsDefaultAndBadFileNamesPath(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Load(filepath.Join(dir, "missing.yaml")); err != nil {
+		t.Fatalf("missing file should not be an error: %v", err)
+	}
+	p := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(p, []byte("logs: [1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(p); err == nil || !strings.Contains(err.Error(), p) {
+		t.Fatalf("expected error naming %s, got %v", p, err)
+	}
+}
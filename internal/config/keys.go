@@ -0,0 +1,258 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+)
+
+// Key binding scopes. Global keys work in every view; list and logs keys
+// only in list and log views, where they take precedence.
+const (
+	ScopeGlobal = "global"
+	ScopeList   = "list"
+	ScopeLogs   = "logs"
+)
+
+// Keys remaps actions to other keys, per scope. Keys are written as bubbletea
+// names them: "D", "ctrl+n", "f5", "alt+d".
+type Keys struct {
+	Global map[string]string `yaml:"global"`
+	List   map[string]string `yaml:"list"`
+	Logs   map[string]string `yaml:"logs"`
+}
+
+// DefaultKeys are the remappable actions of each scope and their keys.
+var DefaultKeys = map[string]map[string]string{
+	ScopeGlobal: {
+		"command":       ":",
+		"quit":          "q",
+		"namespace":     "N",
+		"context":       "X",
+		"resources":     "A",
+		"owner":         "J",
+		"related":       "r",
+		"help":          "?",
+		"bookmark":      "m",
+		"allNamespaces": "0",
+	},
+	ScopeList: {
+		"search":    "/",
+		"nextMatch": "n",
+		"prevMatch": "b",
+		"open":      "o",
+		"wide":      "w",
+		"columns":   "p",
+		"sort":      "s",
+		"yaml":      "y",
+		"events":    "e",
+		"describe":  "d",
+		"find":      "f",
+		"copy":      "c",
+		"export":    "ctrl+e",
+		"compare":   "=",
+		"actions":   "x",
+	},
+	ScopeLogs: {
+		"follow":          "f",
+		"wrap":            "w",
+		"fold":            "z",
+		"foldAll":         "Z",
+		"timestamps":      "t",
+		"timestampFormat": "T",
+		"previous":        "p",
+		"filter":          "&",
+		"search":          "/",
+		"nextMatch":       "n",
+		"prevMatch":       "b",
+		"history":         "B",
+		"sinceNext":       ".",
+		"sincePrev":       ",",
+		"containers":      "c",
+	},
+}
+
+// reservedKeys keep their meaning everywhere: quitting, cancelling, opening
+// and the bookmark slots.
+var reservedKeys = map[string]bool{
+	"ctrl+c": true, "esc": true, "enter": true,
+	"1": true, "2": true, "3": true, "4": true, "5": true, "6": true, "7": true, "8": true, "9": true,
+}
+
+// navigationKeys move the cursor, page and step back through the view stack
+// in every list. They are not actions, so they cannot be remapped, and a
+// plugin bound to one would take it away.
+var navigationKeys = map[string]bool{
+	"up": true, "down": true, "left": true, "right": true,
+	"j": true, "k": true, "h": true, "l": true, "g": true, "G": true, "u": true,
+	"home": true, "end": true, "pgup": true, "pgdown": true, " ": true,
+	"backspace": true, "ctrl+h": true, "tab": true, "shift+tab": true,
+}
+
+// Keymap translates pressed keys into the default keys the views handle.
+type Keymap struct {
+	scopes map[string]map[string]string
+}
+
+func (k Keys) scope(name string) map[string]string {
+	switch name {
+	case ScopeGlobal:
+		return k.Global
+	case ScopeList:
+		return k.List
+	case ScopeLogs:
+		return k.Logs
+	}
+	return nil
+}
+
+// Keymap validates the remapped keys and builds the translation tables.
+func (k Keys) Keymap() (*Keymap, error) {
+	for _, scope := range []string{ScopeGlobal, ScopeList, ScopeLogs} {
+		if err := k.validateScope(scope); err != nil {
+			return nil, err
+		}
+	}
+	m := &Keymap{scopes: map[string]map[string]string{}}
+	for _, scope := range []string{ScopeGlobal, ScopeList, ScopeLogs} {
+		layers := []string{ScopeGlobal}
+		if scope != ScopeGlobal {
+			layers = append(layers, scope)
+		}
+		table := map[string]string{}
+		// A moved binding frees its default key; remapped keys then take
+		// precedence, so swapping two keys works.
+		for _, layer := range layers {
+			for action := range k.scope(layer) {
+				table[DefaultKeys[layer][action]] = ""
+			}
+		}
+		for _, layer := range layers {
+			for action, key := range k.scope(layer) {
+				table[key] = DefaultKeys[layer][action]
+			}
+		}
+		for key, target := range table {
+			if key == target {
+				delete(table, key)
+			}
+		}
+		if len(table) > 0 {
+			m.scopes[scope] = table
+		}
+	}
+	return m, nil
+}
+
+// validateScope checks action names and keys, and that no key is bound to
+// two actions once global and view keys are combined.
+func (k Keys) validateScope(scope string) error {
+	remaps := k.scope(scope)
+	actions := make([]string, 0, len(remaps))
+	for action := range remaps {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		key := remaps[action]
+		if _, ok := DefaultKeys[scope][action]; !ok {
+			return fmt.Errorf("keys.%s.%s: unknown action (want one of %s)", scope, action, strings.Join(actionNames(scope), ", "))
+		}
+		if !validKey(key) {
+			return fmt.Errorf("keys.%s.%s: %q is not a key", scope, action, key)
+		}
+		if reservedKeys[key] {
+			return fmt.Errorf("keys.%s.%s: %q is reserved", scope, action, key)
+		}
+	}
+	if scope == ScopeGlobal {
+		return k.checkConflicts(ScopeGlobal)
+	}
+	return k.checkConflicts(ScopeGlobal, scope)
+}
+
+func (k Keys) checkConflicts(scopes ...string) error {
+	bound := map[string]string{}
+	for _, scope := range scopes {
+		for _, action := range actionNames(scope) {
+			key := DefaultKeys[scope][action]
+			if remapped, ok := k.scope(scope)[action]; ok {
+				key = remapped
+			}
+			name := scope + "." + action
+			if other, ok := bound[key]; ok {
+				return fmt.Errorf("keys.%s: %q is also bound to %s", name, key, other)
+			}
+			bound[key] = name
+		}
+	}
+	return nil
+}
+
+// CheckResourceKeys reports a remapped key that is also a resource hotkey, a
+// binding that would silently take the hotkey over. resourceKeys maps each
+// hotkey to its resource's name.
+func (k Keys) CheckResourceKeys(resourceKeys map[string]string) error {
+	for _, scope := range []string{ScopeGlobal, ScopeList, ScopeLogs} {
+		for _, action := range actionNames(scope) {
+			key, ok := k.scope(scope)[action]
+			if !ok {
+				continue
+			}
+			if name, ok := resourceKeys[key]; ok {
+				return fmt.Errorf("keys.%s.%s: %q is also bound to the %s list", scope, action, key, name)
+			}
+		}
+	}
+	return nil
+}
+
+func actionNames(scope string) []string {
+	names := make([]string, 0, len(DefaultKeys[scope]))
+	for action := range DefaultKeys[scope] {
+		names = append(names, action)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// namedKeys are the non-character keys bubbletea reports, such as "f5".
+var namedKeys = func() map[string]bool {
+	names := map[string]bool{}
+	for t := -128; t <= 128; t++ {
+		if name := bubbletea.KeyType(t).String(); name != "" && name != "runes" {
+			names[name] = true
+		}
+	}
+	return names
+}()
+
+func validKey(key string) bool {
+	key = strings.TrimPrefix(key, "alt+")
+	if utf8.RuneCountInString(key) == 1 {
+		r, _ := utf8.DecodeRuneInString(key)
+		return unicode.IsGraphic(r) && !unicode.IsSpace(r)
+	}
+	return namedKeys[key]
+}
+
+// Translate maps a pressed key to the default key of the action bound to it
+// in scope, or returns it unchanged. It returns "" for a default key whose
+// action was moved elsewhere. A nil Keymap translates nothing.
+func (m *Keymap) Translate(scope, key string) string {
+	if m == nil {
+		return key
+	}
+	table, ok := m.scopes[scope]
+	if !ok {
+		table = m.scopes[ScopeGlobal]
+	}
+	if target, ok := table[key]; ok {
+		return target
+	}
+	return key
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Plugin binds a key or :command to an external command for the selected
+// item of some resource lists. Args are Go templates over the item, such as
+// "{{.Namespace}}" or "{{index .Labels \"app\"}}".
+type Plugin struct {
+	// Name identifies the plugin and is its :command.
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Key runs the plugin from a list view; it is optional.
+	Key string `yaml:"key"`
+	// Kinds are the resource lists the plugin applies to, by name or alias
+	// ("pods", "deploy", "containers"); "*" applies to every list.
+	Kinds   []string `yaml:"kinds"`
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args"`
+	// Background runs the command without suspending podji and shows its
+	// output in a view when it exits. Otherwise it takes over the terminal.
+	Background bool `yaml:"background"`
+}
+
+var pluginNameRE = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// validatePlugins checks names, commands and keys. Keys may not be reserved,
+// navigate, or shadow a global or list action, and names and keys are
+// unique. The argument templates and kinds are checked by the plugin package,
+// which knows the template data and the resources.
+func (c Config) validatePlugins() error {
+	bound := map[string]string{}
+	for _, scope := range []string{ScopeGlobal, ScopeList} {
+		for _, action := range actionNames(scope) {
+			key := DefaultKeys[scope][action]
+			if remapped, ok := c.Keys.scope(scope)[action]; ok {
+				key = remapped
+			}
+			bound[key] = "keys." + scope + "." + action
+		}
+	}
+	names := map[string]bool{}
+	for i, p := range c.Plugins {
+		at := fmt.Sprintf("plugins[%d]", i)
+		if !pluginNameRE.MatchString(p.Name) {
+			return fmt.Errorf("%s.name: %q must be lowercase letters, digits and dashes", at, p.Name)
+		}
+		at = "plugins." + p.Name
+		if names[p.Name] {
+			return fmt.Errorf("%s: defined twice", at)
+		}
+		names[p.Name] = true
+		if strings.TrimSpace(p.Command) == "" {
+			return fmt.Errorf("%s.command: must not be empty", at)
+		}
+		if len(p.Kinds) == 0 {
+			return fmt.Errorf("%s.kinds: must list at least one resource", at)
+		}
+		if p.Key == "" {
+			continue
+		}
+		if !validKey(p.Key) {
+			return fmt.Errorf("%s.key: %q is not a key", at, p.Key)
+		}
+		if reservedKeys[p.Key] {
+			return fmt.Errorf("%s.key: %q is reserved", at, p.Key)
+		}
+		if navigationKeys[p.Key] {
+			return fmt.Errorf("%s.key: %q is a navigation key", at, p.Key)
+		}
+		if other, ok := bound[p.Key]; ok {
+			return fmt.Errorf("%s.key: %q is also bound to %s", at, p.Key, other)
+		}
+		bound[p.Key] = at
+	}
+	return nil
+}
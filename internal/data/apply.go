@@ -0,0 +1,131 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dloss/podji/internal/resources"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// applyFieldManager is the field manager recorded for fields podji applies.
+const applyFieldManager = "podji"
+
+// ApplyObject server-side applies manifest. The resourceVersion from the
+// edited YAML is kept, so an object changed since it was read is reported as
+// a conflict instead of being overwritten. Fields owned by other managers
+// are only taken over with opts.Force; otherwise the apply fails with
+// resources.ErrApplyConflict.
+func (k *clientGoAPI) ApplyObject(ctx context.Context, contextName string, manifest []byte, opts resources.ApplyOptions) (string, error) {
+	obj, err := parseManifest(manifest)
+	if err != nil {
+		return "", err
+	}
+	restCfg, err := k.restConfigForContext(contextName)
+	if err != nil {
+		return "", err
+	}
+	mapper, err := discoveryRESTMapper(restCfg)
+	if err != nil {
+		return "", err
+	}
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return "", fmt.Errorf("unknown kind %s: %w", gvk.Kind, err)
+	}
+	client, err := dynamic.NewForConfig(restCfg)
+	if err != nil {
+		return "", fmt.Errorf("failed creating dynamic client for context %q: %w", contextName, err)
+	}
+
+	var target dynamic.ResourceInterface = client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if obj.GetNamespace() == "" {
+			return "", errors.New("metadata.namespace is required")
+		}
+		target = client.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+	}
+	applyOpts := metav1.ApplyOptions{FieldManager: applyFieldManager, Force: opts.Force}
+	if opts.DryRun {
+		applyOpts.DryRun = []string{metav1.DryRunAll}
+	}
+	result, err := target.Apply(ctx, obj.GetName(), obj, applyOpts)
+	if isFieldManagerConflict(err) {
+		return "", fmt.Errorf("%w: %v", resources.ErrApplyConflict, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	result.SetManagedFields(nil)
+	out, err := yaml.Marshal(result.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// isFieldManagerConflict reports an apply rejected because it changes fields
+// other managers own, as opposed to a stale resourceVersion.
+func isFieldManagerConflict(err error) bool {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Reason != metav1.StatusReasonConflict {
+		return false
+	}
+	if details := status.Status().Details; details != nil {
+		for _, cause := range details.Causes {
+			if cause.Type == metav1.CauseTypeFieldManagerConflict {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// discoveryRESTMapper maps kinds and resource names to API resources using
+// the server's discovery information.
+func discoveryRESTMapper(restCfg *rest.Config) (meta.RESTMapper, error) {
+	disco, err := discovery.NewDiscoveryClientForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating discovery client: %w", err)
+	}
+	groups, err := restmapper.GetAPIGroupResources(disco)
+	if err != nil {
+		return nil, fmt.Errorf("failed discovering api resources: %w", err)
+	}
+	return restmapper.NewDiscoveryRESTMapper(groups), nil
+}
+
+// parseManifest reads an edited object and drops what server-side apply
+// rejects in an applied configuration.
+func parseManifest(manifest []byte) (*unstructured.Unstructured, error) {
+	if strings.TrimSpace(string(manifest)) == "" {
+		return nil, errors.New("manifest is empty")
+	}
+	var fields map[string]any
+	if err := yaml.Unmarshal(manifest, &fields); err != nil {
+		return nil, fmt.Errorf("invalid yaml: %w", err)
+	}
+	obj := &unstructured.Unstructured{Object: fields}
+	switch {
+	case obj.GetAPIVersion() == "":
+		return nil, errors.New("apiVersion is required")
+	case obj.GetKind() == "":
+		return nil, errors.New("kind is required")
+	case obj.GetName() == "":
+		return nil, errors.New("metadata.name is required")
+	}
+	obj.SetManagedFields(nil)
+	unstructured.RemoveNestedField(obj.Object, "status")
+	return obj, nil
+}
@@ -0,0 +1,175 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dloss/podji/internal/resources"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestParseManifestDropsManagedFieldsAndStatus(t *testing.T) {
+	obj, err := parseManifest([]byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: default
+  resourceVersion: "42"
+  managedFields:
+  - manager: kubectl
+spec:
+  replicas: 3
+status:
+  replicas: 2`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.GetManagedFields() != nil || obj.Object["status"] != nil {
+		t.Fatalf("expected managedFields and status to be dropped, got %v", obj.Object)
+	}
+	if obj.GetResourceVersion() != "42" {
+		t.Fatal("resourceVersion must be kept so concurrent changes conflict")
+	}
+}
+
+func TestParseManifestRejectsIncompleteObjects(t *testing.T) {
+	for manifest, want := range map[string]string{
+		"":                                    "empty",
+		"kind: Pod\nmetadata: {name: a}":      "apiVersion",
+		"apiVersion: v1\nmetadata: {name: a}": "kind",
+		"apiVersion: v1\nkind: Pod":           "metadata.name",
+		"apiVersion: v1\nkind: [":             "invalid yaml",
+	} {
+		_, err := parseManifest([]byte(manifest))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("manifest %q: expected error mentioning %q, got %v", manifest, want, err)
+		}
+	}
+}
+
+func TestReadBackedResourceApplyUsesReadModel(t *testing.T) {
+	registry := resources.DefaultRegistry()
+	res := NewReadBackedResource(registry.ByName("deployments"), NewMockReadModel(registry), func() Scope {
+		return Scope{Context: "default", Namespace: "default"}
+	})
+	applier, ok := res.(resources.ObjectApplier)
+	if !ok {
+		t.Fatal("expected read-backed resource to support apply")
+	}
+	manifest := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: api\n"
+	got, err := applier.ApplyYAML(context.Background(), resources.ResourceItem{Name: "api"}, manifest, resources.ApplyOptions{DryRun: true})
+	if err != nil || got != manifest {
+		t.Fatalf("expected mock apply to echo the manifest, got %q, %v", got, err)
+	}
+	if _, err := applier.ApplyYAML(context.Background(), resources.ResourceItem{Name: "api"}, "kind: Deployment", resources.ApplyOptions{DryRun: true}); err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	strict := NewReadBackedResourceStrict(registry.ByName("deployments"), fakeReadModel{}, func() Scope { return Scope{} })
+	_, err = strict.(resources.ObjectApplier).ApplyYAML(context.Background(), resources.ResourceItem{Name: "api"}, manifest, resources.ApplyOptions{DryRun: true})
+	if !errors.Is(err, ErrApplyNotSupported) {
+		t.Fatalf("expected ErrApplyNotSupported, got %v", err)
+	}
+}
+
+// fakeAppsServer serves discovery for apps/v1 Deployments, one Deployment
+// and server-side apply of it, recording the applied body. With conflict set
+// an apply without force fails with a field manager conflict.
+func fakeAppsServer(t *testing.T, applied *map[string]any, conflict bool) *httptest.Server {
+	t.Helper()
+	deployment := `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"api","namespace":"default","resourceVersion":"7"},"spec":{"replicas":1,"selector":{"matchLabels":{"app":"api"}},"template":{"metadata":{"labels":{"app":"api"}},"spec":{"containers":[{"name":"api","image":"myco/api:v2"}]}}}}`
+	mux := http.NewServeMux()
+	reply := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, body)
+		}
+	}
+	mux.HandleFunc("/api", reply(`{"kind":"APIVersions","versions":["v1"]}`))
+	mux.HandleFunc("/api/v1", reply(`{"kind":"APIResourceList","groupVersion":"v1","resources":[]}`))
+	mux.HandleFunc("/apis", reply(`{"kind":"APIGroupList","apiVersion":"v1","groups":[{"name":"apps","versions":[{"groupVersion":"apps/v1","version":"v1"}],"preferredVersion":{"groupVersion":"apps/v1","version":"v1"}}]}`))
+	mux.HandleFunc("/apis/apps/v1", reply(`{"kind":"APIResourceList","apiVersion":"v1","groupVersion":"apps/v1","resources":[{"name":"deployments","singularName":"deployment","namespaced":true,"kind":"Deployment","verbs":["get","list","patch"]}]}`))
+	mux.HandleFunc("/apis/apps/v1/namespaces/default/deployments/api", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPatch {
+			_, _ = io.WriteString(w, deployment)
+			return
+		}
+		if conflict && r.URL.Query().Get("force") != "true" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = io.WriteString(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"Apply failed with 1 conflict: conflict with \"kubectl\": .spec.replicas","reason":"Conflict","details":{"causes":[{"reason":"FieldManagerConflict","message":"conflict with \"kubectl\"","field":".spec.replicas"}]},"code":409}`)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, applied); err != nil {
+			t.Errorf("applied body is not json: %v", err)
+		}
+		_, _ = w.Write(body)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func kubeconfigFor(t *testing.T, server string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	config := "apiVersion: v1\nkind: Config\ncurrent-context: test\n" +
+		"clusters:\n- name: test\n  cluster:\n    server: " + server + "\n" +
+		"contexts:\n- name: test\n  context:\n    cluster: test\n    user: test\n" +
+		"users:\n- name: test\n  user: {}\n"
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestEditedDeploymentYAMLApplies(t *testing.T) {
+	var applied map[string]any
+	server := fakeAppsServer(t, &applied, false)
+	api := &clientGoAPI{loader: clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigFor(t, server.URL)}}
+
+	item := resources.ResourceItem{Name: "api", Namespace: "default", Kind: "DEP"}
+	manifest, err := api.ResourceYAML("test", "default", "workloads", item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(manifest, "apiVersion: apps/v1") || !strings.Contains(manifest, "kind: Deployment") {
+		t.Fatalf("expected apps/v1 Deployment type, got:\n%s", manifest)
+	}
+
+	edited := strings.Replace(manifest, "replicas: 1", "replicas: 3", 1)
+	if _, err := api.ApplyObject(context.Background(), "test", []byte(edited), resources.ApplyOptions{}); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	spec, _ := applied["spec"].(map[string]any)
+	if applied["kind"] != "Deployment" || spec["replicas"] != float64(3) {
+		t.Fatalf("expected the edited Deployment to be applied, got %v", applied)
+	}
+}
+
+func TestApplyFieldManagerConflictCanBeForced(t *testing.T) {
+	var applied map[string]any
+	server := fakeAppsServer(t, &applied, true)
+	api := &clientGoAPI{loader: clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigFor(t, server.URL)}}
+	manifest := []byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: api\n  namespace: default\nspec:\n  replicas: 3\n")
+
+	_, err := api.ApplyObject(context.Background(), "test", manifest, resources.ApplyOptions{})
+	if !errors.Is(err, resources.ErrApplyConflict) || !strings.Contains(err.Error(), ".spec.replicas") {
+		t.Fatalf("expected a field manager conflict, got %v", err)
+	}
+	if _, err := api.ApplyObject(context.Background(), "test", manifest, resources.ApplyOptions{Force: true}); err != nil {
+		t.Fatalf("forced apply failed: %v", err)
+	}
+	if applied["kind"] != "Deployment" {
+		t.Fatalf("expected the forced apply to reach the server, got %v", applied)
+	}
+}
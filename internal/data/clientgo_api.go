@@ -19,20 +19,30 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	appslisters "k8s.io/client-go/listers/apps/v1"
 	batchlisters "k8s.io/client-go/listers/batch/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	networkinglisters "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/rest"
 	kcache "k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/yaml"
 )
 
+// maxLogLines is the floor for how many lines a one-shot log read keeps.
+// Callers asking for a larger tail get that many; the log view buffers and
+// spills beyond its own in-memory limit.
 const maxLogLines = 2000
 
 type clientGoAPI struct {
@@ -170,70 +180,70 @@ func (k *clientGoAPI) ListResourcesMeta(contextName, namespace, resourceName str
 		case "pods":
 			if inf := k.ensureInformers(contextName, client); inf != nil && inf.synced {
 				cacheBacked = true
-				out, err = k.listPodsFromInformer(inf, namespace)
+				out, err = k.listPodsFromInformer(inf, namespace, labels.Everything())
 				break
 			}
 			out, err = k.listPods(ctx, client, namespace)
 		case "services":
 			if inf := k.ensureInformers(contextName, client); inf != nil && inf.synced {
 				cacheBacked = true
-				out, err = k.listServicesFromInformer(inf, namespace)
+				out, err = k.listServicesFromInformer(inf, namespace, labels.Everything())
 				break
 			}
 			out, err = k.listServices(ctx, client, namespace)
 		case "deployments":
 			if inf := k.ensureInformers(contextName, client); inf != nil && inf.synced {
 				cacheBacked = true
-				out, err = k.listDeploymentsFromInformer(inf, namespace)
+				out, err = k.listDeploymentsFromInformer(inf, namespace, labels.Everything())
 				break
 			}
 			out, err = k.listDeployments(ctx, client, namespace)
 		case "workloads":
 			if inf := k.ensureInformers(contextName, client); inf != nil && inf.synced {
 				cacheBacked = true
-				out, err = k.listWorkloadsFromInformer(inf, namespace)
+				out, err = k.listWorkloadsFromInformer(inf, namespace, labels.Everything())
 				break
 			}
 			out, err = k.listWorkloads(ctx, client, namespace)
 		case "ingresses":
 			if inf := k.ensureInformers(contextName, client); inf != nil && inf.synced {
 				cacheBacked = true
-				out, err = k.listIngressesFromInformer(inf, namespace)
+				out, err = k.listIngressesFromInformer(inf, namespace, labels.Everything())
 				break
 			}
 			out, err = k.listIngresses(ctx, client, namespace)
 		case "configmaps":
 			if inf := k.ensureInformers(contextName, client); inf != nil && inf.synced {
 				cacheBacked = true
-				out, err = k.listConfigMapsFromInformer(inf, namespace)
+				out, err = k.listConfigMapsFromInformer(inf, namespace, labels.Everything())
 				break
 			}
 			out, err = k.listConfigMaps(ctx, client, namespace)
 		case "secrets":
 			if inf := k.ensureInformers(contextName, client); inf != nil && inf.synced {
 				cacheBacked = true
-				out, err = k.listSecretsFromInformer(inf, namespace)
+				out, err = k.listSecretsFromInformer(inf, namespace, labels.Everything())
 				break
 			}
 			out, err = k.listSecrets(ctx, client, namespace)
 		case "persistentvolumeclaims":
 			if inf := k.ensureInformers(contextName, client); inf != nil && inf.synced {
 				cacheBacked = true
-				out, err = k.listPVCsFromInformer(inf, namespace)
+				out, err = k.listPVCsFromInformer(inf, namespace, labels.Everything())
 				break
 			}
 			out, err = k.listPVCs(ctx, client, namespace)
 		case "nodes":
 			if inf := k.ensureInformers(contextName, client); inf != nil && inf.synced {
 				cacheBacked = true
-				out, err = k.listNodesFromInformer(inf)
+				out, err = k.listNodesFromInformer(inf, labels.Everything())
 				break
 			}
 			out, err = k.listNodes(ctx, client)
 		case "events":
 			if inf := k.ensureInformers(contextName, client); inf != nil && inf.synced {
 				cacheBacked = true
-				out, err = k.listEventsFromInformer(inf, namespace)
+				out, err = k.listEventsFromInformer(inf, namespace, labels.Everything())
 				break
 			}
 			out, err = k.listEvents(ctx, client, namespace)
@@ -254,6 +264,51 @@ func (k *clientGoAPI) ListResourcesMeta(contextName, namespace, resourceName str
 	return out, cacheBacked, nil
 }
 
+// ListResourcesMatching lists the items whose labels match selector straight
+// from the informer caches, which filter before items are converted. It
+// returns ErrListNotSupported until the caches of the context have synced, or
+// for resources without an informer.
+func (k *clientGoAPI) ListResourcesMatching(contextName, namespace, resourceName string, selector labels.Selector) ([]resources.ResourceItem, error) {
+	client, err := k.clientForContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	inf := k.ensureInformers(contextName, client)
+	if inf == nil || !inf.synced {
+		return nil, fmt.Errorf("%w: %s before caches sync", ErrListNotSupported, resourceName)
+	}
+	var out []resources.ResourceItem
+	switch key := strings.ToLower(strings.TrimSpace(resourceName)); key {
+	case "pods":
+		out, err = k.listPodsFromInformer(inf, namespace, selector)
+	case "services":
+		out, err = k.listServicesFromInformer(inf, namespace, selector)
+	case "deployments":
+		out, err = k.listDeploymentsFromInformer(inf, namespace, selector)
+	case "workloads":
+		out, err = k.listWorkloadsFromInformer(inf, namespace, selector)
+	case "ingresses":
+		out, err = k.listIngressesFromInformer(inf, namespace, selector)
+	case "configmaps":
+		out, err = k.listConfigMapsFromInformer(inf, namespace, selector)
+	case "secrets":
+		out, err = k.listSecretsFromInformer(inf, namespace, selector)
+	case "persistentvolumeclaims":
+		out, err = k.listPVCsFromInformer(inf, namespace, selector)
+	case "nodes":
+		out, err = k.listNodesFromInformer(inf, selector)
+	case "events":
+		out, err = k.listEventsFromInformer(inf, namespace, selector)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrListNotSupported, resourceName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	debugDataf("list resource=%s scope=%s/%s selector=%s source=informer-cache", resourceName, contextName, namespace, selector)
+	return out, nil
+}
+
 func (k *clientGoAPI) PodLogs(contextName, namespace, pod string, tail int) ([]string, error) {
 	return k.PodLogsWithOptions(context.Background(), contextName, namespace, pod, LogOptions{Tail: tail})
 }
@@ -283,7 +338,7 @@ func (k *clientGoAPI) PodLogsWithOptions(ctx context.Context, contextName, names
 	}
 	defer stream.Close()
 
-	lines, err := boundedNonEmptyLines(stream, maxLogLines)
+	lines, err := boundedNonEmptyLines(stream, maxInt(tail, maxLogLines))
 	if err != nil {
 		return nil, fmt.Errorf("failed reading logs for %s/%s: %w", namespace, pod, err)
 	}
@@ -307,13 +362,20 @@ func (k *clientGoAPI) PodLogsStreamWithOptions(ctx context.Context, contextName,
 		tail = 200
 	}
 	tail64 := int64(tail)
-	req := client.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+	logOpts := &corev1.PodLogOptions{
 		TailLines:  &tail64,
 		Follow:     opts.Follow,
 		Previous:   opts.Previous,
 		Container:  opts.Container,
 		Timestamps: opts.Timestamps,
-	})
+	}
+	if !opts.SinceTime.IsZero() {
+		// Resuming after a reconnect: everything since the last seen line,
+		// not the tail. The caller drops lines it already delivered.
+		logOpts.TailLines = nil
+		logOpts.SinceTime = &metav1.Time{Time: opts.SinceTime}
+	}
+	req := client.CoreV1().Pods(namespace).GetLogs(pod, logOpts)
 	stream, err := req.Stream(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to stream follow logs for %s/%s: %w", namespace, pod, err)
@@ -393,12 +455,59 @@ func (k *clientGoAPI) ResourceYAML(contextName, namespace, resourceName string,
 	return marshalKubeObjectYAML(obj, resourceName, item)
 }
 
+func (k *clientGoAPI) ConfigMapData(contextName, namespace, name string) (resources.ConfigMapData, error) {
+	client, err := k.clientForContext(contextName)
+	if err != nil {
+		return resources.ConfigMapData{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+	defer cancel()
+	cm, err := client.CoreV1().ConfigMaps(apiNamespace(namespace)).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return resources.ConfigMapData{}, fmt.Errorf("failed reading configmap %q: %w", name, err)
+	}
+	return resources.ConfigMapData{Data: cm.Data, BinaryData: cm.BinaryData}, nil
+}
+
+func (k *clientGoAPI) SecretData(contextName, namespace, name string) (resources.SecretData, error) {
+	client, err := k.clientForContext(contextName)
+	if err != nil {
+		return resources.SecretData{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+	defer cancel()
+	secret, err := client.CoreV1().Secrets(apiNamespace(namespace)).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return resources.SecretData{}, fmt.Errorf("failed reading secret %q: %w", name, err)
+	}
+	data := make(map[string][]byte, len(secret.Data))
+	for key, value := range secret.Data {
+		data[key] = value
+	}
+	return resources.SecretData{Type: string(secret.Type), Data: data}, nil
+}
+
 func (k *clientGoAPI) ResourceDetail(contextName, namespace, resourceName string, item resources.ResourceItem) (resources.DetailData, error) {
 	obj, err := k.resourceObject(contextName, namespace, resourceName, item)
 	if err != nil {
 		return resources.DetailData{}, err
 	}
-	return detailFromObject(obj, resourceName, item), nil
+	detail := detailFromObject(obj, resourceName, item)
+	if node, ok := obj.(*corev1.Node); ok {
+		if client, err := k.clientForContext(contextName); err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+			defer cancel()
+			pods := listPodsMatching(ctx, client, k.syncedInformers(contextName), metav1.NamespaceAll, labels.Everything(),
+				fields.OneTermEqualSelector("spec.nodeName", node.Name).String(),
+				func(p *corev1.Pod) bool { return p.Spec.NodeName == node.Name && !podTerminated(p) })
+			var total podResources
+			for i := range pods {
+				total.add(podResourceTotals(&pods[i]))
+			}
+			detail.Allocation = nodeAllocationRows(node, total)
+		}
+	}
+	return detail, nil
 }
 
 func (k *clientGoAPI) ResourceDescribe(contextName, namespace, resourceName string, item resources.ResourceItem) (string, error) {
@@ -406,7 +515,17 @@ func (k *clientGoAPI) ResourceDescribe(contextName, namespace, resourceName stri
 	if err != nil {
 		return "", err
 	}
-	return describeKubeObject(obj, resourceName, item, namespace), nil
+	client, err := k.clientForContext(contextName)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+	defer cancel()
+	desc := describeObject(obj, k.describeRelatedObjects(ctx, client, contextName, obj))
+	if desc == "" {
+		return "", fmt.Errorf("%w: describe %s", ErrObjectReadNotSupported, resourceName)
+	}
+	return desc, nil
 }
 
 func (k *clientGoAPI) resourceObject(contextName, namespace, resourceName string, item resources.ResourceItem) (any, error) {
@@ -450,11 +569,53 @@ func (k *clientGoAPI) resourceObject(contextName, namespace, resourceName string
 	case "events":
 		return client.CoreV1().Events(ns).Get(ctx, name, metav1.GetOptions{})
 	default:
+		if strings.Contains(key, ".") {
+			return k.customObject(ctx, contextName, ns, key, name)
+		}
 		return nil, fmt.Errorf("%w: %s", ErrObjectReadNotSupported, resourceName)
 	}
 }
 
+// customObject reads a custom resource named by its qualified resource name,
+// e.g. "certificates.cert-manager.io", through the dynamic client.
+func (k *clientGoAPI) customObject(ctx context.Context, contextName, namespace, resourceName, name string) (*unstructured.Unstructured, error) {
+	restCfg, err := k.restConfigForContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := discoveryRESTMapper(restCfg)
+	if err != nil {
+		return nil, err
+	}
+	gvr, err := mapper.ResourceFor(schema.ParseGroupResource(resourceName).WithVersion(""))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrObjectReadNotSupported, resourceName)
+	}
+	client, err := dynamic.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating dynamic client for context %q: %w", contextName, err)
+	}
+	if gvk, err := mapper.KindFor(gvr); err == nil {
+		if mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err == nil && mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+			return client.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
+		}
+	}
+	return client.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
 func (k *clientGoAPI) clientForContext(contextName string) (kubernetes.Interface, error) {
+	restCfg, err := k.restConfigForContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	client, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating kube client for context %q: %w", contextName, err)
+	}
+	return client, nil
+}
+
+func (k *clientGoAPI) restConfigForContext(contextName string) (*rest.Config, error) {
 	cfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 		&k.loader,
 		&clientcmd.ConfigOverrides{CurrentContext: contextName},
@@ -463,11 +624,7 @@ func (k *clientGoAPI) clientForContext(contextName string) (kubernetes.Interface
 	if err != nil {
 		return nil, fmt.Errorf("failed kube client config for context %q: %w", contextName, err)
 	}
-	client, err := kubernetes.NewForConfig(restCfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed creating kube client for context %q: %w", contextName, err)
-	}
-	return client, nil
+	return restCfg, nil
 }
 
 func (k *clientGoAPI) listPods(ctx context.Context, client kubernetes.Interface, namespace string) ([]resources.ResourceItem, error) {
@@ -489,17 +646,19 @@ func (k *clientGoAPI) listPods(ctx context.Context, client kubernetes.Interface,
 			Age:       ageString(p.CreationTimestamp.Time),
 			Labels:    copyMap(p.Labels),
 			Extra: map[string]string{
-				"node":              p.Spec.NodeName,
-				"ip":                p.Status.PodIP,
-				"qos":               string(p.Status.QOSClass),
-				"controlled-by":     controllerRefString(controllerKind, controllerName),
-				"controlled-by-uid": controllerUID,
-				"nominated-node":    p.Status.NominatedNodeName,
-				"containers":        containerNames(p.Spec.Containers),
-				"images":            containerImages(p.Spec.Containers),
-				"config-refs":       strings.Join(configRefs, ","),
-				"secret-refs":       strings.Join(secretRefs, ","),
-				"pvc-refs":          strings.Join(pvcRefs, ","),
+				"node":                 p.Spec.NodeName,
+				"ip":                   p.Status.PodIP,
+				"qos":                  string(p.Status.QOSClass),
+				"controlled-by":        controllerRefString(controllerKind, controllerName),
+				"controlled-by-uid":    controllerUID,
+				"nominated-node":       p.Status.NominatedNodeName,
+				"containers":           containerNames(p.Spec.Containers),
+				"images":               containerImages(p.Spec.Containers),
+				"init-containers":      containerNames(p.Spec.InitContainers),
+				"ephemeral-containers": ephemeralContainerNames(p.Spec.EphemeralContainers),
+				"config-refs":          strings.Join(configRefs, ","),
+				"secret-refs":          strings.Join(secretRefs, ","),
+				"pvc-refs":             strings.Join(pvcRefs, ","),
 			},
 		})
 	}
@@ -678,52 +837,16 @@ func (k *clientGoAPI) listWorkloads(ctx context.Context, client kubernetes.Inter
 	if err != nil {
 		return nil, fmt.Errorf("failed to list jobs for workloads in %q: %w", namespace, err)
 	}
-	for _, j := range jobs.Items {
-		completions := int32(1)
-		if j.Spec.Completions != nil {
-			completions = *j.Spec.Completions
-		}
-		status := "Healthy"
-		if j.Status.Failed > 0 {
-			status = "Failed"
-		} else if j.Status.Succeeded < completions {
-			status = "Progressing"
-		}
-		out = append(out, resources.ResourceItem{
-			UID:       string(j.UID),
-			Name:      j.Name,
-			Namespace: j.Namespace,
-			Kind:      "JOB",
-			Status:    status,
-			Ready:     strconv.Itoa(int(j.Status.Succeeded)) + "/" + strconv.Itoa(int(completions)),
-			Restarts:  strconv.Itoa(int(j.Status.Failed)),
-			Age:       ageString(j.CreationTimestamp.Time),
-		})
+	for i := range jobs.Items {
+		out = append(out, jobItem(&jobs.Items[i]))
 	}
 
 	cronJobs, err := client.BatchV1().CronJobs(apiNamespace(namespace)).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list cronjobs for workloads in %q: %w", namespace, err)
 	}
-	for _, cj := range cronJobs.Items {
-		ready := "Last: —"
-		if cj.Status.LastScheduleTime != nil {
-			ready = "Last: " + ageString(cj.Status.LastScheduleTime.Time)
-		}
-		status := "Healthy"
-		if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
-			status = "Suspended"
-		}
-		out = append(out, resources.ResourceItem{
-			UID:       string(cj.UID),
-			Name:      cj.Name,
-			Namespace: cj.Namespace,
-			Kind:      "CJ",
-			Status:    status,
-			Ready:     ready,
-			Restarts:  "—",
-			Age:       ageString(cj.CreationTimestamp.Time),
-		})
+	for i := range cronJobs.Items {
+		out = append(out, cronJobItem(&cronJobs.Items[i]))
 	}
 
 	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
@@ -883,7 +1006,7 @@ func (k *clientGoAPI) listNodes(ctx context.Context, client kubernetes.Interface
 	}
 	out := make([]resources.ResourceItem, 0, len(list.Items))
 	for _, n := range list.Items {
-		out = append(out, resources.ResourceItem{
+		item := resources.ResourceItem{
 			UID:    string(n.UID),
 			Name:   n.Name,
 			Status: nodeReadyStatus(n),
@@ -899,7 +1022,9 @@ func (k *clientGoAPI) listNodes(ctx context.Context, client kubernetes.Interface
 				"zone":           nodeLabel(n.Labels, "topology.kubernetes.io/zone"),
 				"taints":         strconv.Itoa(len(n.Spec.Taints)),
 			},
-		})
+		}
+		setNodeAllocationExtra(item.Extra, &n, nil)
+		out = append(out, item)
 	}
 	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
 	return out, nil
@@ -1010,15 +1135,15 @@ func (k *clientGoAPI) ensureInformers(contextName string, client kubernetes.Inte
 	return inf
 }
 
-func (k *clientGoAPI) listPodsFromInformer(inf *contextInformers, namespace string) ([]resources.ResourceItem, error) {
+func (k *clientGoAPI) listPodsFromInformer(inf *contextInformers, namespace string, selector labels.Selector) ([]resources.ResourceItem, error) {
 	var (
 		pods []*corev1.Pod
 		err  error
 	)
 	if namespace == resources.AllNamespaces {
-		pods, err = inf.pods.List(labels.Everything())
+		pods, err = inf.pods.List(selector)
 	} else {
-		pods, err = inf.pods.Pods(namespace).List(labels.Everything())
+		pods, err = inf.pods.Pods(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
@@ -1037,17 +1162,19 @@ func (k *clientGoAPI) listPodsFromInformer(inf *contextInformers, namespace stri
 			Age:       ageString(p.CreationTimestamp.Time),
 			Labels:    copyMap(p.Labels),
 			Extra: map[string]string{
-				"node":              p.Spec.NodeName,
-				"ip":                p.Status.PodIP,
-				"qos":               string(p.Status.QOSClass),
-				"controlled-by":     controllerRefString(controllerKind, controllerName),
-				"controlled-by-uid": controllerUID,
-				"nominated-node":    p.Status.NominatedNodeName,
-				"containers":        containerNames(p.Spec.Containers),
-				"images":            containerImages(p.Spec.Containers),
-				"config-refs":       strings.Join(configRefs, ","),
-				"secret-refs":       strings.Join(secretRefs, ","),
-				"pvc-refs":          strings.Join(pvcRefs, ","),
+				"node":                 p.Spec.NodeName,
+				"ip":                   p.Status.PodIP,
+				"qos":                  string(p.Status.QOSClass),
+				"controlled-by":        controllerRefString(controllerKind, controllerName),
+				"controlled-by-uid":    controllerUID,
+				"nominated-node":       p.Status.NominatedNodeName,
+				"containers":           containerNames(p.Spec.Containers),
+				"images":               containerImages(p.Spec.Containers),
+				"init-containers":      containerNames(p.Spec.InitContainers),
+				"ephemeral-containers": ephemeralContainerNames(p.Spec.EphemeralContainers),
+				"config-refs":          strings.Join(configRefs, ","),
+				"secret-refs":          strings.Join(secretRefs, ","),
+				"pvc-refs":             strings.Join(pvcRefs, ","),
 			},
 		})
 	}
@@ -1055,15 +1182,15 @@ func (k *clientGoAPI) listPodsFromInformer(inf *contextInformers, namespace stri
 	return out, nil
 }
 
-func (k *clientGoAPI) listServicesFromInformer(inf *contextInformers, namespace string) ([]resources.ResourceItem, error) {
+func (k *clientGoAPI) listServicesFromInformer(inf *contextInformers, namespace string, selector labels.Selector) ([]resources.ResourceItem, error) {
 	var (
 		services []*corev1.Service
 		err      error
 	)
 	if namespace == resources.AllNamespaces {
-		services, err = inf.services.List(labels.Everything())
+		services, err = inf.services.List(selector)
 	} else {
-		services, err = inf.services.Services(namespace).List(labels.Everything())
+		services, err = inf.services.Services(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
@@ -1098,15 +1225,15 @@ func (k *clientGoAPI) listServicesFromInformer(inf *contextInformers, namespace
 	return out, nil
 }
 
-func (k *clientGoAPI) listDeploymentsFromInformer(inf *contextInformers, namespace string) ([]resources.ResourceItem, error) {
+func (k *clientGoAPI) listDeploymentsFromInformer(inf *contextInformers, namespace string, selector labels.Selector) ([]resources.ResourceItem, error) {
 	var (
 		deployments []*appsv1.Deployment
 		err         error
 	)
 	if namespace == resources.AllNamespaces {
-		deployments, err = inf.deployments.List(labels.Everything())
+		deployments, err = inf.deployments.List(selector)
 	} else {
-		deployments, err = inf.deployments.Deployments(namespace).List(labels.Everything())
+		deployments, err = inf.deployments.Deployments(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
@@ -1137,9 +1264,9 @@ func (k *clientGoAPI) listDeploymentsFromInformer(inf *contextInformers, namespa
 	return out, nil
 }
 
-func (k *clientGoAPI) listWorkloadsFromInformer(inf *contextInformers, namespace string) ([]resources.ResourceItem, error) {
+func (k *clientGoAPI) listWorkloadsFromInformer(inf *contextInformers, namespace string, selector labels.Selector) ([]resources.ResourceItem, error) {
 	out := make([]resources.ResourceItem, 0)
-	deployments, err := k.listDeploymentsFromInformer(inf, namespace)
+	deployments, err := k.listDeploymentsFromInformer(inf, namespace, selector)
 	if err != nil {
 		return nil, err
 	}
@@ -1151,9 +1278,9 @@ func (k *clientGoAPI) listWorkloadsFromInformer(inf *contextInformers, namespace
 
 	var statefulSets []*appsv1.StatefulSet
 	if namespace == resources.AllNamespaces {
-		statefulSets, err = inf.statefulSets.List(labels.Everything())
+		statefulSets, err = inf.statefulSets.List(selector)
 	} else {
-		statefulSets, err = inf.statefulSets.StatefulSets(namespace).List(labels.Everything())
+		statefulSets, err = inf.statefulSets.StatefulSets(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
@@ -1192,9 +1319,9 @@ func (k *clientGoAPI) listWorkloadsFromInformer(inf *contextInformers, namespace
 
 	var daemonSets []*appsv1.DaemonSet
 	if namespace == resources.AllNamespaces {
-		daemonSets, err = inf.daemonSets.List(labels.Everything())
+		daemonSets, err = inf.daemonSets.List(selector)
 	} else {
-		daemonSets, err = inf.daemonSets.DaemonSets(namespace).List(labels.Everything())
+		daemonSets, err = inf.daemonSets.DaemonSets(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
@@ -1231,79 +1358,43 @@ func (k *clientGoAPI) listWorkloadsFromInformer(inf *contextInformers, namespace
 
 	var jobs []*batchv1.Job
 	if namespace == resources.AllNamespaces {
-		jobs, err = inf.jobs.List(labels.Everything())
+		jobs, err = inf.jobs.List(selector)
 	} else {
-		jobs, err = inf.jobs.Jobs(namespace).List(labels.Everything())
+		jobs, err = inf.jobs.Jobs(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
 	}
 	for _, j := range jobs {
-		completions := int32(1)
-		if j.Spec.Completions != nil {
-			completions = *j.Spec.Completions
-		}
-		status := "Healthy"
-		if j.Status.Failed > 0 {
-			status = "Failed"
-		} else if j.Status.Succeeded < completions {
-			status = "Progressing"
-		}
-		out = append(out, resources.ResourceItem{
-			UID:       string(j.UID),
-			Name:      j.Name,
-			Namespace: j.Namespace,
-			Kind:      "JOB",
-			Status:    status,
-			Ready:     strconv.Itoa(int(j.Status.Succeeded)) + "/" + strconv.Itoa(int(completions)),
-			Restarts:  strconv.Itoa(int(j.Status.Failed)),
-			Age:       ageString(j.CreationTimestamp.Time),
-		})
+		out = append(out, jobItem(j))
 	}
 
 	var cronJobs []*batchv1.CronJob
 	if namespace == resources.AllNamespaces {
-		cronJobs, err = inf.cronJobs.List(labels.Everything())
+		cronJobs, err = inf.cronJobs.List(selector)
 	} else {
-		cronJobs, err = inf.cronJobs.CronJobs(namespace).List(labels.Everything())
+		cronJobs, err = inf.cronJobs.CronJobs(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
 	}
 	for _, cj := range cronJobs {
-		ready := "Last: —"
-		if cj.Status.LastScheduleTime != nil {
-			ready = "Last: " + ageString(cj.Status.LastScheduleTime.Time)
-		}
-		status := "Healthy"
-		if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
-			status = "Suspended"
-		}
-		out = append(out, resources.ResourceItem{
-			UID:       string(cj.UID),
-			Name:      cj.Name,
-			Namespace: cj.Namespace,
-			Kind:      "CJ",
-			Status:    status,
-			Ready:     ready,
-			Restarts:  "—",
-			Age:       ageString(cj.CreationTimestamp.Time),
-		})
+		out = append(out, cronJobItem(cj))
 	}
 
 	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
 	return out, nil
 }
 
-func (k *clientGoAPI) listIngressesFromInformer(inf *contextInformers, namespace string) ([]resources.ResourceItem, error) {
+func (k *clientGoAPI) listIngressesFromInformer(inf *contextInformers, namespace string, selector labels.Selector) ([]resources.ResourceItem, error) {
 	var (
 		ingresses []*networkingv1.Ingress
 		err       error
 	)
 	if namespace == resources.AllNamespaces {
-		ingresses, err = inf.ingresses.List(labels.Everything())
+		ingresses, err = inf.ingresses.List(selector)
 	} else {
-		ingresses, err = inf.ingresses.Ingresses(namespace).List(labels.Everything())
+		ingresses, err = inf.ingresses.Ingresses(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
@@ -1342,15 +1433,15 @@ func (k *clientGoAPI) listIngressesFromInformer(inf *contextInformers, namespace
 	return out, nil
 }
 
-func (k *clientGoAPI) listConfigMapsFromInformer(inf *contextInformers, namespace string) ([]resources.ResourceItem, error) {
+func (k *clientGoAPI) listConfigMapsFromInformer(inf *contextInformers, namespace string, selector labels.Selector) ([]resources.ResourceItem, error) {
 	var (
 		configMaps []*corev1.ConfigMap
 		err        error
 	)
 	if namespace == resources.AllNamespaces {
-		configMaps, err = inf.configMaps.List(labels.Everything())
+		configMaps, err = inf.configMaps.List(selector)
 	} else {
-		configMaps, err = inf.configMaps.ConfigMaps(namespace).List(labels.Everything())
+		configMaps, err = inf.configMaps.ConfigMaps(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
@@ -1378,15 +1469,15 @@ func (k *clientGoAPI) listConfigMapsFromInformer(inf *contextInformers, namespac
 	return out, nil
 }
 
-func (k *clientGoAPI) listSecretsFromInformer(inf *contextInformers, namespace string) ([]resources.ResourceItem, error) {
+func (k *clientGoAPI) listSecretsFromInformer(inf *contextInformers, namespace string, selector labels.Selector) ([]resources.ResourceItem, error) {
 	var (
 		secrets []*corev1.Secret
 		err     error
 	)
 	if namespace == resources.AllNamespaces {
-		secrets, err = inf.secrets.List(labels.Everything())
+		secrets, err = inf.secrets.List(selector)
 	} else {
-		secrets, err = inf.secrets.Secrets(namespace).List(labels.Everything())
+		secrets, err = inf.secrets.Secrets(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
@@ -1406,15 +1497,15 @@ func (k *clientGoAPI) listSecretsFromInformer(inf *contextInformers, namespace s
 	return out, nil
 }
 
-func (k *clientGoAPI) listPVCsFromInformer(inf *contextInformers, namespace string) ([]resources.ResourceItem, error) {
+func (k *clientGoAPI) listPVCsFromInformer(inf *contextInformers, namespace string, selector labels.Selector) ([]resources.ResourceItem, error) {
 	var (
 		pvcs []*corev1.PersistentVolumeClaim
 		err  error
 	)
 	if namespace == resources.AllNamespaces {
-		pvcs, err = inf.pvcs.List(labels.Everything())
+		pvcs, err = inf.pvcs.List(selector)
 	} else {
-		pvcs, err = inf.pvcs.PersistentVolumeClaims(namespace).List(labels.Everything())
+		pvcs, err = inf.pvcs.PersistentVolumeClaims(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
@@ -1445,14 +1536,18 @@ func (k *clientGoAPI) listPVCsFromInformer(inf *contextInformers, namespace stri
 	return out, nil
 }
 
-func (k *clientGoAPI) listNodesFromInformer(inf *contextInformers) ([]resources.ResourceItem, error) {
-	nodes, err := inf.nodes.List(labels.Everything())
+func (k *clientGoAPI) listNodesFromInformer(inf *contextInformers, selector labels.Selector) ([]resources.ResourceItem, error) {
+	nodes, err := inf.nodes.List(selector)
 	if err != nil {
 		return nil, err
 	}
+	var byNode map[string]podResources
+	if pods, err := inf.pods.List(labels.Everything()); err == nil {
+		byNode = allocationByNode(pods)
+	}
 	out := make([]resources.ResourceItem, 0, len(nodes))
 	for _, n := range nodes {
-		out = append(out, resources.ResourceItem{
+		item := resources.ResourceItem{
 			UID:    string(n.UID),
 			Name:   n.Name,
 			Status: nodeReadyStatus(*n),
@@ -1468,21 +1563,23 @@ func (k *clientGoAPI) listNodesFromInformer(inf *contextInformers) ([]resources.
 				"zone":           nodeLabel(n.Labels, "topology.kubernetes.io/zone"),
 				"taints":         strconv.Itoa(len(n.Spec.Taints)),
 			},
-		})
+		}
+		setNodeAllocationExtra(item.Extra, n, byNode)
+		out = append(out, item)
 	}
 	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
 	return out, nil
 }
 
-func (k *clientGoAPI) listEventsFromInformer(inf *contextInformers, namespace string) ([]resources.ResourceItem, error) {
+func (k *clientGoAPI) listEventsFromInformer(inf *contextInformers, namespace string, selector labels.Selector) ([]resources.ResourceItem, error) {
 	var (
 		events []*corev1.Event
 		err    error
 	)
 	if namespace == resources.AllNamespaces {
-		events, err = inf.events.List(labels.Everything())
+		events, err = inf.events.List(selector)
 	} else {
-		events, err = inf.events.Events(namespace).List(labels.Everything())
+		events, err = inf.events.Events(namespace).List(selector)
 	}
 	if err != nil {
 		return nil, err
@@ -1767,6 +1864,14 @@ func containerNames(containers []corev1.Container) string {
 	return strings.Join(names, ",")
 }
 
+func ephemeralContainerNames(containers []corev1.EphemeralContainer) string {
+	names := make([]string, 0, len(containers))
+	for _, c := range containers {
+		names = append(names, c.Name)
+	}
+	return strings.Join(names, ",")
+}
+
 func containerImages(containers []corev1.Container) string {
 	images := make([]string, 0, len(containers))
 	for _, c := range containers {
@@ -1792,7 +1897,18 @@ func workloadObject(ctx context.Context, client kubernetes.Interface, namespace,
 	}
 }
 
+// marshalKubeObjectYAML renders an object read from the API as YAML that can
+// be edited and applied. Typed clients drop apiVersion and kind when they
+// decode, so those are restored from the client-go scheme; the list item only
+// fills in what the scheme does not know.
 func marshalKubeObjectYAML(obj any, resourceName string, item resources.ResourceItem) (string, error) {
+	if typed, ok := obj.(runtime.Object); ok && typed.GetObjectKind().GroupVersionKind().Empty() {
+		if gvks, _, err := scheme.Scheme.ObjectKinds(typed); err == nil && len(gvks) > 0 {
+			typed = typed.DeepCopyObject()
+			typed.GetObjectKind().SetGroupVersionKind(gvks[0])
+			obj = typed
+		}
+	}
 	raw, err := json.Marshal(obj)
 	if err != nil {
 		return "", fmt.Errorf("failed marshaling %s object: %w", resourceName, err)
@@ -1825,184 +1941,58 @@ func marshalKubeObjectYAML(obj any, resourceName string, item resources.Resource
 	return string(out), nil
 }
 
-func describeKubeObject(obj any, resourceName string, item resources.ResourceItem, fallbackNamespace string) string {
-	kind := valueOr(item.Kind, singularKindName(resourceName))
-	name := valueOr(item.Name, "<unknown>")
-	ns := valueOr(item.Namespace, fallbackNamespace)
-	lines := []string{
-		"Name:        " + name,
-		"Namespace:   " + valueOr(ns, resources.DefaultNamespace),
-		"Kind:        " + kind,
+// podContainerRows lists init, app and ephemeral containers in that order,
+// matching kubectl describe, with state, restarts and last exit code taken
+// from the matching container status.
+func podContainerRows(p *corev1.Pod) []resources.ContainerRow {
+	rows := make([]resources.ContainerRow, 0, len(p.Spec.InitContainers)+len(p.Spec.Containers)+len(p.Spec.EphemeralContainers))
+	for _, c := range p.Spec.InitContainers {
+		rows = append(rows, containerRow(c.Name, c.Image, resources.ContainerTypeInit, p.Status.InitContainerStatuses))
 	}
-	switch o := obj.(type) {
-	case *corev1.Pod:
-		lines = append(lines,
-			"Status:      "+valueOr(string(o.Status.Phase), "Unknown"),
-			"Node:        "+valueOr(o.Spec.NodeName, "<none>"),
-			"IP:          "+valueOr(o.Status.PodIP, "<none>"),
-			"Containers:  "+containerNames(o.Spec.Containers),
-		)
-	case *corev1.Service:
-		ports := servicePortsString(o.Spec.Ports)
-		externalIPs := "<none>"
-		if len(o.Spec.ExternalIPs) > 0 {
-			externalIPs = strings.Join(o.Spec.ExternalIPs, ",")
-		}
-		lines = append(lines,
-			"Type:        "+valueOr(string(o.Spec.Type), "ClusterIP"),
-			"ClusterIP:   "+valueOr(o.Spec.ClusterIP, "<none>"),
-			"Selector:    "+valueOr(labelSelectorString(o.Spec.Selector), "<none>"),
-			"Ports:       "+valueOr(ports, "<none>"),
-			"External IP: "+externalIPs,
-		)
-	case *appsv1.Deployment:
-		desired := int32(1)
-		if o.Spec.Replicas != nil {
-			desired = *o.Spec.Replicas
-		}
-		lines = append(lines,
-			"Status:      "+deploymentStatus(*o),
-			"Ready:       "+strconv.Itoa(int(o.Status.ReadyReplicas))+"/"+strconv.Itoa(int(desired)),
-			"Strategy:    "+valueOr(string(o.Spec.Strategy.Type), "<none>"),
-			"Images:      "+containerImages(o.Spec.Template.Spec.Containers),
-		)
-	case *appsv1.StatefulSet:
-		desired := int32(1)
-		if o.Spec.Replicas != nil {
-			desired = *o.Spec.Replicas
-		}
-		lines = append(lines,
-			"Status:      "+valueOr(item.Status, "Progressing"),
-			"Ready:       "+strconv.Itoa(int(o.Status.ReadyReplicas))+"/"+strconv.Itoa(int(desired)),
-			"Service:     "+valueOr(o.Spec.ServiceName, "<none>"),
-			"Selector:    "+valueOr(labelSelectorString(o.Spec.Selector.MatchLabels), "<none>"),
-			"Images:      "+containerImages(o.Spec.Template.Spec.Containers),
-		)
-	case *appsv1.DaemonSet:
-		lines = append(lines,
-			"Status:      "+valueOr(item.Status, "Healthy"),
-			"Ready:       "+strconv.Itoa(int(o.Status.NumberReady))+"/"+strconv.Itoa(int(o.Status.DesiredNumberScheduled)),
-			"Selector:    "+valueOr(labelSelectorString(o.Spec.Selector.MatchLabels), "<none>"),
-			"Images:      "+containerImages(o.Spec.Template.Spec.Containers),
-		)
-	case *batchv1.Job:
-		completions := int32(1)
-		if o.Spec.Completions != nil {
-			completions = *o.Spec.Completions
-		}
-		lines = append(lines,
-			"Status:      "+valueOr(item.Status, "Running"),
-			"Completions: "+strconv.Itoa(int(o.Status.Succeeded))+"/"+strconv.Itoa(int(completions)),
-			"Parallelism: "+strconv.Itoa(int(ptrInt32(o.Spec.Parallelism, 1))),
-			"Images:      "+containerImages(o.Spec.Template.Spec.Containers),
-		)
-	case *batchv1.CronJob:
-		suspend := "false"
-		if o.Spec.Suspend != nil && *o.Spec.Suspend {
-			suspend = "true"
-		}
-		lines = append(lines,
-			"Status:      Scheduled",
-			"Schedule:    "+valueOr(o.Spec.Schedule, "<none>"),
-			"Suspend:     "+suspend,
-			"Images:      "+containerImages(o.Spec.JobTemplate.Spec.Template.Spec.Containers),
-		)
-	case *corev1.ConfigMap:
-		lines = append(lines, "Data Keys:    "+strconv.Itoa(len(o.Data)))
-	case *corev1.Secret:
-		lines = append(lines,
-			"Type:        "+valueOr(string(o.Type), "Opaque"),
-			"Data Keys:    "+strconv.Itoa(len(o.Data)),
-		)
-	case *corev1.PersistentVolumeClaim:
-		lines = append(lines,
-			"Status:      "+valueOr(string(o.Status.Phase), "Unknown"),
-			"Volume:      "+valueOr(o.Spec.VolumeName, "<none>"),
-		)
-	case *networkingv1.Ingress:
-		services := ingressBackendServices(*o)
-		servicesValue := "<none>"
-		if len(services) > 0 {
-			servicesValue = strings.Join(services, ",")
-		}
-		lines = append(lines,
-			"Class:       "+valueOr(ptrString(o.Spec.IngressClassName), "<none>"),
-			"Hosts:       "+valueOr(ingressHosts(o.Spec.Rules), "*"),
-			"Services:    "+servicesValue,
-			"Backends:",
-		)
-		for _, backend := range ingressBackendRoutes(*o) {
-			lines = append(lines, "  "+backend)
-		}
-	case *corev1.Node:
-		lines = append(lines,
-			"Status:      "+valueOr(nodeReadyStatus(*o), "Unknown"),
-			"Pod CIDR:    "+valueOr(o.Spec.PodCIDR, "<none>"),
-		)
-	case *corev1.Namespace:
-		lines = append(lines, "Status:      "+valueOr(string(o.Status.Phase), "Unknown"))
-	case *corev1.Event:
-		ts := eventTime(*o)
-		tsValue := "<none>"
-		if !ts.IsZero() {
-			tsValue = ts.UTC().Format(time.RFC3339)
+	for _, c := range p.Spec.Containers {
+		rows = append(rows, containerRow(c.Name, c.Image, "", p.Status.ContainerStatuses))
+	}
+	for _, c := range p.Spec.EphemeralContainers {
+		rows = append(rows, containerRow(c.Name, c.Image, resources.ContainerTypeEphemeral, p.Status.EphemeralContainerStatuses))
+	}
+	return rows
+}
+
+func containerRow(name, image, containerType string, statuses []corev1.ContainerStatus) resources.ContainerRow {
+	row := resources.ContainerRow{
+		Name:     name,
+		Image:    image,
+		State:    "Unknown",
+		Restarts: "0",
+		Type:     containerType,
+	}
+	for _, cs := range statuses {
+		if cs.Name != name {
+			continue
 		}
-		source := "<none>"
-		if strings.TrimSpace(o.Source.Component) != "" {
-			source = o.Source.Component
+		row.Restarts = strconv.Itoa(int(cs.RestartCount))
+		switch {
+		case cs.State.Running != nil:
+			row.State = "Running"
+		case cs.State.Waiting != nil:
+			row.State = "Waiting"
+			row.Reason = cs.State.Waiting.Reason
+		case cs.State.Terminated != nil:
+			row.State = "Terminated"
+			row.Reason = cs.State.Terminated.Reason
+			row.ExitCode = strconv.Itoa(int(cs.State.Terminated.ExitCode))
 		}
-		if strings.TrimSpace(o.Source.Host) != "" {
-			source += "/" + o.Source.Host
+		if row.ExitCode == "" && cs.LastTerminationState.Terminated != nil {
+			row.ExitCode = strconv.Itoa(int(cs.LastTerminationState.Terminated.ExitCode))
 		}
-		lines = append(lines,
-			"Type:        "+valueOr(o.Type, "Normal"),
-			"Reason:      "+valueOr(o.Reason, "<none>"),
-			"Involved:    "+valueOr(o.InvolvedObject.Kind, "<none>")+"/"+valueOr(o.InvolvedObject.Name, "<none>"),
-			"Source:      "+source,
-			"Count:       "+strconv.Itoa(int(o.Count)),
-			"Last Seen:   "+tsValue,
-			"Message:     "+valueOr(strings.TrimSpace(o.Message), "<none>"),
-		)
+		break
 	}
-	if status := strings.TrimSpace(item.Status); status != "" {
-		lines = append(lines, "List Status: "+status)
-	}
-	if ready := strings.TrimSpace(item.Ready); ready != "" {
-		lines = append(lines, "List Ready:  "+ready)
-	}
-	return strings.Join(lines, "\n")
+	return row
 }
 
 func detailFromObject(obj any, resourceName string, item resources.ResourceItem) resources.DetailData {
 	switch o := obj.(type) {
 	case *corev1.Pod:
-		containers := make([]resources.ContainerRow, 0, len(o.Spec.Containers))
-		for _, c := range o.Spec.Containers {
-			state := "Unknown"
-			reason := ""
-			for _, cs := range o.Status.ContainerStatuses {
-				if cs.Name != c.Name {
-					continue
-				}
-				if cs.State.Running != nil {
-					state = "Running"
-				} else if cs.State.Waiting != nil {
-					state = "Waiting"
-					reason = cs.State.Waiting.Reason
-				} else if cs.State.Terminated != nil {
-					state = "Terminated"
-					reason = cs.State.Terminated.Reason
-				}
-				break
-			}
-			containers = append(containers, resources.ContainerRow{
-				Name:     c.Name,
-				Image:    c.Image,
-				State:    state,
-				Restarts: "0",
-				Reason:   reason,
-			})
-		}
 		return resources.DetailData{
 			Summary: []resources.SummaryField{
 				{Key: "status", Label: "Status", Value: valueOr(string(o.Status.Phase), "Unknown")},
@@ -2011,7 +2001,7 @@ func detailFromObject(obj any, resourceName string, item resources.ResourceItem)
 				{Key: "ip", Label: "IP", Value: valueOr(o.Status.PodIP, "<none>")},
 				{Key: "qos", Label: "QoS", Value: valueOr(string(o.Status.QOSClass), "Unknown")},
 			},
-			Containers: containers,
+			Containers: podContainerRows(o),
 			Labels:     labelsFromMap(o.Labels),
 		}
 	case *corev1.Service:
@@ -2243,32 +2233,6 @@ func servicePortsString(ports []corev1.ServicePort) string {
 	return strings.Join(out, ",")
 }
 
-func ingressBackendRoutes(ing networkingv1.Ingress) []string {
-	out := make([]string, 0)
-	for _, rule := range ing.Spec.Rules {
-		host := strings.TrimSpace(rule.Host)
-		if host == "" {
-			host = "*"
-		}
-		if rule.HTTP == nil {
-			continue
-		}
-		for _, path := range rule.HTTP.Paths {
-			svc := "<none>"
-			if path.Backend.Service != nil {
-				svc = path.Backend.Service.Name
-			}
-			p := strings.TrimSpace(path.Path)
-			if p == "" {
-				p = "/"
-			}
-			out = append(out, host+" "+p+" -> "+svc)
-		}
-	}
-	sort.Strings(out)
-	return out
-}
-
 func ptrString(v *string) string {
 	if v == nil {
 		return ""
@@ -2308,6 +2272,13 @@ func minInt(a, b int) int {
 	return b
 }
 
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func (k *clientGoAPI) namespaceCacheGet(contextName string) ([]string, bool) {
 	k.mu.Lock()
 	defer k.mu.Unlock()
@@ -138,27 +138,6 @@ func TestDetailFromObjectIngressIncludesHostsAndServices(t *testing.T) {
 	}
 }
 
-func TestDescribeKubeObjectStatefulSetIncludesServiceAndReady(t *testing.T) {
-	replicas := int32(2)
-	obj := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{Name: "db"},
-		Spec: appsv1.StatefulSetSpec{
-			Replicas:    &replicas,
-			ServiceName: "db-headless",
-			Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
-		},
-		Status: appsv1.StatefulSetStatus{ReadyReplicas: 1},
-	}
-	out := describeKubeObject(obj, "workloads", resources.ResourceItem{
-		Name:   "db",
-		Kind:   "STS",
-		Status: "Progressing",
-	}, "default")
-	if !strings.Contains(out, "Service:     db-headless") || !strings.Contains(out, "Ready:       1/2") {
-		t.Fatalf("expected sts describe fields, got %q", out)
-	}
-}
-
 func TestDetailFromObjectEventIncludesSourceCountAndTimestamp(t *testing.T) {
 	ts := metav1.NewTime(time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC))
 	obj := &corev1.Event{
@@ -184,25 +163,6 @@ func TestDetailFromObjectEventIncludesSourceCountAndTimestamp(t *testing.T) {
 	}
 }
 
-func TestDescribeKubeObjectServiceIncludesPorts(t *testing.T) {
-	obj := &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{Name: "api"},
-		Spec: corev1.ServiceSpec{
-			Type:      corev1.ServiceTypeClusterIP,
-			ClusterIP: "10.96.0.12",
-			Selector:  map[string]string{"app": "api"},
-			Ports: []corev1.ServicePort{
-				{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP},
-				{Name: "metrics", Port: 9090, Protocol: corev1.ProtocolTCP},
-			},
-		},
-	}
-	out := describeKubeObject(obj, "services", resources.ResourceItem{Name: "api", Kind: "Service"}, "default")
-	if !strings.Contains(out, "Ports:       http:80/TCP,metrics:9090/TCP") {
-		t.Fatalf("expected service ports in describe output, got %q", out)
-	}
-}
-
 func TestEnsureInformersReturnsWithoutSyncWait(t *testing.T) {
 	api := &clientGoAPI{
 		inf: map[string]*contextInformers{},
@@ -228,3 +188,41 @@ func summaryValues(summary []resources.SummaryField) []string {
 	}
 	return out
 }
+
+func TestPodContainerRowsListsInitAppAndEphemeral(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate", Image: "migrate:1"}},
+			Containers:     []corev1.Container{{Name: "api", Image: "api:1"}},
+			EphemeralContainers: []corev1.EphemeralContainer{{
+				EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger", Image: "busybox"},
+			}},
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "migrate",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed", ExitCode: 0}},
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:                 "api",
+				RestartCount:         3,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137}},
+			}},
+		},
+	}
+
+	rows := podContainerRows(pod)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 containers, got %#v", rows)
+	}
+	if rows[0].Name != "migrate" || rows[0].Type != resources.ContainerTypeInit || rows[0].ExitCode != "0" || rows[0].Reason != "Completed" {
+		t.Fatalf("unexpected init row: %#v", rows[0])
+	}
+	if rows[1].Name != "api" || rows[1].Type != "" || rows[1].Restarts != "3" || rows[1].ExitCode != "137" || rows[1].Reason != "CrashLoopBackOff" {
+		t.Fatalf("unexpected app row: %#v", rows[1])
+	}
+	if rows[2].Name != "debugger" || rows[2].Type != resources.ContainerTypeEphemeral || rows[2].State != "Unknown" {
+		t.Fatalf("unexpected ephemeral row: %#v", rows[2])
+	}
+}
@@ -0,0 +1,143 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dloss/podji/internal/resources"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// jobItem lists a Job as a workload. Jobs created by a CronJob record their
+// owner so the CronJob's run history can be built from the Job list.
+func jobItem(j *batchv1.Job) resources.ResourceItem {
+	completions := int32(1)
+	if j.Spec.Completions != nil {
+		completions = *j.Spec.Completions
+	}
+	status := "Healthy"
+	if j.Status.Failed > 0 {
+		status = "Failed"
+	} else if j.Status.Succeeded < completions {
+		status = "Progressing"
+	}
+	extra := map[string]string{
+		"created":  strconv.FormatInt(j.CreationTimestamp.Unix(), 10),
+		"duration": jobDuration(j),
+	}
+	if ref := metav1.GetControllerOf(j); ref != nil {
+		extra["controlled-by"] = ref.Kind + "/" + ref.Name
+		extra["controlled-by-uid"] = string(ref.UID)
+	}
+	item := resources.ResourceItem{
+		UID:       string(j.UID),
+		Name:      j.Name,
+		Namespace: j.Namespace,
+		Kind:      "JOB",
+		Status:    status,
+		Ready:     strconv.Itoa(int(j.Status.Succeeded)) + "/" + strconv.Itoa(int(completions)),
+		Restarts:  strconv.Itoa(int(j.Status.Failed)),
+		Age:       ageString(j.CreationTimestamp.Time),
+		Extra:     extra,
+	}
+	if j.Spec.Selector != nil {
+		item.Selector = copyMap(j.Spec.Selector.MatchLabels)
+	}
+	return item
+}
+
+// jobDuration is the run time of a finished Job, or the time so far of a
+// running one.
+func jobDuration(j *batchv1.Job) string {
+	if j.Status.StartTime == nil {
+		return "-"
+	}
+	end := time.Now()
+	if j.Status.CompletionTime != nil {
+		end = j.Status.CompletionTime.Time
+	}
+	return shortDuration(end.Sub(j.Status.StartTime.Time))
+}
+
+func cronJobItem(cj *batchv1.CronJob) resources.ResourceItem {
+	ready := "Last: —"
+	if cj.Status.LastScheduleTime != nil {
+		ready = "Last: " + ageString(cj.Status.LastScheduleTime.Time)
+	}
+	status := "Healthy"
+	if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+		status = "Suspended"
+	}
+	lastSuccess := "—"
+	if cj.Status.LastSuccessfulTime != nil {
+		lastSuccess = ageString(cj.Status.LastSuccessfulTime.Time) + " ago"
+	}
+	schedule := cj.Spec.Schedule
+	if cj.Spec.TimeZone != nil && *cj.Spec.TimeZone != "" {
+		schedule += " (" + *cj.Spec.TimeZone + ")"
+	}
+	return resources.ResourceItem{
+		UID:       string(cj.UID),
+		Name:      cj.Name,
+		Namespace: cj.Namespace,
+		Kind:      "CJ",
+		Status:    status,
+		Ready:     ready,
+		Restarts:  "—",
+		Age:       ageString(cj.CreationTimestamp.Time),
+		Extra: map[string]string{
+			"schedule":     schedule,
+			"last-success": lastSuccess,
+		},
+	}
+}
+
+// CreateJobFromCronJob runs a CronJob now, like kubectl create job
+// --from=cronjob/NAME: the new Job copies the jobTemplate and is owned by the
+// CronJob, so it shows up in the run history.
+func (k *clientGoAPI) CreateJobFromCronJob(ctx context.Context, contextName, namespace, name string) (resources.ResourceItem, error) {
+	client, err := k.clientForContext(contextName)
+	if err != nil {
+		return resources.ResourceItem{}, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 8*time.Second)
+	defer cancel()
+	return createJobFromCronJob(ctx, client, namespace, name, time.Now())
+}
+
+func createJobFromCronJob(ctx context.Context, client kubernetes.Interface, namespace, name string, now time.Time) (resources.ResourceItem, error) {
+	cj, err := client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return resources.ResourceItem{}, fmt.Errorf("failed reading cronjob %q: %w", name, err)
+	}
+	job, err := client.BatchV1().Jobs(namespace).Create(ctx, jobFromCronJob(cj, now), metav1.CreateOptions{})
+	if err != nil {
+		return resources.ResourceItem{}, fmt.Errorf("failed creating job from cronjob %q: %w", name, err)
+	}
+	return jobItem(job), nil
+}
+
+func jobFromCronJob(cj *batchv1.CronJob, now time.Time) *batchv1.Job {
+	prefix := cj.Name
+	if len(prefix) > 45 {
+		prefix = prefix[:45]
+	}
+	annotations := map[string]string{"cronjob.kubernetes.io/instantiate": "manual"}
+	for key, value := range cj.Spec.JobTemplate.Annotations {
+		annotations[key] = value
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%s-manual-%d", prefix, now.Unix()%1000000),
+			Namespace:       cj.Namespace,
+			Labels:          copyMap(cj.Spec.JobTemplate.Labels),
+			Annotations:     annotations,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cj, batchv1.SchemeGroupVersion.WithKind("CronJob"))},
+		},
+		Spec: *cj.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+}
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dloss/podji/internal/resources"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateJobFromCronJob(t *testing.T) {
+	cj := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly-backup", Namespace: "shop", UID: "cj-uid"},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "0 2 * * *",
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "backup"}},
+				Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{{Name: "backup", Image: "backup:1"}},
+				}}},
+			},
+		},
+	}
+	client := fake.NewSimpleClientset(cj)
+
+	item, err := createJobFromCronJob(context.Background(), client, "shop", "nightly-backup", time.Unix(1700000123, 0))
+	if err != nil {
+		t.Fatalf("createJobFromCronJob: %v", err)
+	}
+	if item.Name != "nightly-backup-manual-123" || item.Kind != "JOB" {
+		t.Fatalf("unexpected job item %+v", item)
+	}
+	if item.Extra["controlled-by"] != "CronJob/nightly-backup" || item.Extra["controlled-by-uid"] != "cj-uid" {
+		t.Fatalf("expected cronjob owner on job item, got %v", item.Extra)
+	}
+
+	job, err := client.BatchV1().Jobs("shop").Get(context.Background(), item.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get job: %v", err)
+	}
+	if job.Annotations["cronjob.kubernetes.io/instantiate"] != "manual" || job.Labels["app"] != "backup" {
+		t.Fatalf("unexpected job metadata %+v", job.ObjectMeta)
+	}
+	if job.Spec.Template.Spec.Containers[0].Image != "backup:1" {
+		t.Fatalf("expected job template copied, got %+v", job.Spec.Template.Spec)
+	}
+
+	if _, err := createJobFromCronJob(context.Background(), client, "shop", "missing", time.Now()); err == nil {
+		t.Fatal("expected an error for a missing cronjob")
+	}
+}
+
+func TestJobItemDuration(t *testing.T) {
+	start := metav1.NewTime(time.Now().Add(-time.Hour))
+	done := metav1.NewTime(start.Add(90 * time.Second))
+	item := jobItem(&batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "shop"},
+		Status:     batchv1.JobStatus{StartTime: &start, CompletionTime: &done, Succeeded: 1},
+	})
+	if item.Status != "Healthy" || item.Ready != "1/1" || item.Extra["duration"] != "1m30s" {
+		t.Fatalf("unexpected job item %+v", item)
+	}
+}
+
+func TestCronJobJobsReadThroughReadModel(t *testing.T) {
+	store, err := newKubeStore(fakeKubeAPI{
+		contexts: []string{"dev"},
+		listsByKey: map[string][]resources.ResourceItem{
+			"dev/default/workloads": {
+				{Name: "backup-100", Kind: "JOB", Extra: map[string]string{"controlled-by-uid": "cj-1", "created": "100"}},
+				{Name: "backup-200", Kind: "JOB", Extra: map[string]string{"controlled-by-uid": "cj-1", "created": "200"}},
+				{Name: "other-300", Kind: "JOB", Extra: map[string]string{"controlled-by-uid": "cj-2", "created": "300"}},
+				{Name: "backup", Kind: "CJ", UID: "cj-1"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SetScope(Scope{Context: "dev", Namespace: "default"})
+	workloads := store.AdaptResource(store.Registry().ByName("workloads"))
+
+	jobs := resources.NewJobsForCronJob(resources.ResourceItem{Name: "backup", Kind: "CJ", UID: "cj-1", Extra: map[string]string{"schedule": "@hourly", "last-success": "—"}}, workloads, store.Registry())
+	items := jobs.Items()
+	if len(items) != 2 || items[0].Name != "backup-200" || items[1].Name != "backup-100" {
+		t.Fatalf("expected owned jobs from the read model, newest first, got %#v", items)
+	}
+	if banner := jobs.Banner(); banner != "schedule: @hourly    last successful run: —" {
+		t.Fatalf("unexpected banner %q", banner)
+	}
+}
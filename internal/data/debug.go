@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+// debugWaitInterval is how often a new debug container's status is polled.
+var debugWaitInterval = time.Second
+
+// debugImagePullFailures are waiting reasons after which a debug container
+// will not start without user action.
+var debugImagePullFailures = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// AddDebugContainer injects an ephemeral container into a pod, like kubectl
+// debug --target, and waits until it runs. The update uses the usual timeout;
+// the wait is bounded by ctx because image pulls can take a while.
+func (k *clientGoAPI) AddDebugContainer(ctx context.Context, contextName, namespace, pod, target, image string) (string, error) {
+	client, err := k.clientForContext(contextName)
+	if err != nil {
+		return "", err
+	}
+	updateCtx, cancel := context.WithTimeout(ctx, 8*time.Second)
+	name, err := addDebugContainer(updateCtx, client, namespace, pod, target, image)
+	cancel()
+	if err != nil {
+		return "", err
+	}
+	return name, waitForDebugContainer(ctx, client, namespace, pod, name)
+}
+
+func addDebugContainer(ctx context.Context, client kubernetes.Interface, namespace, pod, target, image string) (string, error) {
+	p, err := client.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed reading pod %q: %w", pod, err)
+	}
+	name := debugContainerName(p)
+	p.Spec.EphemeralContainers = append(p.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    image,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: target,
+	})
+	if _, err := client.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, pod, p, metav1.UpdateOptions{}); err != nil {
+		return "", fmt.Errorf("failed adding debug container to pod %q: %w", pod, err)
+	}
+	return name, nil
+}
+
+// debugContainerName picks a name like kubectl debug does, avoiding the
+// names already used in the pod.
+func debugContainerName(p *corev1.Pod) string {
+	used := map[string]bool{}
+	for _, c := range p.Spec.InitContainers {
+		used[c.Name] = true
+	}
+	for _, c := range p.Spec.Containers {
+		used[c.Name] = true
+	}
+	for _, c := range p.Spec.EphemeralContainers {
+		used[c.Name] = true
+	}
+	for {
+		name := "debugger-" + utilrand.String(5)
+		if !used[name] {
+			return name
+		}
+	}
+}
+
+// waitForDebugContainer polls until the ephemeral container runs. It fails
+// early when the container exits or its image cannot be pulled.
+func waitForDebugContainer(ctx context.Context, client kubernetes.Interface, namespace, pod, name string) error {
+	for {
+		p, err := client.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed reading pod %q: %w", pod, err)
+		}
+		for _, cs := range p.Status.EphemeralContainerStatuses {
+			if cs.Name != name {
+				continue
+			}
+			switch {
+			case cs.State.Running != nil:
+				return nil
+			case cs.State.Terminated != nil:
+				return fmt.Errorf("debug container %s exited: %s", name, valueOr(cs.State.Terminated.Reason, "terminated"))
+			case cs.State.Waiting != nil && debugImagePullFailures[cs.State.Waiting.Reason]:
+				return fmt.Errorf("debug container %s: %s: %s", name, cs.State.Waiting.Reason, cs.State.Waiting.Message)
+			}
+		}
+		if err := sleepContext(ctx, debugWaitInterval); err != nil {
+			return fmt.Errorf("debug container %s did not start: %w", name, err)
+		}
+	}
+}
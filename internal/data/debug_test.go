@@ -0,0 +1,71 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAddDebugContainerTargetsContainer(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "shop"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "distroless:1"}}},
+	}
+	client := fake.NewSimpleClientset(pod)
+
+	name, err := addDebugContainer(context.Background(), client, "shop", "api-1", "app", "busybox:1.36")
+	if err != nil {
+		t.Fatalf("addDebugContainer: %v", err)
+	}
+	if !strings.HasPrefix(name, "debugger-") {
+		t.Fatalf("unexpected container name %q", name)
+	}
+	got, err := client.CoreV1().Pods("shop").Get(context.Background(), "api-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get pod: %v", err)
+	}
+	if len(got.Spec.EphemeralContainers) != 1 {
+		t.Fatalf("expected one ephemeral container, got %+v", got.Spec.EphemeralContainers)
+	}
+	ec := got.Spec.EphemeralContainers[0]
+	if ec.Name != name || ec.Image != "busybox:1.36" || ec.TargetContainerName != "app" || !ec.Stdin || !ec.TTY {
+		t.Fatalf("unexpected ephemeral container %+v", ec)
+	}
+}
+
+func TestWaitForDebugContainer(t *testing.T) {
+	interval := debugWaitInterval
+	debugWaitInterval = 0
+	defer func() { debugWaitInterval = interval }()
+
+	status := func(state corev1.ContainerState) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "shop"},
+			Status: corev1.PodStatus{EphemeralContainerStatuses: []corev1.ContainerStatus{
+				{Name: "debugger-abcde", State: state},
+			}},
+		}
+	}
+
+	running := fake.NewSimpleClientset(status(corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}))
+	if err := waitForDebugContainer(context.Background(), running, "shop", "api-1", "debugger-abcde"); err != nil {
+		t.Fatalf("expected running container, got %v", err)
+	}
+
+	pullFailed := fake.NewSimpleClientset(status(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "not found"}}))
+	err := waitForDebugContainer(context.Background(), pullFailed, "shop", "api-1", "debugger-abcde")
+	if err == nil || !strings.Contains(err.Error(), "ImagePullBackOff") {
+		t.Fatalf("expected image pull error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pending := fake.NewSimpleClientset(status(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}))
+	if err := waitForDebugContainer(ctx, pending, "shop", "api-1", "debugger-abcde"); err == nil {
+		t.Fatal("expected an error once the context is done")
+	}
+}
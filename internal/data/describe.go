@@ -0,0 +1,1498 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+// describeRelated holds the objects describe output pulls in besides the
+// described object: its events, and for some kinds the pods, replica sets or
+// endpoints kubectl describe also reports.
+type describeRelated struct {
+	events      []corev1.Event
+	pods        []corev1.Pod
+	replicaSets []appsv1.ReplicaSet
+	endpoints   *corev1.Endpoints
+}
+
+// describeRelatedObjects gathers related objects from the informer cache when
+// it has synced, and from the API otherwise. Failures only leave sections
+// out; the object itself is still described.
+func (k *clientGoAPI) describeRelatedObjects(ctx context.Context, client kubernetes.Interface, contextName string, obj any) describeRelated {
+	var related describeRelated
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return related
+	}
+	inf := k.syncedInformers(contextName)
+	related.events = objectEvents(ctx, client, inf, accessor)
+	ns := accessor.GetNamespace()
+	switch o := obj.(type) {
+	case *corev1.Node:
+		related.pods = listPodsMatching(ctx, client, inf, metav1.NamespaceAll, labels.Everything(),
+			fields.OneTermEqualSelector("spec.nodeName", o.Name).String(),
+			func(p *corev1.Pod) bool { return p.Spec.NodeName == o.Name && !podTerminated(p) })
+	case *corev1.PersistentVolumeClaim:
+		related.pods = listPodsMatching(ctx, client, inf, ns, labels.Everything(), "", func(p *corev1.Pod) bool {
+			for _, v := range p.Spec.Volumes {
+				if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == o.Name {
+					return true
+				}
+			}
+			return false
+		})
+	case *corev1.Service:
+		if ep, err := client.CoreV1().Endpoints(ns).Get(ctx, o.Name, metav1.GetOptions{}); err == nil {
+			related.endpoints = ep
+		}
+	case *appsv1.Deployment:
+		if selector, err := metav1.LabelSelectorAsSelector(o.Spec.Selector); err == nil {
+			if list, err := client.AppsV1().ReplicaSets(ns).List(ctx, metav1.ListOptions{LabelSelector: selector.String()}); err == nil {
+				for _, rs := range list.Items {
+					if metav1.IsControlledBy(&rs, o) {
+						related.replicaSets = append(related.replicaSets, rs)
+					}
+				}
+			}
+		}
+	case *appsv1.StatefulSet:
+		related.pods = podsForSelector(ctx, client, inf, ns, o.Spec.Selector)
+	case *appsv1.DaemonSet:
+		related.pods = podsForSelector(ctx, client, inf, ns, o.Spec.Selector)
+	}
+	return related
+}
+
+// syncedInformers returns the context's informers once their caches have
+// synced, or nil so callers read from the API instead.
+func (k *clientGoAPI) syncedInformers(contextName string) *contextInformers {
+	k.infMu.Lock()
+	defer k.infMu.Unlock()
+	inf := k.inf[contextName]
+	if inf != nil && !inf.synced {
+		return nil
+	}
+	return inf
+}
+
+func objectEvents(ctx context.Context, client kubernetes.Interface, inf *contextInformers, obj metav1.Object) []corev1.Event {
+	matches := func(ev *corev1.Event) bool {
+		if obj.GetUID() != "" && ev.InvolvedObject.UID != "" {
+			return ev.InvolvedObject.UID == obj.GetUID()
+		}
+		return ev.InvolvedObject.Name == obj.GetName()
+	}
+	var out []corev1.Event
+	if inf != nil {
+		events, err := inf.events.Events(obj.GetNamespace()).List(labels.Everything())
+		if err == nil {
+			for _, ev := range events {
+				if matches(ev) {
+					out = append(out, *ev)
+				}
+			}
+			sortEvents(out)
+			return out
+		}
+	}
+	selector := fields.OneTermEqualSelector("involvedObject.name", obj.GetName()).String()
+	list, err := client.CoreV1().Events(obj.GetNamespace()).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return nil
+	}
+	for i := range list.Items {
+		if matches(&list.Items[i]) {
+			out = append(out, list.Items[i])
+		}
+	}
+	sortEvents(out)
+	return out
+}
+
+func sortEvents(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool { return eventTime(events[i]).Before(eventTime(events[j])) })
+}
+
+func podsForSelector(ctx context.Context, client kubernetes.Interface, inf *contextInformers, namespace string, ls *metav1.LabelSelector) []corev1.Pod {
+	selector, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil || selector.Empty() {
+		return nil
+	}
+	return listPodsMatching(ctx, client, inf, namespace, selector, "", func(*corev1.Pod) bool { return true })
+}
+
+func listPodsMatching(ctx context.Context, client kubernetes.Interface, inf *contextInformers, namespace string, selector labels.Selector, fieldSelector string, keep func(*corev1.Pod) bool) []corev1.Pod {
+	var out []corev1.Pod
+	if inf != nil {
+		var (
+			pods []*corev1.Pod
+			err  error
+		)
+		if namespace == metav1.NamespaceAll {
+			pods, err = inf.pods.List(selector)
+		} else {
+			pods, err = inf.pods.Pods(namespace).List(selector)
+		}
+		if err == nil {
+			for _, p := range pods {
+				if keep(p) {
+					out = append(out, *p)
+				}
+			}
+			sort.Slice(out, func(i, j int) bool { return out[i].Namespace+"/"+out[i].Name < out[j].Namespace+"/"+out[j].Name })
+			return out
+		}
+	}
+	list, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String(), FieldSelector: fieldSelector})
+	if err != nil {
+		return nil
+	}
+	for i := range list.Items {
+		if keep(&list.Items[i]) {
+			out = append(out, list.Items[i])
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Namespace+"/"+out[i].Name < out[j].Namespace+"/"+out[j].Name })
+	return out
+}
+
+func podTerminated(p *corev1.Pod) bool {
+	return p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed
+}
+
+// describeWriter lays out describe output the way kubectl does: tab-separated
+// cells aligned by a tabwriter, nested sections indented two spaces per
+// level.
+type describeWriter struct {
+	buf bytes.Buffer
+	tw  *tabwriter.Writer
+}
+
+func newDescribeWriter() *describeWriter {
+	w := &describeWriter{}
+	w.tw = tabwriter.NewWriter(&w.buf, 0, 8, 2, ' ', 0)
+	return w
+}
+
+func (w *describeWriter) write(level int, format string, args ...any) {
+	fmt.Fprintf(w.tw, strings.Repeat("  ", level)+format, args...)
+}
+
+func (w *describeWriter) String() string {
+	w.tw.Flush()
+	return strings.TrimRight(w.buf.String(), "\n")
+}
+
+// describeObject renders obj like kubectl describe, from the typed or
+// unstructured object and the related objects gathered for it.
+func describeObject(obj any, related describeRelated) string {
+	w := newDescribeWriter()
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		describePod(w, o)
+	case *corev1.Service:
+		describeService(w, o, related.endpoints)
+	case *appsv1.Deployment:
+		describeDeployment(w, o, related.replicaSets)
+	case *appsv1.StatefulSet:
+		describeStatefulSet(w, o, related.pods)
+	case *appsv1.DaemonSet:
+		describeDaemonSet(w, o, related.pods)
+	case *batchv1.Job:
+		describeJob(w, o)
+	case *batchv1.CronJob:
+		describeCronJob(w, o)
+	case *corev1.ConfigMap:
+		describeConfigMap(w, o)
+	case *corev1.Secret:
+		describeSecret(w, o)
+	case *corev1.PersistentVolumeClaim:
+		describePVC(w, o, related.pods)
+	case *networkingv1.Ingress:
+		describeIngress(w, o)
+	case *corev1.Node:
+		describeNode(w, o, related.pods)
+	case *corev1.Namespace:
+		describeObjectMeta(w, &o.ObjectMeta, false)
+		w.write(0, "Status:\t%s\n", o.Status.Phase)
+	case *corev1.Event:
+		describeEvent(w, o)
+		return w.String()
+	case *unstructured.Unstructured:
+		describeUnstructured(w, o)
+	default:
+		return ""
+	}
+	describeEvents(w, related.events)
+	return w.String()
+}
+
+func describeObjectMeta(w *describeWriter, m *metav1.ObjectMeta, created bool) {
+	w.write(0, "Name:\t%s\n", m.Name)
+	if m.Namespace != "" {
+		w.write(0, "Namespace:\t%s\n", m.Namespace)
+	}
+	if created {
+		w.write(0, "CreationTimestamp:\t%s\n", describeTime(m.CreationTimestamp))
+	}
+	describeStringMap(w, 0, "Labels", m.Labels)
+	describeAnnotations(w, 0, m.Annotations)
+}
+
+// describeStringMap prints one key=value per line, the first next to the
+// title, in key order.
+func describeStringMap(w *describeWriter, level int, title string, m map[string]string) {
+	if len(m) == 0 {
+		w.write(level, "%s:\t<none>\n", title)
+		return
+	}
+	keys := sortedMapKeys(m)
+	for i, key := range keys {
+		label := title + ":"
+		if i > 0 {
+			label = ""
+		}
+		w.write(level, "%s\t%s=%s\n", label, key, m[key])
+	}
+}
+
+// describeAnnotations skips the last-applied configuration, which is a copy
+// of the object, as kubectl does.
+func describeAnnotations(w *describeWriter, level int, annotations map[string]string) {
+	shown := map[string]string{}
+	for key, value := range annotations {
+		if key == corev1.LastAppliedConfigAnnotation {
+			continue
+		}
+		shown[key] = value
+	}
+	if len(shown) == 0 {
+		w.write(level, "Annotations:\t<none>\n")
+		return
+	}
+	for i, key := range sortedMapKeys(shown) {
+		label := "Annotations:"
+		if i > 0 {
+			label = ""
+		}
+		w.write(level, "%s\t%s: %s\n", label, key, shown[key])
+	}
+}
+
+func describeList(w *describeWriter, level int, title string, values []string) {
+	if len(values) == 0 {
+		w.write(level, "%s:\t<none>\n", title)
+		return
+	}
+	for i, value := range values {
+		label := title + ":"
+		if i > 0 {
+			label = ""
+		}
+		w.write(level, "%s\t%s\n", label, value)
+	}
+}
+
+func describePod(w *describeWriter, p *corev1.Pod) {
+	w.write(0, "Name:\t%s\n", p.Name)
+	w.write(0, "Namespace:\t%s\n", p.Namespace)
+	if p.Spec.Priority != nil {
+		w.write(0, "Priority:\t%d\n", *p.Spec.Priority)
+	}
+	if p.Spec.PriorityClassName != "" {
+		w.write(0, "Priority Class Name:\t%s\n", p.Spec.PriorityClassName)
+	}
+	w.write(0, "Service Account:\t%s\n", valueOr(p.Spec.ServiceAccountName, "default"))
+	if p.Spec.NodeName == "" {
+		w.write(0, "Node:\t<none>\n")
+	} else {
+		w.write(0, "Node:\t%s/%s\n", p.Spec.NodeName, p.Status.HostIP)
+	}
+	if p.Status.StartTime != nil {
+		w.write(0, "Start Time:\t%s\n", describeTime(*p.Status.StartTime))
+	}
+	describeStringMap(w, 0, "Labels", p.Labels)
+	describeAnnotations(w, 0, p.Annotations)
+	if p.DeletionTimestamp != nil {
+		w.write(0, "Status:\tTerminating (lasts %s)\n", durationSince(p.DeletionTimestamp.Time))
+	} else {
+		w.write(0, "Status:\t%s\n", p.Status.Phase)
+	}
+	if p.Status.Reason != "" {
+		w.write(0, "Reason:\t%s\n", p.Status.Reason)
+	}
+	if p.Status.Message != "" {
+		w.write(0, "Message:\t%s\n", p.Status.Message)
+	}
+	w.write(0, "IP:\t%s\n", p.Status.PodIP)
+	ips := make([]string, 0, len(p.Status.PodIPs))
+	for _, ip := range p.Status.PodIPs {
+		ips = append(ips, ip.IP)
+	}
+	describeList(w, 0, "IPs", ips)
+	if ref := metav1.GetControllerOf(p); ref != nil {
+		w.write(0, "Controlled By:\t%s/%s\n", ref.Kind, ref.Name)
+	}
+	if p.Status.NominatedNodeName != "" {
+		w.write(0, "NominatedNodeName:\t%s\n", p.Status.NominatedNodeName)
+	}
+	if len(p.Spec.InitContainers) > 0 {
+		describeContainers(w, "Init Containers", p.Spec.InitContainers, p.Status.InitContainerStatuses, true)
+	}
+	describeContainers(w, "Containers", p.Spec.Containers, p.Status.ContainerStatuses, true)
+	if len(p.Spec.EphemeralContainers) > 0 {
+		ephemeral := make([]corev1.Container, 0, len(p.Spec.EphemeralContainers))
+		for _, ec := range p.Spec.EphemeralContainers {
+			c := corev1.Container(ec.EphemeralContainerCommon)
+			ephemeral = append(ephemeral, c)
+		}
+		describeContainers(w, "Ephemeral Containers", ephemeral, p.Status.EphemeralContainerStatuses, true)
+	}
+	if len(p.Status.Conditions) > 0 {
+		w.write(0, "Conditions:\n")
+		w.write(1, "Type\tStatus\n")
+		for _, c := range p.Status.Conditions {
+			w.write(1, "%s\t%s\n", c.Type, c.Status)
+		}
+	}
+	describeVolumes(w, p.Spec.Volumes)
+	if p.Status.QOSClass != "" {
+		w.write(0, "QoS Class:\t%s\n", p.Status.QOSClass)
+	}
+	describeStringMap(w, 0, "Node-Selectors", p.Spec.NodeSelector)
+	describeList(w, 0, "Tolerations", tolerationStrings(p.Spec.Tolerations))
+	describeAffinity(w, 0, p.Spec.Affinity)
+}
+
+// describePodTemplate prints the template section shared by workloads.
+func describePodTemplate(w *describeWriter, t corev1.PodTemplateSpec) {
+	w.write(0, "Pod Template:\n")
+	describeStringMap(w, 1, "Labels", t.Labels)
+	if len(t.Annotations) > 0 {
+		describeAnnotations(w, 1, t.Annotations)
+	}
+	if t.Spec.ServiceAccountName != "" {
+		w.write(1, "Service Account:\t%s\n", t.Spec.ServiceAccountName)
+	}
+	if len(t.Spec.InitContainers) > 0 {
+		describeContainersAt(w, 1, "Init Containers", t.Spec.InitContainers, nil, false)
+	}
+	describeContainersAt(w, 1, "Containers", t.Spec.Containers, nil, false)
+	describeVolumesAt(w, 1, t.Spec.Volumes)
+	describeStringMap(w, 1, "Node-Selectors", t.Spec.NodeSelector)
+	describeList(w, 1, "Tolerations", tolerationStrings(t.Spec.Tolerations))
+	describeAffinity(w, 1, t.Spec.Affinity)
+}
+
+func describeContainers(w *describeWriter, title string, containers []corev1.Container, statuses []corev1.ContainerStatus, withStatus bool) {
+	describeContainersAt(w, 0, title, containers, statuses, withStatus)
+}
+
+func describeContainersAt(w *describeWriter, level int, title string, containers []corev1.Container, statuses []corev1.ContainerStatus, withStatus bool) {
+	if len(containers) == 0 {
+		w.write(level, "%s:\t<none>\n", title)
+		return
+	}
+	w.write(level, "%s:\n", title)
+	byName := make(map[string]corev1.ContainerStatus, len(statuses))
+	for _, s := range statuses {
+		byName[s.Name] = s
+	}
+	for _, c := range containers {
+		status, hasStatus := byName[c.Name]
+		w.write(level+1, "%s:\n", c.Name)
+		if withStatus && hasStatus {
+			w.write(level+2, "Container ID:\t%s\n", status.ContainerID)
+		}
+		w.write(level+2, "Image:\t%s\n", c.Image)
+		if withStatus && hasStatus {
+			w.write(level+2, "Image ID:\t%s\n", status.ImageID)
+		}
+		ports, hostPorts := containerPorts(c.Ports)
+		w.write(level+2, "Port:\t%s\n", ports)
+		w.write(level+2, "Host Port:\t%s\n", hostPorts)
+		if len(c.Command) > 0 {
+			describeCommand(w, level+2, "Command", c.Command)
+		}
+		if len(c.Args) > 0 {
+			describeCommand(w, level+2, "Args", c.Args)
+		}
+		if withStatus && hasStatus {
+			describeContainerState(w, level+2, "State", status.State)
+			if status.LastTerminationState.Terminated != nil || status.LastTerminationState.Waiting != nil || status.LastTerminationState.Running != nil {
+				describeContainerState(w, level+2, "Last State", status.LastTerminationState)
+			}
+			w.write(level+2, "Ready:\t%s\n", boolTitle(status.Ready))
+			w.write(level+2, "Restart Count:\t%d\n", status.RestartCount)
+		}
+		describeResourceList(w, level+2, "Limits", c.Resources.Limits)
+		describeResourceList(w, level+2, "Requests", c.Resources.Requests)
+		if c.LivenessProbe != nil {
+			w.write(level+2, "Liveness:\t%s\n", probeString(c.LivenessProbe))
+		}
+		if c.ReadinessProbe != nil {
+			w.write(level+2, "Readiness:\t%s\n", probeString(c.ReadinessProbe))
+		}
+		if c.StartupProbe != nil {
+			w.write(level+2, "Startup:\t%s\n", probeString(c.StartupProbe))
+		}
+		describeEnv(w, level+2, c)
+		describeMounts(w, level+2, c.VolumeMounts)
+	}
+}
+
+func containerPorts(ports []corev1.ContainerPort) (string, string) {
+	if len(ports) == 0 {
+		return "<none>", "<none>"
+	}
+	var container, host []string
+	for _, p := range ports {
+		proto := valueOr(string(p.Protocol), "TCP")
+		port := fmt.Sprintf("%d/%s", p.ContainerPort, proto)
+		if p.Name != "" {
+			port += " (" + p.Name + ")"
+		}
+		container = append(container, port)
+		host = append(host, fmt.Sprintf("%d/%s", p.HostPort, proto))
+	}
+	return strings.Join(container, ", "), strings.Join(host, ", ")
+}
+
+func describeCommand(w *describeWriter, level int, title string, args []string) {
+	w.write(level, "%s:\n", title)
+	for _, arg := range args {
+		for _, line := range strings.Split(arg, "\n") {
+			w.write(level+1, "%s\n", line)
+		}
+	}
+}
+
+func describeContainerState(w *describeWriter, level int, title string, state corev1.ContainerState) {
+	switch {
+	case state.Running != nil:
+		w.write(level, "%s:\tRunning\n", title)
+		w.write(level+1, "Started:\t%s\n", describeTime(state.Running.StartedAt))
+	case state.Waiting != nil:
+		w.write(level, "%s:\tWaiting\n", title)
+		if state.Waiting.Reason != "" {
+			w.write(level+1, "Reason:\t%s\n", state.Waiting.Reason)
+		}
+		if state.Waiting.Message != "" {
+			w.write(level+1, "Message:\t%s\n", state.Waiting.Message)
+		}
+	case state.Terminated != nil:
+		w.write(level, "%s:\tTerminated\n", title)
+		if state.Terminated.Reason != "" {
+			w.write(level+1, "Reason:\t%s\n", state.Terminated.Reason)
+		}
+		if state.Terminated.Message != "" {
+			w.write(level+1, "Message:\t%s\n", state.Terminated.Message)
+		}
+		w.write(level+1, "Exit Code:\t%d\n", state.Terminated.ExitCode)
+		if state.Terminated.Signal > 0 {
+			w.write(level+1, "Signal:\t%d\n", state.Terminated.Signal)
+		}
+		w.write(level+1, "Started:\t%s\n", describeTime(state.Terminated.StartedAt))
+		w.write(level+1, "Finished:\t%s\n", describeTime(state.Terminated.FinishedAt))
+	default:
+		w.write(level, "%s:\tWaiting\n", title)
+	}
+}
+
+func describeResourceList(w *describeWriter, level int, title string, list corev1.ResourceList) {
+	if len(list) == 0 {
+		return
+	}
+	w.write(level, "%s:\n", title)
+	names := make([]string, 0, len(list))
+	for name := range list {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		quantity := list[corev1.ResourceName(name)]
+		w.write(level+1, "%s:\t%s\n", name, quantity.String())
+	}
+}
+
+// probeString renders a probe on one line in kubectl's format, e.g.
+// "http-get http://:8080/healthz delay=5s timeout=1s period=10s #success=1 #failure=3".
+func probeString(p *corev1.Probe) string {
+	attrs := fmt.Sprintf("delay=%ds timeout=%ds period=%ds #success=%d #failure=%d",
+		p.InitialDelaySeconds, max(p.TimeoutSeconds, 1), valueOrInt32(p.PeriodSeconds, 10),
+		valueOrInt32(p.SuccessThreshold, 1), valueOrInt32(p.FailureThreshold, 3))
+	switch {
+	case p.Exec != nil:
+		return fmt.Sprintf("exec %v %s", p.Exec.Command, attrs)
+	case p.HTTPGet != nil:
+		scheme := strings.ToLower(valueOr(string(p.HTTPGet.Scheme), "http"))
+		return fmt.Sprintf("http-get %s://%s:%s%s %s", scheme, p.HTTPGet.Host, p.HTTPGet.Port.String(), p.HTTPGet.Path, attrs)
+	case p.TCPSocket != nil:
+		return fmt.Sprintf("tcp-socket %s:%s %s", p.TCPSocket.Host, p.TCPSocket.Port.String(), attrs)
+	case p.GRPC != nil:
+		return fmt.Sprintf("grpc <pod>:%d %s %s", p.GRPC.Port, ptrString(p.GRPC.Service), attrs)
+	}
+	return "unknown " + attrs
+}
+
+func valueOrInt32(v, fallback int32) int32 {
+	if v == 0 {
+		return fallback
+	}
+	return v
+}
+
+func describeEnv(w *describeWriter, level int, c corev1.Container) {
+	for _, from := range c.EnvFrom {
+		switch {
+		case from.ConfigMapRef != nil:
+			w.write(level, "Environment Variables from:\n")
+			w.write(level+1, "%s\tConfigMap with prefix '%s'\tOptional: %t\n", from.ConfigMapRef.Name, from.Prefix, from.ConfigMapRef.Optional != nil && *from.ConfigMapRef.Optional)
+		case from.SecretRef != nil:
+			w.write(level, "Environment Variables from:\n")
+			w.write(level+1, "%s\tSecret with prefix '%s'\tOptional: %t\n", from.SecretRef.Name, from.Prefix, from.SecretRef.Optional != nil && *from.SecretRef.Optional)
+		}
+	}
+	if len(c.Env) == 0 {
+		w.write(level, "Environment:\t<none>\n")
+		return
+	}
+	w.write(level, "Environment:\n")
+	for _, env := range c.Env {
+		switch {
+		case env.ValueFrom == nil:
+			w.write(level+1, "%s:\t%s\n", env.Name, env.Value)
+		case env.ValueFrom.FieldRef != nil:
+			w.write(level+1, "%s:\t (%s:%s)\n", env.Name, env.ValueFrom.FieldRef.APIVersion, env.ValueFrom.FieldRef.FieldPath)
+		case env.ValueFrom.ResourceFieldRef != nil:
+			w.write(level+1, "%s:\t%s (limits/requests of %s)\n", env.Name, env.ValueFrom.ResourceFieldRef.Resource, valueOr(env.ValueFrom.ResourceFieldRef.ContainerName, c.Name))
+		case env.ValueFrom.SecretKeyRef != nil:
+			ref := env.ValueFrom.SecretKeyRef
+			w.write(level+1, "%s:\t<set to the key '%s' in secret '%s'>\tOptional: %t\n", env.Name, ref.Key, ref.Name, ref.Optional != nil && *ref.Optional)
+		case env.ValueFrom.ConfigMapKeyRef != nil:
+			ref := env.ValueFrom.ConfigMapKeyRef
+			w.write(level+1, "%s:\t<set to the key '%s' of config map '%s'>\tOptional: %t\n", env.Name, ref.Key, ref.Name, ref.Optional != nil && *ref.Optional)
+		}
+	}
+}
+
+func describeMounts(w *describeWriter, level int, mounts []corev1.VolumeMount) {
+	if len(mounts) == 0 {
+		w.write(level, "Mounts:\t<none>\n")
+		return
+	}
+	sorted := append([]corev1.VolumeMount(nil), mounts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MountPath < sorted[j].MountPath })
+	w.write(level, "Mounts:\n")
+	for _, m := range sorted {
+		mode := "rw"
+		if m.ReadOnly {
+			mode = "ro"
+		}
+		extra := ""
+		if m.SubPath != "" {
+			extra = ",path=\"" + m.SubPath + "\""
+		}
+		w.write(level+1, "%s from %s (%s%s)\n", m.MountPath, m.Name, mode, extra)
+	}
+}
+
+func describeVolumes(w *describeWriter, volumes []corev1.Volume) {
+	describeVolumesAt(w, 0, volumes)
+}
+
+func describeVolumesAt(w *describeWriter, level int, volumes []corev1.Volume) {
+	if len(volumes) == 0 {
+		w.write(level, "Volumes:\t<none>\n")
+		return
+	}
+	w.write(level, "Volumes:\n")
+	for _, v := range volumes {
+		w.write(level+1, "%s:\n", v.Name)
+		l := level + 2
+		switch src := v.VolumeSource; {
+		case src.ConfigMap != nil:
+			w.write(l, "Type:\tConfigMap (a volume populated by a ConfigMap)\n")
+			w.write(l, "Name:\t%s\n", src.ConfigMap.Name)
+			w.write(l, "Optional:\t%t\n", src.ConfigMap.Optional != nil && *src.ConfigMap.Optional)
+		case src.Secret != nil:
+			w.write(l, "Type:\tSecret (a volume populated by a Secret)\n")
+			w.write(l, "SecretName:\t%s\n", src.Secret.SecretName)
+			w.write(l, "Optional:\t%t\n", src.Secret.Optional != nil && *src.Secret.Optional)
+		case src.EmptyDir != nil:
+			w.write(l, "Type:\tEmptyDir (a temporary directory that shares a pod's lifetime)\n")
+			w.write(l, "Medium:\t%s\n", src.EmptyDir.Medium)
+			if src.EmptyDir.SizeLimit != nil {
+				w.write(l, "SizeLimit:\t%s\n", src.EmptyDir.SizeLimit.String())
+			} else {
+				w.write(l, "SizeLimit:\t<unset>\n")
+			}
+		case src.PersistentVolumeClaim != nil:
+			w.write(l, "Type:\tPersistentVolumeClaim (a reference to a PersistentVolumeClaim in the same namespace)\n")
+			w.write(l, "ClaimName:\t%s\n", src.PersistentVolumeClaim.ClaimName)
+			w.write(l, "ReadOnly:\t%t\n", src.PersistentVolumeClaim.ReadOnly)
+		case src.HostPath != nil:
+			hostPathType := ""
+			if src.HostPath.Type != nil {
+				hostPathType = string(*src.HostPath.Type)
+			}
+			w.write(l, "Type:\tHostPath (bare host directory volume)\n")
+			w.write(l, "Path:\t%s\n", src.HostPath.Path)
+			w.write(l, "HostPathType:\t%s\n", hostPathType)
+		case src.Projected != nil:
+			w.write(l, "Type:\tProjected (a volume that contains injected data from multiple sources)\n")
+			for _, s := range src.Projected.Sources {
+				switch {
+				case s.ServiceAccountToken != nil:
+					w.write(l, "TokenExpirationSeconds:\t%d\n", ptrInt64(s.ServiceAccountToken.ExpirationSeconds, 3600))
+				case s.ConfigMap != nil:
+					w.write(l, "ConfigMapName:\t%s\n", s.ConfigMap.Name)
+					w.write(l, "ConfigMapOptional:\t%v\n", optionalString(s.ConfigMap.Optional))
+				case s.Secret != nil:
+					w.write(l, "SecretName:\t%s\n", s.Secret.Name)
+					w.write(l, "SecretOptional:\t%v\n", optionalString(s.Secret.Optional))
+				case s.DownwardAPI != nil:
+					w.write(l, "DownwardAPI:\ttrue\n")
+				}
+			}
+		case src.DownwardAPI != nil:
+			w.write(l, "Type:\tDownwardAPI (a volume populated by information about the pod)\n")
+			w.write(l, "Items:\n")
+			for _, item := range src.DownwardAPI.Items {
+				if item.FieldRef != nil {
+					w.write(l+1, "%s -> %s\n", item.FieldRef.FieldPath, item.Path)
+				}
+			}
+		case src.CSI != nil:
+			w.write(l, "Type:\tCSI (a Container Storage Interface (CSI) volume source)\n")
+			w.write(l, "Driver:\t%s\n", src.CSI.Driver)
+			w.write(l, "ReadOnly:\t%t\n", src.CSI.ReadOnly != nil && *src.CSI.ReadOnly)
+		case src.NFS != nil:
+			w.write(l, "Type:\tNFS (an NFS mount that lasts the lifetime of a pod)\n")
+			w.write(l, "Server:\t%s\n", src.NFS.Server)
+			w.write(l, "Path:\t%s\n", src.NFS.Path)
+			w.write(l, "ReadOnly:\t%t\n", src.NFS.ReadOnly)
+		case src.Ephemeral != nil:
+			w.write(l, "Type:\tEphemeralVolume (an inline specification for a volume that gets created and deleted with the pod)\n")
+		default:
+			w.write(l, "<unknown>\n")
+		}
+	}
+}
+
+func ptrInt64(v *int64, fallback int64) int64 {
+	if v == nil {
+		return fallback
+	}
+	return *v
+}
+
+func optionalString(v *bool) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return strconv.FormatBool(*v)
+}
+
+// tolerationStrings renders tolerations as kubectl does:
+// "key=value:Effect op=Exists for 300s".
+func tolerationStrings(tolerations []corev1.Toleration) []string {
+	out := make([]string, 0, len(tolerations))
+	for _, t := range tolerations {
+		s := t.Key
+		if t.Value != "" {
+			s += "=" + t.Value
+		}
+		if t.Effect != "" {
+			s += ":" + string(t.Effect)
+		}
+		if t.Operator == corev1.TolerationOpExists && t.Value == "" {
+			if t.Key != "" || t.Effect != "" {
+				s += " op=Exists"
+			} else {
+				s = "op=Exists"
+			}
+		}
+		if t.TolerationSeconds != nil {
+			s += fmt.Sprintf(" for %ds", *t.TolerationSeconds)
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// describeAffinity prints node affinity terms and a count of pod
+// (anti-)affinity terms.
+func describeAffinity(w *describeWriter, level int, affinity *corev1.Affinity) {
+	if affinity == nil || affinity.NodeAffinity == nil {
+		return
+	}
+	na := affinity.NodeAffinity
+	w.write(level, "Node Affinity:\n")
+	if req := na.RequiredDuringSchedulingIgnoredDuringExecution; req != nil {
+		w.write(level+1, "Required Terms:\n")
+		for i, term := range req.NodeSelectorTerms {
+			w.write(level+2, "Term %d:\t%s\n", i, nodeSelectorTermString(term))
+		}
+	}
+	if len(na.PreferredDuringSchedulingIgnoredDuringExecution) > 0 {
+		w.write(level+1, "Preferred Terms:\n")
+		for _, pref := range na.PreferredDuringSchedulingIgnoredDuringExecution {
+			w.write(level+2, "Weight %d:\t%s\n", pref.Weight, nodeSelectorTermString(pref.Preference))
+		}
+	}
+}
+
+func nodeSelectorTermString(term corev1.NodeSelectorTerm) string {
+	var parts []string
+	for _, expr := range append(append([]corev1.NodeSelectorRequirement{}, term.MatchExpressions...), term.MatchFields...) {
+		switch expr.Operator {
+		case corev1.NodeSelectorOpExists, corev1.NodeSelectorOpDoesNotExist:
+			parts = append(parts, fmt.Sprintf("%s %s", expr.Key, expr.Operator))
+		default:
+			parts = append(parts, fmt.Sprintf("%s %s [%s]", expr.Key, expr.Operator, strings.Join(expr.Values, ", ")))
+		}
+	}
+	if len(parts) == 0 {
+		return "<empty>"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func describeService(w *describeWriter, s *corev1.Service, endpoints *corev1.Endpoints) {
+	describeObjectMeta(w, &s.ObjectMeta, false)
+	w.write(0, "Selector:\t%s\n", valueOr(labelSelectorString(s.Spec.Selector), "<none>"))
+	w.write(0, "Type:\t%s\n", valueOr(string(s.Spec.Type), "ClusterIP"))
+	if s.Spec.IPFamilyPolicy != nil {
+		w.write(0, "IP Family Policy:\t%s\n", *s.Spec.IPFamilyPolicy)
+	}
+	if len(s.Spec.IPFamilies) > 0 {
+		families := make([]string, len(s.Spec.IPFamilies))
+		for i, f := range s.Spec.IPFamilies {
+			families[i] = string(f)
+		}
+		w.write(0, "IP Families:\t%s\n", strings.Join(families, ","))
+	}
+	w.write(0, "IP:\t%s\n", valueOr(s.Spec.ClusterIP, "<none>"))
+	if len(s.Spec.ClusterIPs) > 0 {
+		w.write(0, "IPs:\t%s\n", strings.Join(s.Spec.ClusterIPs, ","))
+	}
+	if len(s.Spec.ExternalIPs) > 0 {
+		w.write(0, "External IPs:\t%s\n", strings.Join(s.Spec.ExternalIPs, ","))
+	}
+	if s.Spec.ExternalName != "" {
+		w.write(0, "External Name:\t%s\n", s.Spec.ExternalName)
+	}
+	var ingress []string
+	for _, lb := range s.Status.LoadBalancer.Ingress {
+		ingress = append(ingress, valueOr(lb.IP, lb.Hostname))
+	}
+	if len(ingress) > 0 {
+		w.write(0, "LoadBalancer Ingress:\t%s\n", strings.Join(ingress, ", "))
+	}
+	for _, port := range s.Spec.Ports {
+		name := valueOr(port.Name, "<unset>")
+		proto := valueOr(string(port.Protocol), "TCP")
+		w.write(0, "Port:\t%s\t%d/%s\n", name, port.Port, proto)
+		target := port.TargetPort.String()
+		if target == "0" {
+			target = strconv.Itoa(int(port.Port))
+		}
+		w.write(0, "TargetPort:\t%s/%s\n", target, proto)
+		if port.NodePort != 0 {
+			w.write(0, "NodePort:\t%s\t%d/%s\n", name, port.NodePort, proto)
+		}
+		w.write(0, "Endpoints:\t%s\n", endpointsForPort(endpoints, port.Name))
+	}
+	w.write(0, "Session Affinity:\t%s\n", valueOr(string(s.Spec.SessionAffinity), "None"))
+	if s.Spec.ExternalTrafficPolicy != "" {
+		w.write(0, "External Traffic Policy:\t%s\n", s.Spec.ExternalTrafficPolicy)
+	}
+	if s.Spec.InternalTrafficPolicy != nil {
+		w.write(0, "Internal Traffic Policy:\t%s\n", *s.Spec.InternalTrafficPolicy)
+	}
+}
+
+// endpointsForPort lists ready addresses serving the named port, at most
+// three followed by a count of the rest, as kubectl prints them.
+func endpointsForPort(endpoints *corev1.Endpoints, portName string) string {
+	if endpoints == nil {
+		return "<none>"
+	}
+	var out []string
+	for _, subset := range endpoints.Subsets {
+		for _, port := range subset.Ports {
+			if port.Name != portName {
+				continue
+			}
+			for _, addr := range subset.Addresses {
+				out = append(out, fmt.Sprintf("%s:%d", addr.IP, port.Port))
+			}
+		}
+	}
+	switch {
+	case len(out) == 0:
+		return "<none>"
+	case len(out) > 3:
+		return strings.Join(out[:3], ",") + fmt.Sprintf(" + %d more...", len(out)-3)
+	}
+	return strings.Join(out, ",")
+}
+
+func describeDeployment(w *describeWriter, d *appsv1.Deployment, replicaSets []appsv1.ReplicaSet) {
+	describeObjectMeta(w, &d.ObjectMeta, true)
+	w.write(0, "Selector:\t%s\n", selectorString(d.Spec.Selector))
+	desired := ptrInt32(d.Spec.Replicas, 1)
+	w.write(0, "Replicas:\t%d desired | %d updated | %d total | %d available | %d unavailable\n",
+		desired, d.Status.UpdatedReplicas, d.Status.Replicas, d.Status.AvailableReplicas, d.Status.UnavailableReplicas)
+	w.write(0, "StrategyType:\t%s\n", d.Spec.Strategy.Type)
+	w.write(0, "MinReadySeconds:\t%d\n", d.Spec.MinReadySeconds)
+	if ru := d.Spec.Strategy.RollingUpdate; ru != nil {
+		w.write(0, "RollingUpdateStrategy:\t%s max unavailable, %s max surge\n", intOrString(ru.MaxUnavailable), intOrString(ru.MaxSurge))
+	}
+	describePodTemplate(w, d.Spec.Template)
+	if len(d.Status.Conditions) > 0 {
+		w.write(0, "Conditions:\n")
+		w.write(1, "Type\tStatus\tReason\n")
+		w.write(1, "----\t------\t------\n")
+		for _, c := range d.Status.Conditions {
+			w.write(1, "%s\t%s\t%s\n", c.Type, c.Status, c.Reason)
+		}
+	}
+	if replicaSets == nil {
+		return
+	}
+	revision := d.Annotations["deployment.kubernetes.io/revision"]
+	var current *appsv1.ReplicaSet
+	var old []string
+	for i := range replicaSets {
+		rs := &replicaSets[i]
+		if rs.Annotations["deployment.kubernetes.io/revision"] == revision && current == nil {
+			current = rs
+			continue
+		}
+		if rs.Status.Replicas > 0 {
+			old = append(old, replicaSetString(rs))
+		}
+	}
+	w.write(0, "OldReplicaSets:\t%s\n", valueOr(strings.Join(old, ", "), "<none>"))
+	if current != nil {
+		w.write(0, "NewReplicaSet:\t%s\n", replicaSetString(current))
+	} else {
+		w.write(0, "NewReplicaSet:\t<none>\n")
+	}
+}
+
+func replicaSetString(rs *appsv1.ReplicaSet) string {
+	return fmt.Sprintf("%s (%d/%d replicas created)", rs.Name, rs.Status.Replicas, ptrInt32(rs.Spec.Replicas, 1))
+}
+
+func intOrString(v *intstr.IntOrString) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return v.String()
+}
+
+func selectorString(ls *metav1.LabelSelector) string {
+	selector, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil || selector.Empty() {
+		return "<none>"
+	}
+	return selector.String()
+}
+
+func describeStatefulSet(w *describeWriter, s *appsv1.StatefulSet, pods []corev1.Pod) {
+	describeObjectMeta(w, &s.ObjectMeta, true)
+	w.write(0, "Selector:\t%s\n", selectorString(s.Spec.Selector))
+	w.write(0, "Replicas:\t%d desired | %d total\n", ptrInt32(s.Spec.Replicas, 1), s.Status.Replicas)
+	w.write(0, "Update Strategy:\t%s\n", s.Spec.UpdateStrategy.Type)
+	if ru := s.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		w.write(1, "Partition:\t%d\n", *ru.Partition)
+	}
+	w.write(0, "Service Name:\t%s\n", s.Spec.ServiceName)
+	w.write(0, "Pods Status:\t%s\n", podPhaseCounts(pods))
+	describePodTemplate(w, s.Spec.Template)
+	if len(s.Spec.VolumeClaimTemplates) == 0 {
+		w.write(0, "Volume Claims:\t<none>\n")
+		return
+	}
+	w.write(0, "Volume Claims:\n")
+	for _, pvc := range s.Spec.VolumeClaimTemplates {
+		w.write(1, "Name:\t%s\n", pvc.Name)
+		w.write(1, "StorageClass:\t%s\n", ptrString(pvc.Spec.StorageClassName))
+		describeStringMap(w, 1, "Labels", pvc.Labels)
+		describeAnnotations(w, 1, pvc.Annotations)
+		if storage, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			w.write(1, "Capacity:\t%s\n", storage.String())
+		} else {
+			w.write(1, "Capacity:\t<default>\n")
+		}
+		w.write(1, "Access Modes:\t%s\n", accessModesString(pvc.Spec.AccessModes))
+	}
+}
+
+func podPhaseCounts(pods []corev1.Pod) string {
+	var running, waiting, succeeded, failed int
+	for _, p := range pods {
+		switch p.Status.Phase {
+		case corev1.PodRunning:
+			running++
+		case corev1.PodPending:
+			waiting++
+		case corev1.PodSucceeded:
+			succeeded++
+		case corev1.PodFailed:
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d Running / %d Waiting / %d Succeeded / %d Failed", running, waiting, succeeded, failed)
+}
+
+func describeDaemonSet(w *describeWriter, d *appsv1.DaemonSet, pods []corev1.Pod) {
+	w.write(0, "Name:\t%s\n", d.Name)
+	w.write(0, "Namespace:\t%s\n", d.Namespace)
+	w.write(0, "Selector:\t%s\n", selectorString(d.Spec.Selector))
+	w.write(0, "Node-Selector:\t%s\n", valueOr(labelSelectorString(d.Spec.Template.Spec.NodeSelector), "<none>"))
+	describeStringMap(w, 0, "Labels", d.Labels)
+	describeAnnotations(w, 0, d.Annotations)
+	w.write(0, "Desired Number of Nodes Scheduled:\t%d\n", d.Status.DesiredNumberScheduled)
+	w.write(0, "Current Number of Nodes Scheduled:\t%d\n", d.Status.CurrentNumberScheduled)
+	w.write(0, "Number of Nodes Scheduled with Up-to-date Pods:\t%d\n", d.Status.UpdatedNumberScheduled)
+	w.write(0, "Number of Nodes Scheduled with Available Pods:\t%d\n", d.Status.NumberAvailable)
+	w.write(0, "Number of Nodes Misscheduled:\t%d\n", d.Status.NumberMisscheduled)
+	w.write(0, "Pods Status:\t%s\n", podPhaseCounts(pods))
+	describePodTemplate(w, d.Spec.Template)
+}
+
+func describeJob(w *describeWriter, j *batchv1.Job) {
+	w.write(0, "Name:\t%s\n", j.Name)
+	w.write(0, "Namespace:\t%s\n", j.Namespace)
+	w.write(0, "Selector:\t%s\n", selectorString(j.Spec.Selector))
+	describeStringMap(w, 0, "Labels", j.Labels)
+	describeAnnotations(w, 0, j.Annotations)
+	if ref := metav1.GetControllerOf(j); ref != nil {
+		w.write(0, "Controlled By:\t%s/%s\n", ref.Kind, ref.Name)
+	}
+	w.write(0, "Parallelism:\t%d\n", ptrInt32(j.Spec.Parallelism, 1))
+	if j.Spec.Completions != nil {
+		w.write(0, "Completions:\t%d\n", *j.Spec.Completions)
+	} else {
+		w.write(0, "Completions:\t<unset>\n")
+	}
+	if j.Spec.CompletionMode != nil {
+		w.write(0, "Completion Mode:\t%s\n", *j.Spec.CompletionMode)
+	}
+	if j.Status.StartTime != nil {
+		w.write(0, "Start Time:\t%s\n", describeTime(*j.Status.StartTime))
+	}
+	if j.Status.CompletionTime != nil {
+		w.write(0, "Completed At:\t%s\n", describeTime(*j.Status.CompletionTime))
+		if j.Status.StartTime != nil {
+			w.write(0, "Duration:\t%s\n", shortDuration(j.Status.CompletionTime.Sub(j.Status.StartTime.Time)))
+		}
+	}
+	if j.Spec.ActiveDeadlineSeconds != nil {
+		w.write(0, "Active Deadline Seconds:\t%ds\n", *j.Spec.ActiveDeadlineSeconds)
+	}
+	w.write(0, "Pods Statuses:\t%d Active (%d Ready) / %d Succeeded / %d Failed\n",
+		j.Status.Active, ptrInt32(j.Status.Ready, 0), j.Status.Succeeded, j.Status.Failed)
+	describePodTemplate(w, j.Spec.Template)
+}
+
+func describeCronJob(w *describeWriter, c *batchv1.CronJob) {
+	describeObjectMeta(w, &c.ObjectMeta, false)
+	w.write(0, "Schedule:\t%s\n", c.Spec.Schedule)
+	if c.Spec.TimeZone != nil {
+		w.write(0, "Time Zone:\t%s\n", *c.Spec.TimeZone)
+	}
+	w.write(0, "Concurrency Policy:\t%s\n", valueOr(string(c.Spec.ConcurrencyPolicy), "Allow"))
+	w.write(0, "Suspend:\t%s\n", boolTitle(c.Spec.Suspend != nil && *c.Spec.Suspend))
+	w.write(0, "Successful Job History Limit:\t%d\n", ptrInt32(c.Spec.SuccessfulJobsHistoryLimit, 3))
+	w.write(0, "Failed Job History Limit:\t%d\n", ptrInt32(c.Spec.FailedJobsHistoryLimit, 1))
+	if c.Spec.StartingDeadlineSeconds != nil {
+		w.write(0, "Starting Deadline Seconds:\t%ds\n", *c.Spec.StartingDeadlineSeconds)
+	} else {
+		w.write(0, "Starting Deadline Seconds:\t<unset>\n")
+	}
+	job := c.Spec.JobTemplate.Spec
+	w.write(0, "Selector:\t%s\n", selectorString(job.Selector))
+	w.write(0, "Parallelism:\t%d\n", ptrInt32(job.Parallelism, 1))
+	if job.Completions != nil {
+		w.write(0, "Completions:\t%d\n", *job.Completions)
+	} else {
+		w.write(0, "Completions:\t<unset>\n")
+	}
+	describePodTemplate(w, job.Template)
+	if c.Status.LastScheduleTime != nil {
+		w.write(0, "Last Schedule Time:\t%s\n", describeTime(*c.Status.LastScheduleTime))
+	} else {
+		w.write(0, "Last Schedule Time:\t<unset>\n")
+	}
+	active := make([]string, 0, len(c.Status.Active))
+	for _, ref := range c.Status.Active {
+		active = append(active, ref.Name)
+	}
+	w.write(0, "Active Jobs:\t%s\n", valueOr(strings.Join(active, ", "), "<none>"))
+}
+
+func describeConfigMap(w *describeWriter, c *corev1.ConfigMap) {
+	describeObjectMeta(w, &c.ObjectMeta, false)
+	w.write(0, "\nData\n====\n")
+	for _, key := range sortedMapKeys(c.Data) {
+		w.write(0, "%s:\n----\n%s\n\n", key, strings.TrimRight(c.Data[key], "\n"))
+	}
+	w.write(0, "\nBinaryData\n====\n")
+	keys := make([]string, 0, len(c.BinaryData))
+	for key := range c.BinaryData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		w.write(0, "%s: %d bytes\n", key, len(c.BinaryData[key]))
+	}
+	w.write(0, "\n")
+}
+
+// describeSecret lists key sizes only, never values.
+func describeSecret(w *describeWriter, s *corev1.Secret) {
+	describeObjectMeta(w, &s.ObjectMeta, false)
+	w.write(0, "\nType:\t%s\n", valueOr(string(s.Type), "Opaque"))
+	w.write(0, "\nData\n====\n")
+	keys := make([]string, 0, len(s.Data))
+	for key := range s.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		w.write(0, "%s:\t%d bytes\n", key, len(s.Data[key]))
+	}
+}
+
+func describePVC(w *describeWriter, p *corev1.PersistentVolumeClaim, pods []corev1.Pod) {
+	w.write(0, "Name:\t%s\n", p.Name)
+	w.write(0, "Namespace:\t%s\n", p.Namespace)
+	w.write(0, "StorageClass:\t%s\n", ptrString(p.Spec.StorageClassName))
+	if p.DeletionTimestamp != nil {
+		w.write(0, "Status:\tTerminating (lasts %s)\n", durationSince(p.DeletionTimestamp.Time))
+	} else {
+		w.write(0, "Status:\t%s\n", p.Status.Phase)
+	}
+	w.write(0, "Volume:\t%s\n", p.Spec.VolumeName)
+	describeStringMap(w, 0, "Labels", p.Labels)
+	describeAnnotations(w, 0, p.Annotations)
+	w.write(0, "Finalizers:\t%v\n", p.Finalizers)
+	capacity := ""
+	if storage, ok := p.Status.Capacity[corev1.ResourceStorage]; ok {
+		capacity = storage.String()
+	}
+	w.write(0, "Capacity:\t%s\n", capacity)
+	w.write(0, "Access Modes:\t%s\n", accessModesString(p.Status.AccessModes))
+	if p.Spec.VolumeMode != nil {
+		w.write(0, "VolumeMode:\t%s\n", *p.Spec.VolumeMode)
+	}
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	describeList(w, 0, "Used By", names)
+}
+
+func accessModesString(modes []corev1.PersistentVolumeAccessMode) string {
+	short := map[corev1.PersistentVolumeAccessMode]string{
+		corev1.ReadWriteOnce:    "RWO",
+		corev1.ReadOnlyMany:     "ROX",
+		corev1.ReadWriteMany:    "RWX",
+		corev1.ReadWriteOncePod: "RWOP",
+	}
+	out := make([]string, 0, len(modes))
+	for _, m := range modes {
+		out = append(out, valueOr(short[m], string(m)))
+	}
+	return strings.Join(out, ",")
+}
+
+func describeIngress(w *describeWriter, ing *networkingv1.Ingress) {
+	w.write(0, "Name:\t%s\n", ing.Name)
+	describeStringMap(w, 0, "Labels", ing.Labels)
+	w.write(0, "Namespace:\t%s\n", ing.Namespace)
+	var addresses []string
+	for _, lb := range ing.Status.LoadBalancer.Ingress {
+		addresses = append(addresses, valueOr(lb.IP, lb.Hostname))
+	}
+	w.write(0, "Address:\t%s\n", strings.Join(addresses, ","))
+	w.write(0, "Ingress Class:\t%s\n", valueOr(ptrString(ing.Spec.IngressClassName), "<none>"))
+	if def := ing.Spec.DefaultBackend; def != nil {
+		w.write(0, "Default backend:\t%s\n", ingressBackendString(*def))
+	} else {
+		w.write(0, "Default backend:\t<default>\n")
+	}
+	if len(ing.Spec.TLS) > 0 {
+		w.write(0, "TLS:\n")
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName == "" {
+				w.write(1, "SNI routes %s\n", strings.Join(tls.Hosts, ","))
+			} else {
+				w.write(1, "%s terminates %s\n", tls.SecretName, strings.Join(tls.Hosts, ","))
+			}
+		}
+	}
+	w.write(0, "Rules:\n")
+	w.write(1, "Host\tPath\tBackends\n")
+	w.write(1, "----\t----\t--------\n")
+	for _, rule := range ing.Spec.Rules {
+		host := valueOr(rule.Host, "*")
+		if rule.HTTP == nil {
+			w.write(1, "%s\t\t%s\n", host, "<default>")
+			continue
+		}
+		w.write(1, "%s\t\t\n", host)
+		for _, path := range rule.HTTP.Paths {
+			w.write(2, "\t%s\t%s\n", valueOr(path.Path, "/"), ingressBackendString(path.Backend))
+		}
+	}
+	describeAnnotations(w, 0, ing.Annotations)
+}
+
+func ingressBackendString(b networkingv1.IngressBackend) string {
+	switch {
+	case b.Service != nil:
+		port := b.Service.Port.Name
+		if port == "" {
+			port = strconv.Itoa(int(b.Service.Port.Number))
+		}
+		return b.Service.Name + ":" + port
+	case b.Resource != nil:
+		return "APIGroup: " + ptrString(b.Resource.APIGroup) + ", Kind: " + b.Resource.Kind + ", Name: " + b.Resource.Name
+	}
+	return "<none>"
+}
+
+func describeNode(w *describeWriter, n *corev1.Node, pods []corev1.Pod) {
+	w.write(0, "Name:\t%s\n", n.Name)
+	w.write(0, "Roles:\t%s\n", valueOr(strings.Join(nodeRoles(n.Labels), ","), "<none>"))
+	describeStringMap(w, 0, "Labels", n.Labels)
+	describeAnnotations(w, 0, n.Annotations)
+	w.write(0, "CreationTimestamp:\t%s\n", describeTime(n.CreationTimestamp))
+	taints := make([]string, 0, len(n.Spec.Taints))
+	for _, t := range n.Spec.Taints {
+		taints = append(taints, t.ToString())
+	}
+	describeList(w, 0, "Taints", taints)
+	w.write(0, "Unschedulable:\t%t\n", n.Spec.Unschedulable)
+	if len(n.Status.Conditions) > 0 {
+		w.write(0, "Conditions:\n")
+		w.write(1, "Type\tStatus\tLastHeartbeatTime\tLastTransitionTime\tReason\tMessage\n")
+		w.write(1, "----\t------\t-----------------\t------------------\t------\t-------\n")
+		for _, c := range n.Status.Conditions {
+			w.write(1, "%s\t%s\t%s\t%s\t%s\t%s\n", c.Type, c.Status,
+				describeTime(c.LastHeartbeatTime), describeTime(c.LastTransitionTime), c.Reason, c.Message)
+		}
+	}
+	w.write(0, "Addresses:\n")
+	for _, addr := range n.Status.Addresses {
+		w.write(1, "%s:\t%s\n", addr.Type, addr.Address)
+	}
+	describeResourceList(w, 0, "Capacity", n.Status.Capacity)
+	describeResourceList(w, 0, "Allocatable", n.Status.Allocatable)
+	info := n.Status.NodeInfo
+	w.write(0, "System Info:\n")
+	w.write(1, "Machine ID:\t%s\n", info.MachineID)
+	w.write(1, "System UUID:\t%s\n", info.SystemUUID)
+	w.write(1, "Boot ID:\t%s\n", info.BootID)
+	w.write(1, "Kernel Version:\t%s\n", info.KernelVersion)
+	w.write(1, "OS Image:\t%s\n", info.OSImage)
+	w.write(1, "Operating System:\t%s\n", info.OperatingSystem)
+	w.write(1, "Architecture:\t%s\n", info.Architecture)
+	w.write(1, "Container Runtime Version:\t%s\n", info.ContainerRuntimeVersion)
+	w.write(1, "Kubelet Version:\t%s\n", info.KubeletVersion)
+	if n.Spec.PodCIDR != "" {
+		w.write(0, "PodCIDR:\t%s\n", n.Spec.PodCIDR)
+		w.write(0, "PodCIDRs:\t%s\n", strings.Join(n.Spec.PodCIDRs, ","))
+	}
+	if n.Spec.ProviderID != "" {
+		w.write(0, "ProviderID:\t%s\n", n.Spec.ProviderID)
+	}
+	if pods == nil {
+		return
+	}
+	allocatable := n.Status.Allocatable
+	w.write(0, "Non-terminated Pods:\t(%d in total)\n", len(pods))
+	w.write(1, "Namespace\tName\tCPU Requests\tCPU Limits\tMemory Requests\tMemory Limits\tAge\n")
+	w.write(1, "---------\t----\t------------\t----------\t---------------\t-------------\t---\n")
+	var total podResources
+	for i := range pods {
+		r := podResourceTotals(&pods[i])
+		total.add(r)
+		w.write(1, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", pods[i].Namespace, pods[i].Name,
+			quantityWithPercent(r.cpuRequests, allocatable.Cpu()), quantityWithPercent(r.cpuLimits, allocatable.Cpu()),
+			quantityWithPercent(r.memoryRequests, allocatable.Memory()), quantityWithPercent(r.memoryLimits, allocatable.Memory()),
+			ageString(pods[i].CreationTimestamp.Time))
+	}
+	w.write(0, "Allocated resources:\n")
+	w.write(1, "(Total limits may be over 100 percent, i.e., overcommitted.)\n")
+	w.write(1, "Resource\tRequests\tLimits\n")
+	w.write(1, "--------\t--------\t------\n")
+	w.write(1, "cpu\t%s\t%s\n", quantityWithPercent(total.cpuRequests, allocatable.Cpu()), quantityWithPercent(total.cpuLimits, allocatable.Cpu()))
+	w.write(1, "memory\t%s\t%s\n", quantityWithPercent(total.memoryRequests, allocatable.Memory()), quantityWithPercent(total.memoryLimits, allocatable.Memory()))
+}
+
+func nodeRoles(nodeLabels map[string]string) []string {
+	var roles []string
+	for key, value := range nodeLabels {
+		switch {
+		case strings.HasPrefix(key, "node-role.kubernetes.io/"):
+			if role := strings.TrimPrefix(key, "node-role.kubernetes.io/"); role != "" {
+				roles = append(roles, role)
+			}
+		case key == "kubernetes.io/role" && value != "":
+			roles = append(roles, value)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// podResources sums the cpu and memory requests and limits of a pod.
+type podResources struct {
+	cpuRequests, cpuLimits, memoryRequests, memoryLimits resource.Quantity
+}
+
+func (r *podResources) add(o podResources) {
+	r.cpuRequests.Add(o.cpuRequests)
+	r.cpuLimits.Add(o.cpuLimits)
+	r.memoryRequests.Add(o.memoryRequests)
+	r.memoryLimits.Add(o.memoryLimits)
+}
+
+// podResourceTotals follows the scheduler's rule: app containers are summed,
+// and an init container counts instead when it asks for more than that sum.
+func podResourceTotals(p *corev1.Pod) podResources {
+	var total podResources
+	for _, c := range p.Spec.Containers {
+		total.add(containerResources(c))
+	}
+	for _, c := range p.Spec.InitContainers {
+		r := containerResources(c)
+		maxQuantity(&total.cpuRequests, r.cpuRequests)
+		maxQuantity(&total.cpuLimits, r.cpuLimits)
+		maxQuantity(&total.memoryRequests, r.memoryRequests)
+		maxQuantity(&total.memoryLimits, r.memoryLimits)
+	}
+	for name, q := range p.Spec.Overhead {
+		switch name {
+		case corev1.ResourceCPU:
+			total.cpuRequests.Add(q)
+			total.cpuLimits.Add(q)
+		case corev1.ResourceMemory:
+			total.memoryRequests.Add(q)
+			total.memoryLimits.Add(q)
+		}
+	}
+	return total
+}
+
+func containerResources(c corev1.Container) podResources {
+	var r podResources
+	if q, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
+		r.cpuRequests = q.DeepCopy()
+	}
+	if q, ok := c.Resources.Limits[corev1.ResourceCPU]; ok {
+		r.cpuLimits = q.DeepCopy()
+	}
+	if q, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
+		r.memoryRequests = q.DeepCopy()
+	}
+	if q, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+		r.memoryLimits = q.DeepCopy()
+	}
+	return r
+}
+
+func maxQuantity(dst *resource.Quantity, q resource.Quantity) {
+	if q.Cmp(*dst) > 0 {
+		*dst = q.DeepCopy()
+	}
+}
+
+func quantityWithPercent(q resource.Quantity, allocatable *resource.Quantity) string {
+	return fmt.Sprintf("%s (%d%%)", q.String(), percentOf(q, allocatable))
+}
+
+func describeEvent(w *describeWriter, e *corev1.Event) {
+	describeObjectMeta(w, &e.ObjectMeta, false)
+	w.write(0, "Type:\t%s\n", valueOr(e.Type, "Normal"))
+	w.write(0, "Reason:\t%s\n", valueOr(e.Reason, "<none>"))
+	w.write(0, "Involved Object:\n")
+	w.write(1, "Kind:\t%s\n", e.InvolvedObject.Kind)
+	w.write(1, "Name:\t%s\n", e.InvolvedObject.Name)
+	if e.InvolvedObject.Namespace != "" {
+		w.write(1, "Namespace:\t%s\n", e.InvolvedObject.Namespace)
+	}
+	w.write(0, "Source:\n")
+	w.write(1, "Component:\t%s\n", e.Source.Component)
+	if e.Source.Host != "" {
+		w.write(1, "Host:\t%s\n", e.Source.Host)
+	}
+	w.write(0, "Count:\t%d\n", e.Count)
+	if !e.FirstTimestamp.IsZero() {
+		w.write(0, "First Timestamp:\t%s\n", describeTime(e.FirstTimestamp))
+	}
+	if ts := eventTime(*e); !ts.IsZero() {
+		w.write(0, "Last Timestamp:\t%s\n", ts.Format(time.RFC1123Z))
+	}
+	w.write(0, "Message:\t%s\n", valueOr(strings.TrimSpace(e.Message), "<none>"))
+}
+
+// describeEvents prints the event table that ends most describe output.
+func describeEvents(w *describeWriter, events []corev1.Event) {
+	if len(events) == 0 {
+		w.write(0, "Events:\t<none>\n")
+		return
+	}
+	w.write(0, "Events:\n")
+	w.write(1, "Type\tReason\tAge\tFrom\tMessage\n")
+	w.write(1, "----\t------\t----\t----\t-------\n")
+	for _, e := range events {
+		age := ageString(eventTime(e))
+		if e.Count > 1 && !e.FirstTimestamp.IsZero() {
+			age = fmt.Sprintf("%s (x%d over %s)", age, e.Count, ageString(e.FirstTimestamp.Time))
+		}
+		from := e.Source.Component
+		if from == "" {
+			from = e.ReportingController
+		}
+		w.write(1, "%s\t%s\t%s\t%s\t%s\n", e.Type, e.Reason, age, from, strings.TrimSpace(e.Message))
+	}
+}
+
+// describeUnstructured is the generic describer used for custom resources:
+// metadata first, then every other field with its name spelled out as
+// words, nested by indentation.
+func describeUnstructured(w *describeWriter, u *unstructured.Unstructured) {
+	w.write(0, "Name:\t%s\n", u.GetName())
+	if u.GetNamespace() != "" {
+		w.write(0, "Namespace:\t%s\n", u.GetNamespace())
+	}
+	describeStringMap(w, 0, "Labels", u.GetLabels())
+	describeAnnotations(w, 0, u.GetAnnotations())
+	w.write(0, "API Version:\t%s\n", u.GetAPIVersion())
+	w.write(0, "Kind:\t%s\n", u.GetKind())
+	w.write(0, "Metadata:\n")
+	w.write(1, "Creation Timestamp:\t%s\n", u.GetCreationTimestamp().UTC().Format(time.RFC3339))
+	if u.GetGeneration() != 0 {
+		w.write(1, "Generation:\t%d\n", u.GetGeneration())
+	}
+	w.write(1, "Resource Version:\t%s\n", u.GetResourceVersion())
+	w.write(1, "UID:\t%s\n", u.GetUID())
+	for _, key := range sortedMapKeys(u.Object) {
+		switch key {
+		case "apiVersion", "kind", "metadata":
+			continue
+		}
+		describeField(w, 0, key, u.Object[key])
+	}
+}
+
+func describeField(w *describeWriter, level int, key string, value any) {
+	label := smartLabel(key)
+	switch v := value.(type) {
+	case map[string]any:
+		w.write(level, "%s:\n", label)
+		for _, k := range sortedMapKeys(v) {
+			describeField(w, level+1, k, v[k])
+		}
+	case []any:
+		w.write(level, "%s:\n", label)
+		for _, item := range v {
+			if m, ok := item.(map[string]any); ok {
+				for _, k := range sortedMapKeys(m) {
+					describeField(w, level+1, k, m[k])
+				}
+				continue
+			}
+			w.write(level+1, "%v\n", item)
+		}
+	default:
+		w.write(level, "%s:\t%v\n", label, v)
+	}
+}
+
+// smartLabel turns a camelCase field name into words: "lastTransitionTime"
+// becomes "Last Transition Time".
+func smartLabel(field string) string {
+	var b strings.Builder
+	runes := []rune(field)
+	for i, r := range runes {
+		if i == 0 {
+			b.WriteRune(unicode.ToUpper(r))
+			continue
+		}
+		prev := runes[i-1]
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || (unicode.IsUpper(prev) && nextLower)) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func describeTime(t metav1.Time) string {
+	if t.IsZero() {
+		return "<unset>"
+	}
+	return t.Time.Format(time.RFC1123Z)
+}
+
+func boolTitle(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
+
+func durationSince(t time.Time) string {
+	return shortDuration(time.Since(t))
+}
+
+func shortDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return strconv.Itoa(int(d.Seconds())) + "s"
+	case d < time.Hour:
+		return strconv.Itoa(int(d.Minutes())) + "m" + strconv.Itoa(int(d.Seconds())%60) + "s"
+	case d < 24*time.Hour:
+		return strconv.Itoa(int(d.Hours())) + "h" + strconv.Itoa(int(d.Minutes())%60) + "m"
+	}
+	return strconv.Itoa(int(d.Hours()/24)) + "d" + strconv.Itoa(int(d.Hours())%24) + "h"
+}
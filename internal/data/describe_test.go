@@ -0,0 +1,260 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func describedPod() *corev1.Pod {
+	seconds := int64(300)
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "shop", UID: "pod-uid", Labels: map[string]string{"app": "api", "tier": "web"}},
+		Spec: corev1.PodSpec{
+			NodeName: "worker-1",
+			Containers: []corev1.Container{{
+				Name:  "api",
+				Image: "api:1.2",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+				},
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler:        corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8080)}},
+					InitialDelaySeconds: 5,
+				},
+				Env: []corev1.EnvVar{
+					{Name: "MODE", Value: "prod"},
+					{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "password"}}},
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: "/etc/api", ReadOnly: true}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         "config",
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "api-config"}}},
+			}},
+			Tolerations: []corev1.Toleration{{Key: "node.kubernetes.io/not-ready", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &seconds}},
+			Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a", "b"}}},
+				}}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:    corev1.PodRunning,
+			QOSClass: corev1.PodQOSBurstable,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "api",
+				Ready:        true,
+				RestartCount: 2,
+				State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Reason:   "OOMKilled",
+					ExitCode: 137,
+				}},
+			}},
+		},
+	}
+}
+
+func TestDescribeObjectPodMatchesKubectlSections(t *testing.T) {
+	events := []corev1.Event{{Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container", Source: corev1.EventSource{Component: "kubelet"}}}
+	out := describeObject(describedPod(), describeRelated{events: events})
+
+	for _, want := range []string{
+		"Name:             api-1",
+		"Labels:           app=api\n                  tier=web",
+		"Port:           8080/TCP (http)",
+		"Last State:     Terminated\n      Reason:       OOMKilled\n      Exit Code:    137",
+		"Liveness:       http-get http://:8080/healthz delay=5s timeout=1s period=10s #success=1 #failure=3",
+		"cpu:     250m",
+		"DB_PASSWORD:  <set to the key 'password' in secret 'db'>",
+		"/etc/api from config (ro)",
+		"Type:      ConfigMap (a volume populated by a ConfigMap)",
+		"Tolerations:      node.kubernetes.io/not-ready:NoExecute op=Exists for 300s",
+		"Term 0:  zone In [a, b]",
+		"Warning  BackOff  ",
+	} {
+		if !containsSpaced(out, want) {
+			t.Errorf("expected %q in describe output:\n%s", want, out)
+		}
+	}
+}
+
+func TestDescribeObjectDeploymentReportsReplicaSetsAndTemplate(t *testing.T) {
+	replicas := int32(3)
+	maxUnavailable := intstr.FromString("25%")
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop", Annotations: map[string]string{
+			"deployment.kubernetes.io/revision": "2",
+			corev1.LastAppliedConfigAnnotation:  "{}",
+		}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Strategy: appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxUnavailable: &maxUnavailable},
+			},
+			Template: corev1.PodTemplateSpec{Spec: describedPod().Spec},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2, UnavailableReplicas: 1},
+	}
+	rsReplicas := int32(3)
+	sets := []appsv1.ReplicaSet{
+		{ObjectMeta: metav1.ObjectMeta{Name: "api-new", Annotations: map[string]string{"deployment.kubernetes.io/revision": "2"}}, Spec: appsv1.ReplicaSetSpec{Replicas: &rsReplicas}, Status: appsv1.ReplicaSetStatus{Replicas: 3}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "api-old", Annotations: map[string]string{"deployment.kubernetes.io/revision": "1"}}, Status: appsv1.ReplicaSetStatus{Replicas: 0}},
+	}
+	out := describeObject(dep, describeRelated{replicaSets: sets})
+
+	for _, want := range []string{
+		"Replicas:               3 desired | 3 updated | 3 total | 2 available | 1 unavailable",
+		"RollingUpdateStrategy:  25% max unavailable, <nil> max surge",
+		"Pod Template:",
+		"    api:\n      Image:",
+		"OldReplicaSets:         <none>",
+		"NewReplicaSet:          api-new (3/3 replicas created)",
+		"Events:                 <none>",
+	} {
+		if !containsSpaced(out, want) {
+			t.Errorf("expected %q in describe output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, corev1.LastAppliedConfigAnnotation) {
+		t.Errorf("expected last-applied annotation hidden:\n%s", out)
+	}
+}
+
+func TestDescribeObjectNodeSumsPodResources(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{"node-role.kubernetes.io/worker": ""}},
+		Spec:       corev1.NodeSpec{Taints: []corev1.Taint{{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule}}},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}
+	out := describeObject(node, describeRelated{pods: []corev1.Pod{*describedPod()}})
+
+	for _, want := range []string{
+		"Roles:              worker",
+		"Taints:             dedicated=db:NoSchedule",
+		"Non-terminated Pods:  (1 in total)",
+		"cpu       250m (25%)    0 (0%)",
+		"memory    256Mi (25%)   512Mi (50%)",
+	} {
+		if !containsSpaced(out, want) {
+			t.Errorf("expected %q in describe output:\n%s", want, out)
+		}
+	}
+}
+
+func TestDescribeObjectServiceListsEndpointsPerPort(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: "10.96.0.12",
+			Selector:  map[string]string{"app": "api"},
+			Ports:     []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	endpoints := &corev1.Endpoints{Subsets: []corev1.EndpointSubset{{
+		Addresses: []corev1.EndpointAddress{{IP: "10.0.0.5"}, {IP: "10.0.0.6"}},
+		Ports:     []corev1.EndpointPort{{Name: "http", Port: 8080}},
+	}}}
+	out := describeObject(svc, describeRelated{endpoints: endpoints})
+
+	for _, want := range []string{
+		"Selector:           app=api",
+		"Port:               http  80/TCP",
+		"TargetPort:         8080/TCP",
+		"Endpoints:          10.0.0.5:8080,10.0.0.6:8080",
+	} {
+		if !containsSpaced(out, want) {
+			t.Errorf("expected %q in describe output:\n%s", want, out)
+		}
+	}
+}
+
+func TestDescribeObjectCustomResourceSpellsOutFields(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata":   map[string]any{"name": "api-tls", "namespace": "shop"},
+		"spec":       map[string]any{"secretName": "api-tls", "dnsNames": []any{"api.example.com"}},
+		"status": map[string]any{"conditions": []any{
+			map[string]any{"type": "Ready", "status": "True", "lastTransitionTime": "2026-01-02T03:04:05Z"},
+		}},
+	}}
+	out := describeObject(obj, describeRelated{})
+
+	for _, want := range []string{
+		"API Version:  cert-manager.io/v1",
+		"Kind:         Certificate",
+		"Spec:\n  Dns Names:\n    api.example.com\n  Secret Name:  api-tls",
+		"Last Transition Time:  2026-01-02T03:04:05Z",
+		"Events:       <none>",
+	} {
+		if !containsSpaced(out, want) {
+			t.Errorf("expected %q in describe output:\n%s", want, out)
+		}
+	}
+}
+
+func TestDescribeRelatedObjectsReadsEventsAndEndpointsFromAPI(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop", UID: "svc-uid"}}
+	client := fake.NewSimpleClientset(
+		svc,
+		&corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "api.1", Namespace: "shop"}, Reason: "Synced", InvolvedObject: corev1.ObjectReference{Name: "api", UID: "svc-uid"}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "api.2", Namespace: "shop"}, Reason: "Stale", InvolvedObject: corev1.ObjectReference{Name: "api", UID: "old-uid"}},
+	)
+	api := &clientGoAPI{inf: map[string]*contextInformers{}}
+
+	related := api.describeRelatedObjects(context.Background(), client, "dev", svc)
+	if related.endpoints == nil {
+		t.Fatal("expected service endpoints")
+	}
+	if len(related.events) != 1 || related.events[0].Reason != "Synced" {
+		t.Fatalf("expected only events for this object's uid, got %#v", related.events)
+	}
+}
+
+// containsSpaced reports whether want appears in out when runs of spaces are
+// collapsed, so tests do not depend on tabwriter column widths.
+func containsSpaced(out, want string) bool {
+	collapse := func(s string) string {
+		return strings.Join(strings.FieldsFunc(s, func(r rune) bool { return r == ' ' }), " ")
+	}
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		lines = append(lines, collapse(line))
+	}
+	var wantLines []string
+	for _, line := range strings.Split(want, "\n") {
+		wantLines = append(wantLines, collapse(line))
+	}
+	return strings.Contains(strings.Join(lines, "\n"), strings.Join(wantLines, "\n"))
+}
+
+func TestSmartLabelSplitsCamelCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"lastTransitionTime": "Last Transition Time",
+		"dnsNames":           "Dns Names",
+		"podCIDR":            "Pod CIDR",
+		"spec":               "Spec",
+	} {
+		if got := smartLabel(in); got != want {
+			t.Errorf("smartLabel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dloss/podji/internal/resources"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// mirrorPodAnnotation marks static pods mirrored by the kubelet; they cannot
+// be evicted through the API.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+func (k *clientGoAPI) SetNodeSchedulable(ctx context.Context, contextName, node string, schedulable bool) error {
+	client, err := k.clientForContext(contextName)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 8*time.Second)
+	defer cancel()
+	return setNodeUnschedulable(ctx, client, node, !schedulable)
+}
+
+// DrainNode cordons node and evicts its pods like kubectl drain: DaemonSet
+// and mirror pods are skipped, evictions refused by a PodDisruptionBudget are
+// retried until the budget allows them or ctx is cancelled.
+func (k *clientGoAPI) DrainNode(ctx context.Context, contextName, node string, opts resources.DrainOptions, onEvent func(resources.DrainEvent)) error {
+	client, err := k.clientForContext(contextName)
+	if err != nil {
+		return err
+	}
+	return newDrainer(client, opts, onEvent).drain(ctx, node)
+}
+
+func setNodeUnschedulable(ctx context.Context, client kubernetes.Interface, node string, unschedulable bool) error {
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	if _, err := client.CoreV1().Nodes().Patch(ctx, node, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+		verb := "cordon"
+		if !unschedulable {
+			verb = "uncordon"
+		}
+		return fmt.Errorf("failed to %s node %q: %w", verb, node, err)
+	}
+	return nil
+}
+
+type drainer struct {
+	client        kubernetes.Interface
+	opts          resources.DrainOptions
+	retryInterval time.Duration
+	pollInterval  time.Duration
+
+	mu      sync.Mutex
+	onEvent func(resources.DrainEvent)
+}
+
+func newDrainer(client kubernetes.Interface, opts resources.DrainOptions, onEvent func(resources.DrainEvent)) *drainer {
+	return &drainer{
+		client:        client,
+		opts:          opts,
+		retryInterval: 5 * time.Second,
+		pollInterval:  time.Second,
+		onEvent:       onEvent,
+	}
+}
+
+// emit serializes events from the per-pod eviction goroutines.
+func (d *drainer) emit(pod corev1.Pod, state, reason string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onEvent(resources.DrainEvent{Namespace: pod.Namespace, Pod: pod.Name, State: state, Reason: reason})
+}
+
+func (d *drainer) drain(ctx context.Context, node string) error {
+	if err := setNodeUnschedulable(ctx, d.client, node, true); err != nil {
+		return err
+	}
+	list, err := d.client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed listing pods on node %q: %w", node, err)
+	}
+
+	var evict []corev1.Pod
+	blocked := 0
+	for _, pod := range list.Items {
+		if reason := drainSkipReason(pod); reason != "" {
+			d.emit(pod, resources.DrainSkipped, reason)
+			continue
+		}
+		if reason := d.blockReason(pod); reason != "" {
+			d.emit(pod, resources.DrainBlocked, reason)
+			blocked++
+			continue
+		}
+		d.emit(pod, resources.DrainPending, "")
+		evict = append(evict, pod)
+	}
+	if blocked > 0 {
+		return fmt.Errorf("cannot drain node %q: %d pod(s) blocked", node, blocked)
+	}
+
+	var wg sync.WaitGroup
+	var failed int
+	var failedMu sync.Mutex
+	for _, pod := range evict {
+		wg.Add(1)
+		go func(pod corev1.Pod) {
+			defer wg.Done()
+			if err := d.evict(ctx, pod); err != nil {
+				failedMu.Lock()
+				failed++
+				failedMu.Unlock()
+			}
+		}(pod)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("drain of node %q incomplete: %d pod(s) not evicted", node, failed)
+	}
+	return nil
+}
+
+// evict requests the eviction of pod, retrying while a disruption budget
+// refuses it, and waits until the pod is gone.
+func (d *drainer) evict(ctx context.Context, pod corev1.Pod) error {
+	d.emit(pod, resources.DrainEvicting, "")
+	eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+	for {
+		err := d.client.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction)
+		if err == nil {
+			break
+		}
+		switch {
+		case apierrors.IsNotFound(err):
+			d.emit(pod, resources.DrainEvicted, "")
+			return nil
+		case apierrors.IsTooManyRequests(err):
+			d.emit(pod, resources.DrainRetrying, err.Error())
+			if err := sleepContext(ctx, d.retryInterval); err != nil {
+				d.emit(pod, resources.DrainFailed, err.Error())
+				return err
+			}
+		default:
+			d.emit(pod, resources.DrainFailed, err.Error())
+			return err
+		}
+	}
+
+	d.emit(pod, resources.DrainTerminating, "")
+	for {
+		current, err := d.client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+			d.emit(pod, resources.DrainEvicted, "")
+			return nil
+		}
+		if err := sleepContext(ctx, d.pollInterval); err != nil {
+			d.emit(pod, resources.DrainFailed, err.Error())
+			return err
+		}
+	}
+}
+
+// drainSkipReason explains why a pod is left on the node, or returns "".
+func drainSkipReason(pod corev1.Pod) string {
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return "mirror pod"
+	}
+	if ref := metav1.GetControllerOf(&pod); ref != nil && ref.Kind == "DaemonSet" {
+		return "DaemonSet-managed"
+	}
+	return ""
+}
+
+// blockReason explains why a pod stops the drain, or returns "". Finished
+// pods never block since nothing is lost by evicting them.
+func (d *drainer) blockReason(pod corev1.Pod) string {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return ""
+	}
+	if metav1.GetControllerOf(&pod) == nil {
+		return "not managed by a controller"
+	}
+	if !d.opts.DeleteEmptyDirData {
+		for _, vol := range pod.Spec.Volumes {
+			if vol.EmptyDir != nil {
+				return "uses emptyDir volume " + vol.Name
+			}
+		}
+	}
+	return ""
+}
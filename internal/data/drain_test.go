@@ -0,0 +1,124 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dloss/podji/internal/resources"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func drainPod(name, ownerKind string, volumes ...corev1.Volume) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", UID: types.UID("uid-" + name)},
+		Spec:       corev1.PodSpec{NodeName: "worker-1", Volumes: volumes},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if ownerKind != "" {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: name + "-owner", Controller: &controller}}
+	}
+	return pod
+}
+
+type drainRecorder struct {
+	mu     sync.Mutex
+	states map[string][]string
+	reason map[string]string
+}
+
+func (r *drainRecorder) record(ev resources.DrainEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[ev.Pod] = append(r.states[ev.Pod], ev.State)
+	if ev.Reason != "" {
+		r.reason[ev.Pod] = ev.Reason
+	}
+}
+
+func newDrainTest(pods ...*corev1.Pod) (*fake.Clientset, *drainRecorder) {
+	objects := []runtime.Object{&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}}}
+	for _, pod := range pods {
+		objects = append(objects, pod)
+	}
+	return fake.NewSimpleClientset(objects...), &drainRecorder{states: map[string][]string{}, reason: map[string]string{}}
+}
+
+func TestDrainEvictsPodsAndRetriesBudgetRefusals(t *testing.T) {
+	emptyDir := corev1.Volume{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+	client, rec := newDrainTest(
+		drainPod("api-1", "ReplicaSet"),
+		drainPod("cache-0", "StatefulSet", emptyDir),
+		drainPod("exporter-x", "DaemonSet"),
+	)
+	refused := false
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8stesting.CreateAction).GetObject().(metav1.Object)
+		if eviction.GetName() == "api-1" && !refused {
+			refused = true
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		err := client.Tracker().Delete(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, "shop", eviction.GetName())
+		return true, nil, err
+	})
+
+	d := newDrainer(client, resources.DrainOptions{DeleteEmptyDirData: true}, rec.record)
+	d.retryInterval, d.pollInterval = 0, 0
+	if err := d.drain(context.Background(), "worker-1"); err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+
+	node, _ := client.CoreV1().Nodes().Get(context.Background(), "worker-1", metav1.GetOptions{})
+	if !node.Spec.Unschedulable {
+		t.Fatal("expected node to be cordoned")
+	}
+	want := []string{resources.DrainPending, resources.DrainEvicting, resources.DrainRetrying, resources.DrainTerminating, resources.DrainEvicted}
+	if got := rec.states["api-1"]; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("api-1 states = %v, want %v", got, want)
+	}
+	if !strings.Contains(rec.reason["api-1"], "disruption budget") {
+		t.Fatalf("expected budget retry reason, got %q", rec.reason["api-1"])
+	}
+	if got := rec.states["cache-0"]; got[len(got)-1] != resources.DrainEvicted {
+		t.Fatalf("cache-0 states = %v", got)
+	}
+	if got := rec.states["exporter-x"]; len(got) != 1 || got[0] != resources.DrainSkipped {
+		t.Fatalf("exporter-x states = %v, want skipped", got)
+	}
+}
+
+func TestDrainBlocksOnEmptyDirAndUnmanagedPods(t *testing.T) {
+	emptyDir := corev1.Volume{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+	client, rec := newDrainTest(
+		drainPod("cache-0", "StatefulSet", emptyDir),
+		drainPod("bare", ""),
+	)
+	evicted := false
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		evicted = evicted || action.GetSubresource() == "eviction"
+		return false, nil, nil
+	})
+
+	err := newDrainer(client, resources.DrainOptions{}, rec.record).drain(context.Background(), "worker-1")
+	if err == nil || !strings.Contains(err.Error(), "2 pod(s) blocked") {
+		t.Fatalf("expected blocked error, got %v", err)
+	}
+	if evicted {
+		t.Fatal("no pod should be evicted when the drain is blocked")
+	}
+	if rec.reason["cache-0"] != "uses emptyDir volume scratch" || rec.reason["bare"] != "not managed by a controller" {
+		t.Fatalf("unexpected block reasons: %v", rec.reason)
+	}
+}
@@ -0,0 +1,90 @@
+package data
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HelmReleaseManifest returns the manifest of the deployed revision of a Helm
+// release. Helm 3 stores each revision in a secret labelled owner=helm and
+// name=<release>; the release record is base64 encoded, usually gzipped JSON.
+func (k *clientGoAPI) HelmReleaseManifest(contextName, namespace, release string) (string, error) {
+	client, err := k.clientForContext(contextName)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+	defer cancel()
+	list, err := client.CoreV1().Secrets(apiNamespace(namespace)).List(ctx, metav1.ListOptions{
+		LabelSelector: "owner=helm,name=" + release,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list helm release secrets for %q: %w", release, err)
+	}
+	secret, ok := deployedHelmRelease(list.Items)
+	if !ok {
+		return "", fmt.Errorf("no helm release %q in %q", release, namespace)
+	}
+	return decodeHelmRelease(secret.Data["release"])
+}
+
+// deployedHelmRelease picks the newest revision marked deployed, or the newest
+// revision when none is (for example during a failed upgrade).
+func deployedHelmRelease(secrets []corev1.Secret) (corev1.Secret, bool) {
+	best, bestDeployed := -1, -1
+	for i, s := range secrets {
+		version := helmVersion(s)
+		if best < 0 || version > helmVersion(secrets[best]) {
+			best = i
+		}
+		if s.Labels["status"] == "deployed" && (bestDeployed < 0 || version > helmVersion(secrets[bestDeployed])) {
+			bestDeployed = i
+		}
+	}
+	if bestDeployed >= 0 {
+		return secrets[bestDeployed], true
+	}
+	if best >= 0 {
+		return secrets[best], true
+	}
+	return corev1.Secret{}, false
+}
+
+func helmVersion(s corev1.Secret) int {
+	v, _ := strconv.Atoi(s.Labels["version"])
+	return v
+}
+
+func decodeHelmRelease(data []byte) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return "", fmt.Errorf("failed decoding helm release: %w", err)
+	}
+	if len(raw) > 2 && raw[0] == 0x1f && raw[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return "", fmt.Errorf("failed decompressing helm release: %w", err)
+		}
+		defer zr.Close()
+		if raw, err = io.ReadAll(zr); err != nil {
+			return "", fmt.Errorf("failed decompressing helm release: %w", err)
+		}
+	}
+	var rel struct {
+		Manifest string `json:"manifest"`
+	}
+	if err := json.Unmarshal(raw, &rel); err != nil {
+		return "", fmt.Errorf("failed decoding helm release json: %w", err)
+	}
+	return rel.Manifest, nil
+}
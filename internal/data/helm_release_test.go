@@ -0,0 +1,62 @@
+package data
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func helmSecret(t *testing.T, version, status, manifest string) corev1.Secret {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	raw, err := json.Marshal(map[string]string{"name": "api", "manifest": manifest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := zw.Write(raw); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "sh.helm.release.v1.api.v" + version,
+			Labels: map[string]string{"owner": "helm", "name": "api", "version": version, "status": status},
+		},
+		Data: map[string][]byte{"release": []byte(base64.StdEncoding.EncodeToString(buf.Bytes()))},
+	}
+}
+
+func TestHelmReleasePrefersNewestDeployedRevision(t *testing.T) {
+	secrets := []corev1.Secret{
+		helmSecret(t, "9", "superseded", "kind: A\n"),
+		helmSecret(t, "11", "failed", "kind: C\n"),
+		helmSecret(t, "10", "deployed", "kind: B\n"),
+	}
+	secret, ok := deployedHelmRelease(secrets)
+	if !ok {
+		t.Fatal("expected a release")
+	}
+	manifest, err := decodeHelmRelease(secret.Data["release"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest != "kind: B\n" {
+		t.Fatalf("expected the deployed revision's manifest, got %q", manifest)
+	}
+
+	secret, _ = deployedHelmRelease(secrets[:2])
+	if secret.Labels["version"] != "11" {
+		t.Fatalf("without a deployed revision the newest should win, got v%s", secret.Labels["version"])
+	}
+	if _, ok := deployedHelmRelease(nil); ok {
+		t.Fatal("no secrets should yield no release")
+	}
+}
@@ -5,10 +5,15 @@ import (
 	"errors"
 
 	"github.com/dloss/podji/internal/resources"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 var ErrListNotSupported = errors.New("list not supported")
 var ErrObjectReadNotSupported = errors.New("object read not supported")
+var ErrApplyNotSupported = errors.New("apply not supported")
+var ErrNodeActionNotSupported = errors.New("node action not supported")
+var ErrTriggerNotSupported = errors.New("cronjob trigger not supported")
+var ErrDebugNotSupported = errors.New("debug container not supported")
 
 type KubeAPI interface {
 	Contexts() ([]string, error)
@@ -18,6 +23,12 @@ type KubeAPI interface {
 	PodEvents(context, namespace, pod string) ([]string, error)
 }
 
+// KubeAPISelectorLister is an optional extension for listing only the items
+// whose labels match a selector, filtered where the items are cached.
+type KubeAPISelectorLister interface {
+	ListResourcesMatching(context, namespace, resourceName string, selector labels.Selector) ([]resources.ResourceItem, error)
+}
+
 // KubeAPILogStreamer is an optional extension for incremental pod log
 // streaming used by follow mode.
 type KubeAPILogStreamer interface {
@@ -36,6 +47,50 @@ type KubeAPILogOptionsStreamer interface {
 	PodLogsStreamWithOptions(ctx context.Context, contextName, namespace, pod string, opts LogOptions, onLine func(string)) error
 }
 
+// KubeHelmReleaseReader is an optional extension for reading the manifest of
+// the deployed revision of a Helm release from its release secret.
+type KubeHelmReleaseReader interface {
+	HelmReleaseManifest(context, namespace, release string) (string, error)
+}
+
+// KubeObjectApplier is an optional extension for writing an edited object
+// back with server-side apply. It returns the object as the server stored it,
+// or would store it for a dry run.
+type KubeObjectApplier interface {
+	ApplyObject(ctx context.Context, contextName string, manifest []byte, opts resources.ApplyOptions) (string, error)
+}
+
+// KubeNodeOperator is an optional extension for cordoning, uncordoning and
+// draining nodes.
+type KubeNodeOperator interface {
+	SetNodeSchedulable(ctx context.Context, contextName, node string, schedulable bool) error
+	DrainNode(ctx context.Context, contextName, node string, opts resources.DrainOptions, onEvent func(resources.DrainEvent)) error
+}
+
+// KubeCronJobTrigger is an optional extension for creating a Job from a
+// CronJob's jobTemplate.
+type KubeCronJobTrigger interface {
+	CreateJobFromCronJob(ctx context.Context, contextName, namespace, name string) (resources.ResourceItem, error)
+}
+
+// KubeDebugContainerCreator is an optional extension for adding ephemeral
+// debug containers to a pod.
+type KubeDebugContainerCreator interface {
+	AddDebugContainer(ctx context.Context, contextName, namespace, pod, target, image string) (string, error)
+}
+
+// KubeConfigMapReader is an optional extension for reading the keys of a
+// config map.
+type KubeConfigMapReader interface {
+	ConfigMapData(context, namespace, name string) (resources.ConfigMapData, error)
+}
+
+// KubeSecretReader is an optional extension for reading the decoded values of
+// a secret.
+type KubeSecretReader interface {
+	SecretData(context, namespace, name string) (resources.SecretData, error)
+}
+
 // KubeObjectReader is an optional extension for typed object fetches used by
 // live YAML/describe rendering paths.
 type KubeObjectReader interface {
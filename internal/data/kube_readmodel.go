@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/dloss/podji/internal/resources"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // KubeReadModel routes pod logs/events through KubeAPI while falling back to
@@ -90,6 +91,31 @@ func (k *KubeReadModel) List(resourceName string, scope Scope) ([]resources.Reso
 	return k.fallback.List(resourceName, scope)
 }
 
+// ListMatching lists the items matching selector, filtered in the informer
+// cache when the API supports it and from the full list otherwise.
+func (k *KubeReadModel) ListMatching(resourceName string, scope Scope, selector labels.Selector) ([]resources.ResourceItem, error) {
+	if lister, ok := k.api.(KubeAPISelectorLister); ok {
+		active := scope
+		if k.scope != nil {
+			active = k.scope()
+		}
+		items, err := lister.ListResourcesMatching(active.Context, active.Namespace, resourceName, selector)
+		if err == nil {
+			k.markReady(resourceName, StoreDataSourceCache)
+			return items, nil
+		}
+		if !errors.Is(err, ErrListNotSupported) {
+			k.report(err)
+			return nil, err
+		}
+	}
+	items, err := k.List(resourceName, scope)
+	if err != nil {
+		return nil, err
+	}
+	return filterLabels(items, selector), nil
+}
+
 func (k *KubeReadModel) Detail(resourceName string, item resources.ResourceItem, scope Scope) (resources.DetailData, error) {
 	if reader, ok := k.api.(KubeObjectReader); ok {
 		ns, contextName := k.resolveScope(scope, item)
@@ -136,6 +162,78 @@ func (k *KubeReadModel) YAML(resourceName string, item resources.ResourceItem, s
 	return k.fallback.YAML(resourceName, item, scope)
 }
 
+func (k *KubeReadModel) HelmManifest(release string, scope Scope) (string, error) {
+	reader, ok := k.api.(KubeHelmReleaseReader)
+	if !ok {
+		return "", ErrObjectReadNotSupported
+	}
+	ns, contextName := k.resolveScope(scope, resources.ResourceItem{Namespace: scope.Namespace})
+	return reader.HelmReleaseManifest(contextName, ns, release)
+}
+
+func (k *KubeReadModel) ApplyObject(ctx context.Context, manifest string, scope Scope, opts resources.ApplyOptions) (string, error) {
+	applier, ok := k.api.(KubeObjectApplier)
+	if !ok {
+		return "", ErrApplyNotSupported
+	}
+	_, contextName := k.resolveScope(scope, resources.ResourceItem{Namespace: scope.Namespace})
+	return applier.ApplyObject(ctx, contextName, []byte(manifest), opts)
+}
+
+func (k *KubeReadModel) SetNodeSchedulable(ctx context.Context, item resources.ResourceItem, scope Scope, schedulable bool) error {
+	operator, ok := k.api.(KubeNodeOperator)
+	if !ok {
+		return ErrNodeActionNotSupported
+	}
+	_, contextName := k.resolveScope(scope, item)
+	return operator.SetNodeSchedulable(ctx, contextName, item.Name, schedulable)
+}
+
+func (k *KubeReadModel) DrainNode(ctx context.Context, item resources.ResourceItem, scope Scope, opts resources.DrainOptions, onEvent func(resources.DrainEvent)) error {
+	operator, ok := k.api.(KubeNodeOperator)
+	if !ok {
+		return ErrNodeActionNotSupported
+	}
+	_, contextName := k.resolveScope(scope, item)
+	return operator.DrainNode(ctx, contextName, item.Name, opts, onEvent)
+}
+
+func (k *KubeReadModel) TriggerCronJob(ctx context.Context, item resources.ResourceItem, scope Scope) (resources.ResourceItem, error) {
+	trigger, ok := k.api.(KubeCronJobTrigger)
+	if !ok {
+		return resources.ResourceItem{}, ErrTriggerNotSupported
+	}
+	ns, contextName := k.resolveScope(scope, item)
+	return trigger.CreateJobFromCronJob(ctx, contextName, ns, item.Name)
+}
+
+func (k *KubeReadModel) AddDebugContainer(ctx context.Context, pod resources.ResourceItem, scope Scope, target, image string) (string, error) {
+	creator, ok := k.api.(KubeDebugContainerCreator)
+	if !ok {
+		return "", ErrDebugNotSupported
+	}
+	ns, contextName := k.resolveScope(scope, pod)
+	return creator.AddDebugContainer(ctx, contextName, ns, pod.Name, target, image)
+}
+
+func (k *KubeReadModel) ConfigMapData(item resources.ResourceItem, scope Scope) (resources.ConfigMapData, error) {
+	reader, ok := k.api.(KubeConfigMapReader)
+	if !ok {
+		return resources.ConfigMapData{}, ErrObjectReadNotSupported
+	}
+	ns, contextName := k.resolveScope(scope, item)
+	return reader.ConfigMapData(contextName, ns, item.Name)
+}
+
+func (k *KubeReadModel) SecretData(item resources.ResourceItem, scope Scope) (resources.SecretData, error) {
+	reader, ok := k.api.(KubeSecretReader)
+	if !ok {
+		return resources.SecretData{}, ErrObjectReadNotSupported
+	}
+	ns, contextName := k.resolveScope(scope, item)
+	return reader.SecretData(contextName, ns, item.Name)
+}
+
 func (k *KubeReadModel) Describe(resourceName string, item resources.ResourceItem, scope Scope) (string, error) {
 	if reader, ok := k.api.(KubeObjectReader); ok {
 		ns, contextName := k.resolveScope(scope, item)
@@ -223,7 +321,7 @@ func (k *KubeReadModel) StreamLogsWithContext(ctx context.Context, resourceName
 		}
 		if streamer, ok := k.api.(KubeAPILogOptionsStreamer); ok && opts.Follow {
 			ns, contextName := k.resolveScope(scope, item)
-			err := streamer.PodLogsStreamWithOptions(ctx, contextName, ns, item.Name, opts, onLine)
+			err := k.followPodLogs(ctx, streamer, contextName, ns, item, opts, onLine)
 			if err != nil {
 				k.report(err)
 				return err
@@ -362,6 +460,8 @@ func podLiveDetail(item resources.ResourceItem) resources.DetailData {
 			Restarts: valueOr(item.Restarts, "0"),
 		})
 	}
+	rows = append(namedContainerRows(item.Extra["init-containers"], resources.ContainerTypeInit), rows...)
+	rows = append(rows, namedContainerRows(item.Extra["ephemeral-containers"], resources.ContainerTypeEphemeral)...)
 	return resources.DetailData{
 		Summary: []resources.SummaryField{
 			{Key: "status", Label: "Status", Value: status},
@@ -375,6 +475,26 @@ func podLiveDetail(item resources.ResourceItem) resources.DetailData {
 	}
 }
 
+// namedContainerRows builds placeholder rows for containers only known by
+// name from list metadata; state is filled in once the pod object is read.
+func namedContainerRows(raw, containerType string) []resources.ContainerRow {
+	var rows []resources.ContainerRow
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		rows = append(rows, resources.ContainerRow{
+			Name:     name,
+			Image:    "<unknown>",
+			State:    "Unknown",
+			Restarts: "0",
+			Type:     containerType,
+		})
+	}
+	return rows
+}
+
 func serviceLiveDetail(item resources.ResourceItem) resources.DetailData {
 	return resources.DetailData{
 		Summary: []resources.SummaryField{
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/dloss/podji/internal/resources"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 type fallbackDetailReadModel struct {
@@ -584,3 +585,49 @@ func containsAll(text string, parts ...string) bool {
 	}
 	return true
 }
+
+type fakeKubeAPISelectorLister struct {
+	fakeKubeAPI
+	selector string
+}
+
+func (f *fakeKubeAPISelectorLister) ListResourcesMatching(contextName, namespace, resourceName string, selector labels.Selector) ([]resources.ResourceItem, error) {
+	f.selector = selector.String()
+	return []resources.ResourceItem{{Name: "from-cache"}}, nil
+}
+
+func TestKubeReadModelListMatchingUsesSelectorLister(t *testing.T) {
+	api := &fakeKubeAPISelectorLister{}
+	read := NewKubeReadModel(NewMockReadModel(resources.DefaultRegistry()), api,
+		func() Scope { return Scope{Context: "dev", Namespace: "default"} }, nil, nil, nil, nil)
+
+	selector, _ := labels.Parse("app in (api),!canary")
+	got, err := ListMatching(read, "pods", Scope{}, selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Name != "from-cache" || api.selector != selector.String() {
+		t.Fatalf("expected items from the selector lister, got %v (selector %q)", got, api.selector)
+	}
+}
+
+func TestKubeReadModelListMatchingFiltersFullList(t *testing.T) {
+	api := fakeKubeAPI{listsByKey: map[string][]resources.ResourceItem{
+		"dev/default/pods": {
+			{Name: "api-1", Labels: map[string]string{"app": "api"}},
+			{Name: "api-canary", Labels: map[string]string{"app": "api", "canary": "true"}},
+			{Name: "web-1", Labels: map[string]string{"app": "web"}},
+		},
+	}}
+	read := NewKubeReadModel(NewMockReadModel(resources.DefaultRegistry()), api,
+		func() Scope { return Scope{Context: "dev", Namespace: "default"} }, nil, nil, nil, nil)
+
+	selector, _ := labels.Parse("app=api,!canary")
+	got, err := ListMatching(read, "pods", Scope{}, selector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Name != "api-1" {
+		t.Fatalf("expected only api-1, got %v", got)
+	}
+}
@@ -226,6 +226,11 @@ func (s *KubeStore) configurePodFetchers() {
 		return
 	}
 	pods.SetLiveFetchers(s.podLogsWithOptions, s.podEvents)
+	pods.SetLiveLogStreamer(s.podLogStream)
+}
+
+func (s *KubeStore) podLogStream(ctx context.Context, item resources.ResourceItem, opts resources.LogOptions, onLine func(string)) error {
+	return StreamLogs(ctx, s.read, "pods", item, s.scope, logOptionsFromResource(opts), onLine)
 }
 
 func (s *KubeStore) podLogs(namespace, pod string) ([]string, error) {
@@ -240,13 +245,7 @@ func (s *KubeStore) podLogs(namespace, pod string) ([]string, error) {
 
 func (s *KubeStore) podLogsWithOptions(namespace, pod string, opts resources.LogOptions) ([]string, error) {
 	if reader, ok := s.api.(KubeAPILogOptionsReader); ok {
-		lines, err := reader.PodLogsWithOptions(context.Background(), s.scope.Context, namespace, pod, LogOptions{
-			Tail:       opts.Tail,
-			Follow:     opts.Follow,
-			Previous:   opts.Previous,
-			Container:  opts.Container,
-			Timestamps: opts.Timestamps,
-		})
+		lines, err := reader.PodLogsWithOptions(context.Background(), s.scope.Context, namespace, pod, logOptionsFromResource(opts))
 		if err != nil {
 			s.setStatusForError(err)
 			return nil, err
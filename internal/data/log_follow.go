@@ -0,0 +1,277 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dloss/podji/internal/resources"
+)
+
+// Reconnect backoff for follow streams. Variables so tests can shorten them.
+var (
+	followBackoffMin = 500 * time.Millisecond
+	followBackoffMax = 10 * time.Second
+)
+
+// podLogFollower keeps a follow stream alive across the events that end a
+// single API stream: container restarts, the pod being replaced, and dropped
+// connections. Each reconnect resumes from the last seen timestamp, drops the
+// lines it already delivered, and marks the gap with a resources.LogBoundary
+// line naming the cause.
+type podLogFollower struct {
+	api       KubeAPI
+	streamer  KubeAPILogOptionsStreamer
+	context   string
+	namespace string
+	pod       resources.ResourceItem
+	opts      LogOptions
+	onLine    func(string)
+
+	// lastTS and atLastTS track the newest timestamp delivered and the lines
+	// carrying it. On reconnect they become resumeTS/resumeSeen, which filter
+	// the overlap the API resends (sinceTime has second precision).
+	lastTS     time.Time
+	atLastTS   map[string]int
+	resumeTS   time.Time
+	resumeSeen map[string]int
+	delivered  int
+}
+
+func (k *KubeReadModel) followPodLogs(ctx context.Context, streamer KubeAPILogOptionsStreamer, contextName, namespace string, item resources.ResourceItem, opts LogOptions, onLine func(string)) error {
+	item.Namespace = namespace
+	f := &podLogFollower{
+		api:       k.api,
+		streamer:  streamer,
+		context:   contextName,
+		namespace: namespace,
+		pod:       item,
+		opts:      opts,
+		onLine:    onLine,
+	}
+	return f.run(ctx)
+}
+
+func (f *podLogFollower) run(ctx context.Context) error {
+	backoff := followBackoffMin
+	reqOpts := f.requestOptions()
+	lastCause := ""
+	for attempt := 0; ; attempt++ {
+		f.delivered = 0
+		err := f.streamer.PodLogsStreamWithOptions(ctx, f.context, f.namespace, f.pod.Name, reqOpts, f.emit)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if attempt == 0 && err != nil && f.delivered == 0 {
+			// Never connected: surface the error (forbidden, not found, ...)
+			// instead of retrying something that is unlikely to recover.
+			return err
+		}
+		if f.delivered > 0 {
+			backoff = followBackoffMin
+			lastCause = ""
+		}
+
+		if err := sleepContext(ctx, backoff); err != nil {
+			return err
+		}
+		backoff *= 2
+		if backoff > followBackoffMax {
+			backoff = followBackoffMax
+		}
+
+		cause, stop := f.diagnose(err)
+		if cause != lastCause && f.onLine != nil {
+			f.onLine(resources.LogBoundary(cause))
+		}
+		lastCause = cause
+		if stop {
+			return nil
+		}
+		reqOpts = f.requestOptions()
+	}
+}
+
+// diagnose inspects the pod after a stream ended to name the cause and pick
+// the pod to follow next. stop is true when there is nothing left to follow.
+func (f *podLogFollower) diagnose(streamErr error) (cause string, stop bool) {
+	pods, err := f.api.ListResources(f.context, f.namespace, "pods")
+	if err != nil {
+		return "connection lost, reconnecting", false
+	}
+	for _, pod := range pods {
+		if pod.Name != f.pod.Name {
+			continue
+		}
+		if f.pod.UID != "" && pod.UID != "" && pod.UID != f.pod.UID {
+			// Same name, new pod: a StatefulSet replica came back elsewhere.
+			f.switchTo(pod)
+			return "pod rescheduled as " + podPlacement(pod), false
+		}
+		prevRestarts := parseRestartCount(f.pod.Restarts)
+		f.pod = pod
+		if parseRestartCount(pod.Restarts) > prevRestarts {
+			return "container restarted (restart " + strings.Fields(pod.Restarts)[0] + ")", false
+		}
+		if streamErr != nil {
+			return "connection lost, reconnecting", false
+		}
+		if isTerminalPodStatus(pod.Status) {
+			return "container exited (" + pod.Status + ")", true
+		}
+		return "container stopped, waiting for restart", false
+	}
+
+	if !f.opts.FollowOwner {
+		return "pod " + f.pod.Name + " deleted", true
+	}
+	if next, ok := replacementPod(f.pod, pods); ok {
+		prev := f.pod.Name
+		f.switchTo(next)
+		return "pod " + prev + " replaced by " + next.Name, false
+	}
+	return "pod " + f.pod.Name + " deleted, waiting for replacement", false
+}
+
+func (f *podLogFollower) switchTo(pod resources.ResourceItem) {
+	f.pod = pod
+	f.lastTS = time.Time{}
+	f.atLastTS = nil
+	f.resumeTS = time.Time{}
+	f.resumeSeen = nil
+}
+
+// requestOptions builds the next stream request. Timestamps are always
+// requested so the follower can resume and dedupe; they are stripped again
+// in emit when the caller did not ask for them.
+func (f *podLogFollower) requestOptions() LogOptions {
+	opts := f.opts
+	opts.Timestamps = true
+	if f.lastTS.IsZero() {
+		return opts
+	}
+	opts.Previous = false
+	opts.Tail = 0
+	opts.SinceTime = f.lastTS
+	f.resumeTS = f.lastTS
+	f.resumeSeen = f.atLastTS
+	f.atLastTS = nil
+	return opts
+}
+
+func (f *podLogFollower) emit(line string) {
+	ts, rest, ok := splitLogTimestamp(line)
+	if ok {
+		if !f.resumeTS.IsZero() {
+			if ts.Before(f.resumeTS) {
+				return
+			}
+			if ts.Equal(f.resumeTS) {
+				if f.resumeSeen[line] > 0 {
+					f.resumeSeen[line]--
+					return
+				}
+			}
+		}
+		if ts.After(f.lastTS) {
+			f.lastTS = ts
+			f.atLastTS = make(map[string]int)
+		}
+		if ts.Equal(f.lastTS) {
+			f.atLastTS[line]++
+		}
+		if !f.opts.Timestamps {
+			line = rest
+		}
+	}
+	f.delivered++
+	if f.onLine != nil {
+		f.onLine(line)
+	}
+}
+
+func splitLogTimestamp(line string) (time.Time, string, bool) {
+	prefix, rest, found := strings.Cut(line, " ")
+	if !found {
+		return time.Time{}, line, false
+	}
+	ts, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return ts, strings.TrimLeft(rest, " "), true
+}
+
+// replacementPod picks the pod that took over from gone: same owner kind and
+// the same labels once per-revision hash labels are ignored. Running pods win.
+func replacementPod(gone resources.ResourceItem, pods []resources.ResourceItem) (resources.ResourceItem, bool) {
+	ownerKind, _, _ := strings.Cut(gone.Extra["controlled-by"], "/")
+	stable := stablePodLabels(gone.Labels)
+	if ownerKind == "" || len(stable) == 0 {
+		return resources.ResourceItem{}, false
+	}
+	var best resources.ResourceItem
+	found := false
+	for _, pod := range pods {
+		if pod.Name == gone.Name {
+			continue
+		}
+		kind, _, _ := strings.Cut(pod.Extra["controlled-by"], "/")
+		if kind != ownerKind || !resources.MatchesSelector(stable, pod.Labels) {
+			continue
+		}
+		if !found || (pod.Status == "Running" && best.Status != "Running") {
+			best = pod
+			found = true
+		}
+	}
+	return best, found
+}
+
+var podRevisionLabels = map[string]bool{
+	"pod-template-hash":                        true,
+	"controller-revision-hash":                 true,
+	"pod-template-generation":                  true,
+	"statefulset.kubernetes.io/pod-name":       true,
+	"apps.kubernetes.io/pod-index":             true,
+	"batch.kubernetes.io/controller-uid":       true,
+	"batch.kubernetes.io/job-completion-index": true,
+	"controller-uid":                           true,
+}
+
+func stablePodLabels(labels map[string]string) map[string]string {
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if podRevisionLabels[k] {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func podPlacement(pod resources.ResourceItem) string {
+	if node := pod.Extra["node"]; node != "" {
+		return pod.Name + " on " + node
+	}
+	return pod.Name
+}
+
+func isTerminalPodStatus(status string) bool {
+	switch status {
+	case "Succeeded", "Failed", "Completed":
+		return true
+	}
+	return false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,161 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dloss/podji/internal/resources"
+)
+
+type scriptedLogStreamer struct {
+	fakeKubeAPI
+	attempts []func(pod string, opts LogOptions, onLine func(string)) error
+	pods     []string
+	opts     []LogOptions
+}
+
+func (s *scriptedLogStreamer) PodLogsStreamWithOptions(ctx context.Context, contextName, namespace, pod string, opts LogOptions, onLine func(string)) error {
+	s.pods = append(s.pods, pod)
+	s.opts = append(s.opts, opts)
+	idx := len(s.pods) - 1
+	if idx >= len(s.attempts) {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return s.attempts[idx](pod, opts, onLine)
+}
+
+func shortFollowBackoff(t *testing.T) {
+	t.Helper()
+	prevMin, prevMax := followBackoffMin, followBackoffMax
+	followBackoffMin, followBackoffMax = time.Millisecond, 2*time.Millisecond
+	t.Cleanup(func() { followBackoffMin, followBackoffMax = prevMin, prevMax })
+}
+
+func collectFollow(t *testing.T, api *scriptedLogStreamer, item resources.ResourceItem, opts LogOptions) ([]string, error) {
+	t.Helper()
+	read := NewKubeReadModel(nil, api, func() Scope { return Scope{Context: "dev", Namespace: "default"} }, nil, nil, nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	var got []string
+	opts.Follow = true
+	err := read.StreamLogsWithContext(ctx, "pods", item, Scope{}, opts, func(line string) {
+		got = append(got, line)
+	})
+	return got, err
+}
+
+func TestFollowResumesAfterConnectionLossWithoutDuplicates(t *testing.T) {
+	shortFollowBackoff(t)
+	api := &scriptedLogStreamer{
+		fakeKubeAPI: fakeKubeAPI{listsByKey: map[string][]resources.ResourceItem{
+			"dev/default/pods": {{Name: "api-1", UID: "u1", Restarts: "0", Status: "Running"}},
+		}},
+	}
+	api.attempts = []func(string, LogOptions, func(string)) error{
+		func(_ string, _ LogOptions, onLine func(string)) error {
+			onLine("2026-01-01T10:00:00.100Z a")
+			onLine("2026-01-01T10:00:00.200Z b")
+			return errors.New("unexpected EOF")
+		},
+		func(_ string, _ LogOptions, onLine func(string)) error {
+			// The API resends from the start of the second.
+			onLine("2026-01-01T10:00:00.100Z a")
+			onLine("2026-01-01T10:00:00.200Z b")
+			onLine("2026-01-01T10:00:01.000Z c")
+			api.listsByKey["dev/default/pods"] = nil
+			return nil
+		},
+	}
+
+	got, err := collectFollow(t, api, resources.ResourceItem{Name: "api-1", UID: "u1"}, LogOptions{Tail: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"a", "b", resources.LogBoundary("connection lost, reconnecting"), "c", resources.LogBoundary("pod api-1 deleted")}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if !api.opts[0].Timestamps || api.opts[0].Tail != 10 {
+		t.Fatalf("expected first request to use tail with timestamps, got %#v", api.opts[0])
+	}
+	if want := time.Date(2026, 1, 1, 10, 0, 0, 200000000, time.UTC); !api.opts[1].SinceTime.Equal(want) {
+		t.Fatalf("expected resume since %v, got %v", want, api.opts[1].SinceTime)
+	}
+}
+
+func TestFollowMarksContainerRestart(t *testing.T) {
+	shortFollowBackoff(t)
+	api := &scriptedLogStreamer{
+		fakeKubeAPI: fakeKubeAPI{listsByKey: map[string][]resources.ResourceItem{
+			"dev/default/pods": {{Name: "api-1", UID: "u1", Restarts: "1", Status: "Running"}},
+		}},
+	}
+	api.attempts = []func(string, LogOptions, func(string)) error{
+		func(_ string, _ LogOptions, onLine func(string)) error {
+			onLine("2026-01-01T10:00:00Z boom")
+			return nil
+		},
+	}
+
+	got, _ := collectFollow(t, api, resources.ResourceItem{Name: "api-1", UID: "u1", Restarts: "0"}, LogOptions{Timestamps: true})
+	if len(got) < 2 || got[1] != resources.LogBoundary("container restarted (restart 1)") {
+		t.Fatalf("expected restart boundary, got %q", got)
+	}
+	if got[0] != "2026-01-01T10:00:00Z boom" {
+		t.Fatalf("expected timestamps kept when requested, got %q", got[0])
+	}
+}
+
+func TestFollowOwnerSwitchesToReplacementPod(t *testing.T) {
+	shortFollowBackoff(t)
+	labels := map[string]string{"app": "api", "pod-template-hash": "abc"}
+	api := &scriptedLogStreamer{
+		fakeKubeAPI: fakeKubeAPI{listsByKey: map[string][]resources.ResourceItem{
+			"dev/default/pods": {
+				{Name: "api-new", UID: "u2", Status: "Running", Labels: map[string]string{"app": "api", "pod-template-hash": "def"}, Extra: map[string]string{"controlled-by": "ReplicaSet/api-def"}},
+				{Name: "db-0", UID: "u3", Status: "Running", Labels: map[string]string{"app": "db"}, Extra: map[string]string{"controlled-by": "StatefulSet/db"}},
+			},
+		}},
+	}
+	api.attempts = []func(string, LogOptions, func(string)) error{
+		func(_ string, _ LogOptions, onLine func(string)) error {
+			onLine("2026-01-01T10:00:00Z old")
+			return nil
+		},
+		func(pod string, opts LogOptions, onLine func(string)) error {
+			onLine("2026-01-01T09:59:59Z " + pod)
+			api.listsByKey["dev/default/pods"] = nil
+			return nil
+		},
+	}
+
+	item := resources.ResourceItem{Name: "api-old", UID: "u1", Labels: labels, Extra: map[string]string{"controlled-by": "ReplicaSet/api-abc"}}
+	got, _ := collectFollow(t, api, item, LogOptions{FollowOwner: true})
+	if len(api.pods) < 2 || api.pods[1] != "api-new" {
+		t.Fatalf("expected second stream on replacement pod, got %v", api.pods)
+	}
+	if !api.opts[1].SinceTime.IsZero() {
+		t.Fatalf("expected replacement pod to start from tail, got since %v", api.opts[1].SinceTime)
+	}
+	if len(got) < 3 || got[1] != resources.LogBoundary("pod api-old replaced by api-new") || got[2] != "api-new" {
+		t.Fatalf("expected reschedule boundary then replacement lines, got %q", got)
+	}
+}
+
+func TestFollowReturnsInitialConnectError(t *testing.T) {
+	shortFollowBackoff(t)
+	api := &scriptedLogStreamer{}
+	api.attempts = []func(string, LogOptions, func(string)) error{
+		func(string, LogOptions, func(string)) error { return errors.New("forbidden") },
+	}
+	if _, err := collectFollow(t, api, resources.ResourceItem{Name: "api-1"}, LogOptions{}); err == nil || err.Error() != "forbidden" {
+		t.Fatalf("expected initial error to surface, got %v", err)
+	}
+	if len(api.pods) != 1 {
+		t.Fatalf("expected no retry after initial failure, got %d attempts", len(api.pods))
+	}
+}
@@ -0,0 +1,116 @@
+package data
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dloss/podji/internal/resources"
+)
+
+// grepConcurrency bounds how many pod log requests a grep has in flight.
+const grepConcurrency = 8
+
+// GrepTailLines is how many recent lines are searched per pod. The log view
+// opened on a hit loads its own window, so it finds the hit by its text.
+const GrepTailLines = 200
+
+// LogGrepResult is the outcome of a cross-pod log search. Skipped counts pods
+// none of whose container logs could be read (forbidden, not yet started).
+type LogGrepResult struct {
+	Hits    []resources.LogHit
+	Pods    int
+	Skipped int
+}
+
+// GrepLogs searches the recent logs of every container of every pod in scope
+// for pattern, a case-insensitive regular expression (invalid expressions are
+// matched literally). Hits are ordered by pod, container and line.
+func GrepLogs(ctx context.Context, read ReadModel, scope Scope, pattern string) (LogGrepResult, error) {
+	re := grepPattern(pattern)
+	pods, err := read.List("pods", scope)
+	if err != nil {
+		return LogGrepResult{}, err
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		result = LogGrepResult{Pods: len(pods)}
+		sem    = make(chan struct{}, grepConcurrency)
+	)
+	for _, pod := range pods {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return LogGrepResult{}, ctx.Err()
+		}
+		wg.Add(1)
+		go func(pod resources.ResourceItem) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			var hits []resources.LogHit
+			readable := 0
+			for _, container := range grepContainers(pod) {
+				lines, err := ReadLogs(ctx, read, "pods", pod, scope, LogOptions{Tail: GrepTailLines, Container: container})
+				if err != nil {
+					continue
+				}
+				readable++
+				for i, line := range lines {
+					if re.MatchString(line) {
+						hits = append(hits, resources.LogHit{Pod: pod, Container: container, Line: i, Text: line})
+					}
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if readable == 0 {
+				result.Skipped++
+				return
+			}
+			result.Hits = append(result.Hits, hits...)
+		}(pod)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return LogGrepResult{}, err
+	}
+	sort.SliceStable(result.Hits, func(i, j int) bool {
+		a, b := result.Hits[i], result.Hits[j]
+		if a.Pod.Name != b.Pod.Name {
+			return a.Pod.Name < b.Pod.Name
+		}
+		if a.Container != b.Container {
+			return a.Container < b.Container
+		}
+		return a.Line < b.Line
+	})
+	return result, nil
+}
+
+// grepContainers returns the containers whose logs are searched for pod. A
+// pod listed without its containers is read with the default container.
+func grepContainers(pod resources.ResourceItem) []string {
+	var out []string
+	for _, name := range strings.Split(pod.Extra["containers"], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			out = append(out, name)
+		}
+	}
+	if len(out) == 0 {
+		return []string{""}
+	}
+	return out
+}
+
+func grepPattern(pattern string) *regexp.Regexp {
+	pattern = strings.TrimSpace(pattern)
+	if re, err := regexp.Compile("(?i)" + pattern); err == nil {
+		return re
+	}
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(pattern))
+}
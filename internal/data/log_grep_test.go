@@ -0,0 +1,117 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/dloss/podji/internal/resources"
+)
+
+type grepReadModel struct {
+	fakeReadModel
+	logs map[string][]string
+
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	release  chan struct{}
+}
+
+func (g *grepReadModel) LogsWithContext(ctx context.Context, resourceName string, item resources.ResourceItem, scope Scope, opts LogOptions) ([]string, error) {
+	g.mu.Lock()
+	g.inFlight++
+	if g.inFlight > g.peak {
+		g.peak = g.inFlight
+	}
+	g.mu.Unlock()
+	defer func() {
+		g.mu.Lock()
+		g.inFlight--
+		g.mu.Unlock()
+	}()
+	if g.release != nil {
+		select {
+		case <-g.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	key := item.Name
+	if opts.Container != "" {
+		key += "/" + opts.Container
+	}
+	lines, ok := g.logs[key]
+	if !ok {
+		return nil, errors.New("container name must be specified")
+	}
+	return lines, nil
+}
+
+func (g *grepReadModel) EventsWithContext(ctx context.Context, resourceName string, item resources.ResourceItem, scope Scope, opts EventOptions) ([]string, error) {
+	return nil, nil
+}
+
+func TestGrepLogsGroupsHitsByPodAndCountsSkipped(t *testing.T) {
+	read := &grepReadModel{
+		fakeReadModel: fakeReadModel{items: []resources.ResourceItem{
+			{Name: "web-2"},
+			{Name: "web-1"},
+			{Name: "multi", Extra: map[string]string{"containers": "app,sidecar"}},
+			{Name: "pending"},
+		}},
+		logs: map[string][]string{
+			"web-1":         {"ok", "ERROR connection refused", "ok", "error: timeout"},
+			"web-2":         {"Error: connection refused"},
+			"multi/app":     {"ok"},
+			"multi/sidecar": {"ok", "error: upstream refused"},
+		},
+	}
+	got, err := GrepLogs(context.Background(), read, Scope{}, "error.*refused")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Pods != 4 || got.Skipped != 1 {
+		t.Fatalf("expected 4 pods with 1 skipped, got %+v", got)
+	}
+	if len(got.Hits) != 3 || got.Hits[0].Pod.Name != "multi" || got.Hits[0].Container != "sidecar" || got.Hits[0].Line != 1 ||
+		got.Hits[1].Pod.Name != "web-1" || got.Hits[1].Line != 1 || got.Hits[2].Pod.Name != "web-2" {
+		t.Fatalf("unexpected hits: %+v", got.Hits)
+	}
+
+	// Invalid expressions fall back to a literal match.
+	got, _ = GrepLogs(context.Background(), read, Scope{}, "error: (")
+	if len(got.Hits) != 0 {
+		t.Fatalf("expected literal fallback to find nothing, got %+v", got.Hits)
+	}
+}
+
+func TestGrepLogsBoundsConcurrencyAndCancels(t *testing.T) {
+	pods := make([]resources.ResourceItem, grepConcurrency*3)
+	for i := range pods {
+		pods[i] = resources.ResourceItem{Name: "pod-" + string(rune('a'+i))}
+	}
+	read := &grepReadModel{fakeReadModel: fakeReadModel{items: pods}, release: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := GrepLogs(ctx, read, Scope{}, "x")
+		done <- err
+	}()
+	for {
+		read.mu.Lock()
+		n := read.inFlight
+		read.mu.Unlock()
+		if n == grepConcurrency {
+			break
+		}
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+	if read.peak > grepConcurrency {
+		t.Fatalf("expected at most %d concurrent requests, saw %d", grepConcurrency, read.peak)
+	}
+}
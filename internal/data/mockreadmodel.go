@@ -3,12 +3,20 @@ package data
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/dloss/podji/internal/resources"
 )
 
 type MockReadModel struct {
 	registry *resources.Registry
+
+	mu sync.Mutex
+	// debugContainers holds the ephemeral containers added per pod key, so
+	// they show up in later pod details.
+	debugContainers map[string][]resources.ContainerRow
 }
 
 func NewMockReadModel(registry *resources.Registry) *MockReadModel {
@@ -28,7 +36,13 @@ func (m *MockReadModel) Detail(resourceName string, item resources.ResourceItem,
 	if err != nil {
 		return resources.DetailData{}, err
 	}
-	return res.Detail(item), nil
+	detail := res.Detail(item)
+	if resourceName == "pods" {
+		m.mu.Lock()
+		detail.Containers = append(detail.Containers, m.debugContainers[mockPodKey(item, scope)]...)
+		m.mu.Unlock()
+	}
+	return detail, nil
 }
 
 func (m *MockReadModel) Logs(resourceName string, item resources.ResourceItem, scope Scope) ([]string, error) {
@@ -100,6 +114,148 @@ func (m *MockReadModel) Describe(resourceName string, item resources.ResourceIte
 	return res.Describe(item), nil
 }
 
+func (m *MockReadModel) ConfigMapData(item resources.ResourceItem, scope Scope) (resources.ConfigMapData, error) {
+	res, err := m.resourceFor("configmaps", scope)
+	if err != nil {
+		return resources.ConfigMapData{}, err
+	}
+	reader, ok := res.(resources.ConfigMapDataReader)
+	if !ok {
+		return resources.ConfigMapData{}, ErrObjectReadNotSupported
+	}
+	return reader.ConfigMapData(item)
+}
+
+func (m *MockReadModel) SecretData(item resources.ResourceItem, scope Scope) (resources.SecretData, error) {
+	res, err := m.resourceFor("secrets", scope)
+	if err != nil {
+		return resources.SecretData{}, err
+	}
+	reader, ok := res.(resources.SecretDataReader)
+	if !ok {
+		return resources.SecretData{}, ErrObjectReadNotSupported
+	}
+	return reader.SecretData(item)
+}
+
+// ApplyObject simulates server-side apply: the manifest is validated and
+// echoed back, but mock data is never changed.
+func (m *MockReadModel) ApplyObject(ctx context.Context, manifest string, scope Scope, opts resources.ApplyOptions) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if _, err := parseManifest([]byte(manifest)); err != nil {
+		return "", err
+	}
+	return manifest, nil
+}
+
+// mockDrainStep paces the simulated drain so its progress is visible.
+var mockDrainStep = 400 * time.Millisecond
+
+// SetNodeSchedulable pretends to cordon or uncordon; mock nodes are static.
+func (m *MockReadModel) SetNodeSchedulable(ctx context.Context, item resources.ResourceItem, scope Scope, schedulable bool) error {
+	return ctx.Err()
+}
+
+// DrainNode simulates a drain of the node's mock pods. A node-exporter
+// DaemonSet pod is skipped, stateful pods hold emptyDir data, and the first
+// pod is held back once by a disruption budget.
+func (m *MockReadModel) DrainNode(ctx context.Context, item resources.ResourceItem, scope Scope, opts resources.DrainOptions, onEvent func(resources.DrainEvent)) error {
+	ns := scope.Namespace
+	if ns == "" || ns == resources.AllNamespaces {
+		ns = resources.DefaultNamespace
+	}
+	pods := resources.NewNodePods(item.Name).Items()
+	onEvent(resources.DrainEvent{Namespace: "monitoring", Pod: "node-exporter-" + item.Name, State: resources.DrainSkipped, Reason: "DaemonSet-managed"})
+	var evict []string
+	var blocked int
+	for _, pod := range pods {
+		if strings.HasSuffix(pod.Name, "-0") && !opts.DeleteEmptyDirData {
+			onEvent(resources.DrainEvent{Namespace: ns, Pod: pod.Name, State: resources.DrainBlocked, Reason: "uses emptyDir (enable deletion of emptyDir data)"})
+			blocked++
+			continue
+		}
+		onEvent(resources.DrainEvent{Namespace: ns, Pod: pod.Name, State: resources.DrainPending})
+		evict = append(evict, pod.Name)
+	}
+	if blocked > 0 {
+		return fmt.Errorf("cannot drain %s: %d pod(s) blocked", item.Name, blocked)
+	}
+	steps := []string{resources.DrainEvicting, resources.DrainTerminating, resources.DrainEvicted}
+	for i, pod := range evict {
+		for _, state := range steps {
+			if err := sleepContext(ctx, mockDrainStep); err != nil {
+				return err
+			}
+			onEvent(resources.DrainEvent{Namespace: ns, Pod: pod, State: state})
+			if i == 0 && state == resources.DrainEvicting {
+				if err := sleepContext(ctx, mockDrainStep); err != nil {
+					return err
+				}
+				onEvent(resources.DrainEvent{Namespace: ns, Pod: pod, State: resources.DrainRetrying, Reason: "Cannot evict pod as it would violate the pod's disruption budget."})
+			}
+		}
+	}
+	return nil
+}
+
+// TriggerCronJob pretends to create a Job from the CronJob's template and
+// returns it as a fresh, running Job.
+func (m *MockReadModel) TriggerCronJob(ctx context.Context, item resources.ResourceItem, scope Scope) (resources.ResourceItem, error) {
+	if err := ctx.Err(); err != nil {
+		return resources.ResourceItem{}, err
+	}
+	if item.Kind != "CJ" {
+		return resources.ResourceItem{}, fmt.Errorf("%s is not a cronjob", item.Name)
+	}
+	now := time.Now()
+	return resources.ResourceItem{
+		Name:      fmt.Sprintf("%s-manual-%d", item.Name, now.Unix()%1000000),
+		Namespace: item.Namespace,
+		Kind:      "JOB",
+		Status:    "Progressing",
+		Ready:     "0/1",
+		Restarts:  "0",
+		Age:       "0s",
+		Extra: map[string]string{
+			"created":       fmt.Sprint(now.Unix()),
+			"controlled-by": "CronJob/" + item.Name,
+		},
+	}, nil
+}
+
+// AddDebugContainer pretends to start an ephemeral container; it runs at
+// once and is listed with the pod's containers afterwards.
+func (m *MockReadModel) AddDebugContainer(ctx context.Context, pod resources.ResourceItem, scope Scope, target, image string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	key := mockPodKey(pod, scope)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.debugContainers == nil {
+		m.debugContainers = map[string][]resources.ContainerRow{}
+	}
+	name := fmt.Sprintf("debugger-%d", len(m.debugContainers[key])+1)
+	m.debugContainers[key] = append(m.debugContainers[key], resources.ContainerRow{
+		Name:     name,
+		Image:    image,
+		State:    "Running",
+		Restarts: "0",
+		Type:     resources.ContainerTypeEphemeral,
+	})
+	return name, nil
+}
+
+func mockPodKey(pod resources.ResourceItem, scope Scope) string {
+	ns := pod.Namespace
+	if ns == "" {
+		ns = scope.Namespace
+	}
+	return ns + "/" + pod.Name
+}
+
 func (m *MockReadModel) resourceFor(resourceName string, scope Scope) (resources.ResourceType, error) {
 	if m.registry == nil {
 		return nil, fmt.Errorf("read model has no registry")
@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/dloss/podji/internal/resources"
@@ -84,3 +85,67 @@ func TestMockReadModelLogsWithContextCancelled(t *testing.T) {
 		t.Fatal("expected cancellation error")
 	}
 }
+
+func TestMockReadModelDrainNode(t *testing.T) {
+	step := mockDrainStep
+	mockDrainStep = 0
+	defer func() { mockDrainStep = step }()
+
+	read := NewMockStore().ReadModel().(NodeReadModel)
+	scope := Scope{Context: "default", Namespace: "default"}
+	node := resources.ResourceItem{Name: "worker-01"}
+
+	states := map[string]string{}
+	err := read.DrainNode(context.Background(), node, scope, resources.DrainOptions{}, func(ev resources.DrainEvent) {
+		states[ev.Pod] = ev.State
+	})
+	if err == nil || states["prometheus-0"] != resources.DrainBlocked {
+		t.Fatalf("expected emptyDir pod to block the drain, got %v %v", err, states)
+	}
+
+	states = map[string]string{}
+	err = read.DrainNode(context.Background(), node, scope, resources.DrainOptions{DeleteEmptyDirData: true}, func(ev resources.DrainEvent) {
+		states[ev.Pod] = ev.State
+	})
+	if err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+	if states["prometheus-0"] != resources.DrainEvicted || states["node-exporter-worker-01"] != resources.DrainSkipped {
+		t.Fatalf("unexpected final states: %v", states)
+	}
+}
+
+func TestMockReadModelTriggerCronJob(t *testing.T) {
+	read := NewMockStore().ReadModel().(CronJobReadModel)
+	scope := Scope{Context: "default", Namespace: "default"}
+
+	job, err := read.TriggerCronJob(context.Background(), resources.ResourceItem{Name: "nightly-backup", Kind: "CJ"}, scope)
+	if err != nil {
+		t.Fatalf("TriggerCronJob: %v", err)
+	}
+	if job.Kind != "JOB" || !strings.HasPrefix(job.Name, "nightly-backup-manual-") {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	if _, err := read.TriggerCronJob(context.Background(), resources.ResourceItem{Name: "api", Kind: "DEP"}, scope); err == nil {
+		t.Fatal("expected an error for a non-cronjob")
+	}
+}
+
+func TestMockReadModelDebugContainerShowsInPodDetail(t *testing.T) {
+	read := NewMockStore().ReadModel()
+	scope := Scope{Context: "default", Namespace: "default"}
+	pod := resources.ResourceItem{Name: "api-7c6c8d5f7d-x8p2k"}
+
+	name, err := read.(DebugReadModel).AddDebugContainer(context.Background(), pod, scope, "app", "busybox:1.36")
+	if err != nil {
+		t.Fatalf("AddDebugContainer: %v", err)
+	}
+	detail, err := read.Detail("pods", pod, scope)
+	if err != nil {
+		t.Fatalf("Detail: %v", err)
+	}
+	last := detail.Containers[len(detail.Containers)-1]
+	if last.Name != name || last.Type != resources.ContainerTypeEphemeral || last.Image != "busybox:1.36" {
+		t.Fatalf("expected debug container in pod detail, got %+v", detail.Containers)
+	}
+}
@@ -0,0 +1,70 @@
+package data
+
+import (
+	"strconv"
+
+	"github.com/dloss/podji/internal/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// allocationByNode sums the requests and limits of scheduled, non-terminated
+// pods per node name.
+func allocationByNode(pods []*corev1.Pod) map[string]podResources {
+	out := map[string]podResources{}
+	for _, p := range pods {
+		if p.Spec.NodeName == "" || podTerminated(p) {
+			continue
+		}
+		total := out[p.Spec.NodeName]
+		total.add(podResourceTotals(p))
+		out[p.Spec.NodeName] = total
+	}
+	return out
+}
+
+// nodeAllocationRows compares a node's pod totals with its allocatable cpu
+// and memory.
+func nodeAllocationRows(n *corev1.Node, total podResources) []resources.AllocationRow {
+	allocatable := n.Status.Allocatable
+	return []resources.AllocationRow{
+		allocationRow("cpu", total.cpuRequests, total.cpuLimits, allocatable.Cpu()),
+		allocationRow("memory", total.memoryRequests, total.memoryLimits, allocatable.Memory()),
+	}
+}
+
+func allocationRow(name string, requests, limits resource.Quantity, allocatable *resource.Quantity) resources.AllocationRow {
+	return resources.AllocationRow{
+		Resource:        name,
+		Requests:        requests.String(),
+		Limits:          limits.String(),
+		Allocatable:     allocatable.String(),
+		RequestsPercent: percentOf(requests, allocatable),
+		LimitsPercent:   percentOf(limits, allocatable),
+	}
+}
+
+// setNodeAllocationExtra fills the optional allocation list columns. They
+// show "-" when the pods could not be read, and until the pod cache has
+// synced: summing every pod of the cluster on each refresh is too costly to
+// do with a direct list. The node detail computes its own allocation.
+func setNodeAllocationExtra(extra map[string]string, n *corev1.Node, byNode map[string]podResources) {
+	if byNode == nil {
+		for _, key := range []string{"cpu-req", "cpu-lim", "mem-req", "mem-lim"} {
+			extra[key] = "-"
+		}
+		return
+	}
+	rows := nodeAllocationRows(n, byNode[n.Name])
+	extra["cpu-req"] = strconv.Itoa(rows[0].RequestsPercent) + "%"
+	extra["cpu-lim"] = strconv.Itoa(rows[0].LimitsPercent) + "%"
+	extra["mem-req"] = strconv.Itoa(rows[1].RequestsPercent) + "%"
+	extra["mem-lim"] = strconv.Itoa(rows[1].LimitsPercent) + "%"
+}
+
+func percentOf(q resource.Quantity, allocatable *resource.Quantity) int {
+	if allocatable == nil || allocatable.MilliValue() <= 0 {
+		return 0
+	}
+	return int(q.MilliValue() * 100 / allocatable.MilliValue())
+}
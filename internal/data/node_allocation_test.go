@@ -0,0 +1,89 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func allocationPod(name, node string, phase corev1.PodPhase, cpu, memory string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestListNodesAddsAllocationColumns(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("4"),
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+		}},
+	}
+	pods := []*corev1.Pod{
+		allocationPod("api", "worker-1", corev1.PodRunning, "1", "2Gi"),
+		allocationPod("worker", "worker-1", corev1.PodRunning, "1", "2Gi"),
+		allocationPod("done", "worker-1", corev1.PodSucceeded, "2", "4Gi"),
+		allocationPod("pending", "", corev1.PodPending, "2", "4Gi"),
+	}
+
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	podIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = nodeIndexer.Add(node)
+	for _, p := range pods {
+		_ = podIndexer.Add(p)
+	}
+	inf := &contextInformers{nodes: corelisters.NewNodeLister(nodeIndexer), pods: corelisters.NewPodLister(podIndexer)}
+	items, err := (&clientGoAPI{}).listNodesFromInformer(inf, labels.Everything())
+	if err != nil {
+		t.Fatalf("listNodesFromInformer: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected one node, got %d", len(items))
+	}
+	extra := items[0].Extra
+	if extra["cpu-req"] != "50%" || extra["mem-req"] != "50%" || extra["cpu-lim"] != "50%" {
+		t.Fatalf("unexpected allocation columns: %v", extra)
+	}
+
+	// Without a synced cache the columns wait rather than list every pod.
+	client := fake.NewSimpleClientset(node, pods[0], pods[1])
+	items, err = (&clientGoAPI{}).listNodes(context.Background(), client)
+	if err != nil {
+		t.Fatalf("listNodes: %v", err)
+	}
+	if len(items) != 1 || items[0].Extra["cpu-req"] != "-" {
+		t.Fatalf("expected pending allocation columns, got %v", items)
+	}
+	for _, action := range client.Actions() {
+		if action.GetResource().Resource == "pods" {
+			t.Fatalf("expected no pod list, got %v", action)
+		}
+	}
+
+	rows := nodeAllocationRows(node, allocationByNode([]*corev1.Pod{allocationPod("api", "worker-1", corev1.PodRunning, "3", "2Gi")})["worker-1"])
+	if rows[0].Resource != "cpu" || rows[0].Requests != "3" || rows[0].Allocatable != "4" || rows[0].RequestsPercent != 75 {
+		t.Fatalf("unexpected cpu row: %+v", rows[0])
+	}
+	if rows[1].Resource != "memory" || rows[1].RequestsPercent != 25 {
+		t.Fatalf("unexpected memory row: %+v", rows[1])
+	}
+}
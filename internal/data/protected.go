@@ -0,0 +1,81 @@
+package data
+
+import "strings"
+
+// Context tiers returned by ContextTier.
+const (
+	ContextTierProd   = "prod"
+	ContextTierLocal  = "local"
+	ContextTierRemote = "remote"
+)
+
+// contextTierPatterns holds the name patterns for prod and local contexts.
+var contextTierPatterns = struct {
+	prod, local []string
+}{
+	prod:  []string{"*prod*"},
+	local: []string{"default", "minikube", "docker-desktop", "rancher-desktop", "kind-*", "k3d-*"},
+}
+
+// SetContextTierPatterns replaces the patterns that classify contexts. In a
+// pattern, * matches any run of characters, including the slashes of EKS
+// context names, and ? matches one character. Matching is case-insensitive.
+// It is meant to be called once at startup.
+func SetContextTierPatterns(prod, local []string) {
+	contextTierPatterns.prod = append([]string(nil), prod...)
+	contextTierPatterns.local = append([]string(nil), local...)
+}
+
+// ContextTier classifies a context name: "prod" for production contexts,
+// "local" for local clusters and "remote" for everything else.
+func ContextTier(name string) string {
+	switch {
+	case matchesAny(contextTierPatterns.prod, name):
+		return ContextTierProd
+	case matchesAny(contextTierPatterns.local, name):
+		return ContextTierLocal
+	}
+	return ContextTierRemote
+}
+
+func matchesAny(patterns []string, name string) bool {
+	lower := []rune(strings.ToLower(name))
+	for _, pattern := range patterns {
+		if globMatch([]rune(strings.ToLower(pattern)), lower) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatch matches * and ? wildcards, backtracking to the last star.
+func globMatch(pattern, name []rune) bool {
+	p, n := 0, 0
+	star, mark := -1, 0
+	for n < len(name) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == name[n]):
+			p++
+			n++
+		case p < len(pattern) && pattern[p] == '*':
+			star, mark = p, n
+			p++
+		case star >= 0:
+			p = star + 1
+			mark++
+			n = mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// ProtectedContext reports whether a context is treated as production, where
+// podji refuses to reveal secret values.
+func ProtectedContext(name string) bool {
+	return ContextTier(name) == ContextTierProd
+}
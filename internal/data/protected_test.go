@@ -0,0 +1,36 @@
+package data
+
+import "testing"
+
+func TestContextTierPatterns(t *testing.T) {
+	t.Cleanup(func() {
+		SetContextTierPatterns([]string{"*prod*"}, []string{"default", "minikube", "docker-desktop", "rancher-desktop", "kind-*", "k3d-*"})
+	})
+	cases := map[string]string{
+		"arn:aws:eks:eu-west-1:1234:cluster/PROD-main": ContextTierProd,
+		"kind-dev":    ContextTierLocal,
+		"minikube":    ContextTierLocal,
+		"staging-eu":  ContextTierRemote,
+		"minikube-02": ContextTierRemote,
+	}
+	for name, want := range cases {
+		if got := ContextTier(name); got != want {
+			t.Errorf("ContextTier(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	SetContextTierPatterns([]string{"live-*", "*-p?"}, []string{"dev-*"})
+	for name, want := range map[string]string{
+		"live-eu":   ContextTierProd,
+		"shop-p1":   ContextTierProd,
+		"prod-main": ContextTierRemote,
+		"dev-local": ContextTierLocal,
+	} {
+		if got := ContextTier(name); got != want {
+			t.Errorf("ContextTier(%q) = %q, want %q", name, got, want)
+		}
+	}
+	if !ProtectedContext("LIVE-us") {
+		t.Error("expected configured prod pattern to protect the context")
+	}
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/dloss/podji/internal/resources"
@@ -91,23 +92,11 @@ func (r *ReadBackedResource) Events(item resources.ResourceItem) []string {
 func (r *ReadBackedResource) LogsWithOptions(ctx context.Context, item resources.ResourceItem, opts resources.LogOptions) ([]string, error) {
 	reqCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	return ReadLogs(reqCtx, r.read, r.base.Name(), item, r.scopeFunc(), LogOptions{
-		Tail:       opts.Tail,
-		Follow:     opts.Follow,
-		Previous:   opts.Previous,
-		Container:  opts.Container,
-		Timestamps: opts.Timestamps,
-	})
+	return ReadLogs(reqCtx, r.read, r.base.Name(), item, r.scopeFunc(), logOptionsFromResource(opts))
 }
 
 func (r *ReadBackedResource) LogsStream(ctx context.Context, item resources.ResourceItem, opts resources.LogOptions, onLine func(string)) error {
-	return StreamLogs(ctx, r.read, r.base.Name(), item, r.scopeFunc(), LogOptions{
-		Tail:       opts.Tail,
-		Follow:     opts.Follow,
-		Previous:   opts.Previous,
-		Container:  opts.Container,
-		Timestamps: opts.Timestamps,
-	}, onLine)
+	return StreamLogs(ctx, r.read, r.base.Name(), item, r.scopeFunc(), logOptionsFromResource(opts), onLine)
 }
 
 func (r *ReadBackedResource) EventsWithOptions(ctx context.Context, item resources.ResourceItem, opts resources.EventOptions) ([]string, error) {
@@ -129,6 +118,138 @@ func (r *ReadBackedResource) YAML(item resources.ResourceItem) string {
 	return text
 }
 
+// HelmManifest reads the release manifest through the read model, falling back
+// to the base resource (mock data) when the read model has no Helm support.
+func (r *ReadBackedResource) HelmManifest(namespace, release string) (string, error) {
+	if helm, ok := r.read.(HelmReadModel); ok {
+		scope := r.scopeFunc()
+		if namespace != "" {
+			scope.Namespace = namespace
+		}
+		manifest, err := helm.HelmManifest(release, scope)
+		if err == nil || !r.fallback {
+			return manifest, err
+		}
+	}
+	if base, ok := r.base.(resources.HelmManifestReader); ok && r.fallback {
+		return base.HelmManifest(namespace, release)
+	}
+	return "", ErrObjectReadNotSupported
+}
+
+// ConfigMapData reads config map keys through the read model, falling back to
+// the base resource like other reads.
+func (r *ReadBackedResource) ConfigMapData(item resources.ResourceItem) (resources.ConfigMapData, error) {
+	if reader, ok := r.read.(ConfigMapReadModel); ok {
+		data, err := reader.ConfigMapData(item, r.scopeFunc())
+		if err == nil || !r.fallback {
+			return data, err
+		}
+	}
+	if base, ok := r.base.(resources.ConfigMapDataReader); ok && r.fallback {
+		return base.ConfigMapData(item)
+	}
+	return resources.ConfigMapData{}, ErrObjectReadNotSupported
+}
+
+// SecretData reads decoded secret values and marks them protected when the
+// scope is a protected context.
+func (r *ReadBackedResource) SecretData(item resources.ResourceItem) (resources.SecretData, error) {
+	scope := r.scopeFunc()
+	var (
+		data resources.SecretData
+		err  = ErrObjectReadNotSupported
+	)
+	if reader, ok := r.read.(SecretReadModel); ok {
+		data, err = reader.SecretData(item, scope)
+	}
+	if err != nil && r.fallback {
+		if base, ok := r.base.(resources.SecretDataReader); ok {
+			data, err = base.SecretData(item)
+		}
+	}
+	if err != nil {
+		return resources.SecretData{}, err
+	}
+	data.Protected = ProtectedContext(scope.Context)
+	return data, nil
+}
+
+// ApplyYAML writes an edited object through the read model. Without live
+// apply support the base resource gets a chance, as with reads.
+func (r *ReadBackedResource) ApplyYAML(ctx context.Context, item resources.ResourceItem, manifest string, opts resources.ApplyOptions) (string, error) {
+	if applier, ok := r.read.(ApplyReadModel); ok {
+		scope := r.scopeFunc()
+		if item.Namespace != "" {
+			scope.Namespace = item.Namespace
+		}
+		result, err := applier.ApplyObject(ctx, manifest, scope, opts)
+		if !errors.Is(err, ErrApplyNotSupported) || !r.fallback {
+			return result, err
+		}
+	}
+	if base, ok := r.base.(resources.ObjectApplier); ok && r.fallback {
+		return base.ApplyYAML(ctx, item, manifest, opts)
+	}
+	return "", ErrApplyNotSupported
+}
+
+// SetNodeSchedulable cordons or uncordons a node through the read model.
+func (r *ReadBackedResource) SetNodeSchedulable(ctx context.Context, item resources.ResourceItem, schedulable bool) error {
+	if operator, ok := r.read.(NodeReadModel); ok {
+		err := operator.SetNodeSchedulable(ctx, item, r.scopeFunc(), schedulable)
+		if !errors.Is(err, ErrNodeActionNotSupported) || !r.fallback {
+			return err
+		}
+	}
+	if base, ok := r.base.(resources.NodeOperator); ok && r.fallback {
+		return base.SetNodeSchedulable(ctx, item, schedulable)
+	}
+	return ErrNodeActionNotSupported
+}
+
+// DrainNode drains a node through the read model.
+func (r *ReadBackedResource) DrainNode(ctx context.Context, item resources.ResourceItem, opts resources.DrainOptions, onEvent func(resources.DrainEvent)) error {
+	if operator, ok := r.read.(NodeReadModel); ok {
+		err := operator.DrainNode(ctx, item, r.scopeFunc(), opts, onEvent)
+		if !errors.Is(err, ErrNodeActionNotSupported) || !r.fallback {
+			return err
+		}
+	}
+	if base, ok := r.base.(resources.NodeOperator); ok && r.fallback {
+		return base.DrainNode(ctx, item, opts, onEvent)
+	}
+	return ErrNodeActionNotSupported
+}
+
+// TriggerCronJob runs a CronJob now through the read model.
+func (r *ReadBackedResource) TriggerCronJob(ctx context.Context, item resources.ResourceItem) (resources.ResourceItem, error) {
+	if trigger, ok := r.read.(CronJobReadModel); ok {
+		job, err := trigger.TriggerCronJob(ctx, item, r.scopeFunc())
+		if !errors.Is(err, ErrTriggerNotSupported) || !r.fallback {
+			return job, err
+		}
+	}
+	if base, ok := r.base.(resources.CronJobTrigger); ok && r.fallback {
+		return base.TriggerCronJob(ctx, item)
+	}
+	return resources.ResourceItem{}, ErrTriggerNotSupported
+}
+
+// AddDebugContainer adds an ephemeral debug container through the read model.
+func (r *ReadBackedResource) AddDebugContainer(ctx context.Context, pod resources.ResourceItem, target, image string) (string, error) {
+	if creator, ok := r.read.(DebugReadModel); ok {
+		name, err := creator.AddDebugContainer(ctx, pod, r.scopeFunc(), target, image)
+		if !errors.Is(err, ErrDebugNotSupported) || !r.fallback {
+			return name, err
+		}
+	}
+	if base, ok := r.base.(resources.DebugContainerCreator); ok && r.fallback {
+		return base.AddDebugContainer(ctx, pod, target, image)
+	}
+	return "", ErrDebugNotSupported
+}
+
 func (r *ReadBackedResource) Describe(item resources.ResourceItem) string {
 	text, err := r.read.Describe(r.base.Name(), item, r.scopeFunc())
 	if err != nil {
@@ -194,3 +194,19 @@ func TestReadBackedResourceLogStreamerPropagatesOptions(t *testing.T) {
 		t.Fatalf("expected propagated stream log options, got %#v", streaming.lastLogOptions)
 	}
 }
+
+func TestReadBackedResourceSecretDataMarksProtectedContexts(t *testing.T) {
+	registry := resources.DefaultRegistry()
+	item := resources.ResourceItem{Name: "redis-password", Kind: "Opaque"}
+	for context, protected := range map[string]bool{"dev-cluster": false, "prod-eu-1": true} {
+		scope := Scope{Context: context, Namespace: "default"}
+		res := NewReadBackedResource(registry.ByName("secrets"), NewMockReadModel(registry), func() Scope { return scope })
+		data, err := res.(resources.SecretDataReader).SecretData(item)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.Protected != protected || len(data.Data["password"]) == 0 {
+			t.Fatalf("context %s: expected protected=%v with data, got %+v", context, protected, data.Protected)
+		}
+	}
+}
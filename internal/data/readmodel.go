@@ -2,8 +2,10 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/dloss/podji/internal/resources"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // ReadModel defines resource read operations independent from concrete data source.
@@ -18,17 +20,60 @@ type ReadModel interface {
 }
 
 type LogOptions struct {
-	Tail       int
-	Follow     bool
-	Previous   bool
-	Container  string
-	Timestamps bool
+	Tail        int
+	Follow      bool
+	Previous    bool
+	Container   string
+	Timestamps  bool
+	SinceTime   time.Time
+	FollowOwner bool
+}
+
+func logOptionsFromResource(opts resources.LogOptions) LogOptions {
+	return LogOptions{
+		Tail:        opts.Tail,
+		Follow:      opts.Follow,
+		Previous:    opts.Previous,
+		Container:   opts.Container,
+		Timestamps:  opts.Timestamps,
+		SinceTime:   opts.SinceTime,
+		FollowOwner: opts.FollowOwner,
+	}
 }
 
 type EventOptions struct {
 	Limit int
 }
 
+// SelectorReadModel optionally extends ReadModel with listing only the items
+// whose labels match a selector.
+type SelectorReadModel interface {
+	ListMatching(resourceName string, scope Scope, selector labels.Selector) ([]resources.ResourceItem, error)
+}
+
+// ListMatching lists the items of a resource whose labels match selector. It
+// lets the read model filter when it can and filters the full list otherwise.
+func ListMatching(read ReadModel, resourceName string, scope Scope, selector labels.Selector) ([]resources.ResourceItem, error) {
+	if lister, ok := read.(SelectorReadModel); ok {
+		return lister.ListMatching(resourceName, scope, selector)
+	}
+	items, err := read.List(resourceName, scope)
+	if err != nil {
+		return nil, err
+	}
+	return filterLabels(items, selector), nil
+}
+
+func filterLabels(items []resources.ResourceItem, selector labels.Selector) []resources.ResourceItem {
+	var out []resources.ResourceItem
+	for _, item := range items {
+		if selector.Matches(labels.Set(item.Labels)) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 // StreamingReadModel optionally extends ReadModel with context-aware access for
 // cancellation and future follow/tail behavior.
 type StreamingReadModel interface {
@@ -42,6 +87,46 @@ type LogStreamReadModel interface {
 	StreamLogsWithContext(ctx context.Context, resourceName string, item resources.ResourceItem, scope Scope, opts LogOptions, onLine func(string)) error
 }
 
+// HelmReadModel optionally extends ReadModel with access to Helm release
+// manifests.
+type HelmReadModel interface {
+	HelmManifest(release string, scope Scope) (string, error)
+}
+
+// ApplyReadModel optionally extends ReadModel with server-side apply of
+// edited objects.
+type ApplyReadModel interface {
+	ApplyObject(ctx context.Context, manifest string, scope Scope, opts resources.ApplyOptions) (string, error)
+}
+
+// NodeReadModel optionally extends ReadModel with node scheduling actions.
+type NodeReadModel interface {
+	SetNodeSchedulable(ctx context.Context, item resources.ResourceItem, scope Scope, schedulable bool) error
+	DrainNode(ctx context.Context, item resources.ResourceItem, scope Scope, opts resources.DrainOptions, onEvent func(resources.DrainEvent)) error
+}
+
+// CronJobReadModel optionally extends ReadModel with running a CronJob now.
+type CronJobReadModel interface {
+	TriggerCronJob(ctx context.Context, item resources.ResourceItem, scope Scope) (resources.ResourceItem, error)
+}
+
+// DebugReadModel optionally extends ReadModel with ephemeral debug containers.
+type DebugReadModel interface {
+	AddDebugContainer(ctx context.Context, pod resources.ResourceItem, scope Scope, target, image string) (string, error)
+}
+
+// ConfigMapReadModel optionally extends ReadModel with access to config map
+// keys.
+type ConfigMapReadModel interface {
+	ConfigMapData(item resources.ResourceItem, scope Scope) (resources.ConfigMapData, error)
+}
+
+// SecretReadModel optionally extends ReadModel with access to decoded secret
+// values.
+type SecretReadModel interface {
+	SecretData(item resources.ResourceItem, scope Scope) (resources.SecretData, error)
+}
+
 func ReadLogs(ctx context.Context, read ReadModel, resourceName string, item resources.ResourceItem, scope Scope, opts LogOptions) ([]string, error) {
 	if streaming, ok := read.(StreamingReadModel); ok {
 		return streaming.LogsWithContext(ctx, resourceName, item, scope, opts)
@@ -0,0 +1,100 @@
+// Package plugin runs user-defined commands on the selected item of a
+// resource list. Plugins are declared in the config file; their arguments
+// are Go templates filled in from the item.
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/dloss/podji/internal/config"
+	"github.com/dloss/podji/internal/resources"
+)
+
+// AllKinds in a plugin's kinds applies it to every resource list.
+const AllKinds = "*"
+
+// Plugin is a configured plugin with its arguments parsed.
+type Plugin struct {
+	config.Plugin
+	args  []*template.Template
+	kinds map[string]bool
+}
+
+// funcs are the template functions available to arguments.
+var funcs = template.FuncMap{
+	"quote": Quote,
+	"join":  strings.Join,
+}
+
+// Compile parses the argument templates of p and resolves its kinds through
+// the registry, so aliases such as "deploy" and singulars such as "pod"
+// work. Kinds the registry does not know, such as "containers", are kept as
+// written.
+func Compile(p config.Plugin, registry *resources.Registry) (*Plugin, error) {
+	compiled := &Plugin{Plugin: p, kinds: map[string]bool{}}
+	for i, arg := range p.Args {
+		tmpl, err := template.New(p.Name).Funcs(funcs).Option("missingkey=error").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("plugins.%s.args[%d]: %w", p.Name, i, err)
+		}
+		compiled.args = append(compiled.args, tmpl)
+	}
+	for _, kind := range p.Kinds {
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		if res := registry.Lookup(kind); res != nil {
+			kind = res.Name()
+		}
+		compiled.kinds[kind] = true
+	}
+	return compiled, nil
+}
+
+// ListKind returns the kind of items a resource list shows: its name without
+// any qualifier, so "pods (node: n1)" and "pods" are both "pods".
+func ListKind(res resources.ResourceType) string {
+	name := strings.ToLower(res.Name())
+	if head, _, ok := strings.Cut(name, " "); ok {
+		return head
+	}
+	return name
+}
+
+// Applies reports whether the plugin can run on items of the list res.
+func (p *Plugin) Applies(res resources.ResourceType) bool {
+	return p.kinds[AllKinds] || p.kinds[ListKind(res)]
+}
+
+// Args fills in the argument templates for an item.
+func (p *Plugin) Args(vars Vars) ([]string, error) {
+	args := make([]string, len(p.args))
+	for i, tmpl := range p.args {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, vars); err != nil {
+			return nil, fmt.Errorf("args[%d]: %w", i, err)
+		}
+		args[i] = buf.String()
+	}
+	return args, nil
+}
+
+// CommandLine renders the command and args for display, quoting arguments
+// that need it.
+func CommandLine(command string, args []string) string {
+	parts := []string{command}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`|&;<>()*?[]{}~#") {
+			arg = Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Quote returns s as a single-quoted shell word, for arguments passed to
+// "sh -c".
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
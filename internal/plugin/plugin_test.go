@@ -0,0 +1,72 @@
+package plugin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dloss/podji/internal/config"
+	"github.com/dloss/podji/internal/resources"
+)
+
+func TestCompileResolvesKindAliases(t *testing.T) {
+	registry := resources.DefaultRegistry()
+	p, err := Compile(config.Plugin{Name: "p", Command: "true", Kinds: []string{"deploy", "Pod", "containers"}}, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod := resources.ResourceItem{Name: "api-1"}
+	for _, res := range []resources.ResourceType{
+		registry.ByName("deployments"),
+		resources.NewPods(),
+		resources.NewContainerResource(pod, resources.NewPods()),
+		resources.NewQueryResource("pods", nil, resources.NewPods()),
+	} {
+		if !p.Applies(res) {
+			t.Errorf("expected plugin to apply to %s", res.Name())
+		}
+	}
+	if p.Applies(registry.ByName("services")) {
+		t.Error("expected plugin not to apply to services")
+	}
+}
+
+func TestCompileRejectsBadTemplate(t *testing.T) {
+	_, err := Compile(config.Plugin{Name: "p", Command: "true", Kinds: []string{"*"}, Args: []string{"ok", "{{.Name"}}, resources.DefaultRegistry())
+	if err == nil || !strings.Contains(err.Error(), "plugins.p.args[1]") {
+		t.Fatalf("expected error naming the argument, got %v", err)
+	}
+}
+
+func TestArgsFromItem(t *testing.T) {
+	p, err := Compile(config.Plugin{Name: "p", Command: "true", Kinds: []string{"*"}, Args: []string{
+		"--context={{.Context}}", "{{.Namespace}}/{{.Name}}", `{{index .Labels "app"}}`, "{{.Image}}", "{{quote .Name}}",
+	}}, resources.DefaultRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := resources.ResourceItem{Name: "api", Labels: map[string]string{"app": "shop"}, Extra: map[string]string{"images": "myco/api:v2, sidecar:1"}}
+	args, err := p.Args(VarsFor(item, resources.NewWorkloads(), "prod", "default"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"--context=prod", "default/api", "shop", "myco/api:v2", "'api'"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("args = %q, want %q", args, want)
+	}
+}
+
+func TestVarsForContainer(t *testing.T) {
+	pod := resources.ResourceItem{Name: "api-1", Namespace: "shop", Labels: map[string]string{"app": "api"}}
+	vars := VarsFor(resources.ResourceItem{Name: "sidecar"}, resources.NewContainerResource(pod, resources.NewPods()), "", "default")
+	if vars.Pod != "api-1" || vars.Container != "sidecar" || vars.Namespace != "shop" || vars.Labels["app"] != "api" || vars.Resource != "containers" {
+		t.Fatalf("unexpected vars %+v", vars)
+	}
+}
+
+func TestCommandLineQuotes(t *testing.T) {
+	got := CommandLine("sh", []string{"-c", "echo it's"})
+	if want := `sh -c 'echo it'\''s'`; got != want {
+		t.Fatalf("CommandLine = %q, want %q", got, want)
+	}
+}
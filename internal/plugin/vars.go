@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"strings"
+
+	"github.com/dloss/podji/internal/resources"
+)
+
+// Vars is the data argument templates see, e.g. {{.Namespace}}/{{.Name}}.
+type Vars struct {
+	Name      string
+	Namespace string
+	Context   string
+	// Kind is the item's kind as the list shows it, such as "DEP".
+	Kind string
+	// Resource is the kind of list the item is in, such as "pods".
+	Resource string
+	// Pod is the pod an item belongs to: the item itself in pod lists, the
+	// owning pod in container lists, and empty elsewhere.
+	Pod string
+	// Container is the container name in container lists.
+	Container string
+	Labels    map[string]string
+
+	item resources.ResourceItem
+	res  resources.ResourceType
+}
+
+// VarsFor describes item of the list res. The namespace falls back to the
+// pod's and then to the current one, since some lists leave it empty.
+func VarsFor(item resources.ResourceItem, res resources.ResourceType, context, namespace string) Vars {
+	vars := Vars{
+		Name:      item.Name,
+		Namespace: item.Namespace,
+		Context:   context,
+		Kind:      item.Kind,
+		Resource:  ListKind(res),
+		Labels:    item.Labels,
+		item:      item,
+		res:       res,
+	}
+	switch r := res.(type) {
+	case *resources.ContainerResource:
+		pod := r.PodItem()
+		vars.Pod = pod.Name
+		vars.Container = item.Name
+		if vars.Namespace == "" {
+			vars.Namespace = pod.Namespace
+		}
+		if vars.Labels == nil {
+			vars.Labels = pod.Labels
+		}
+	default:
+		if vars.Resource == "pods" {
+			vars.Pod = item.Name
+		}
+	}
+	if vars.Namespace == "" || vars.Namespace == resources.AllNamespaces {
+		vars.Namespace = namespace
+	}
+	if vars.Labels == nil {
+		vars.Labels = map[string]string{}
+	}
+	return vars
+}
+
+// Images returns the container images of the item, looked up only when a
+// template asks for them.
+func (v Vars) Images() []string {
+	if image := v.item.Extra["image"]; image != "" {
+		return []string{image}
+	}
+	if images := strings.TrimSpace(v.item.Extra["images"]); images != "" {
+		var out []string
+		for _, image := range strings.Split(images, ",") {
+			if image = strings.TrimSpace(image); image != "" {
+				out = append(out, image)
+			}
+		}
+		return out
+	}
+	if v.res == nil {
+		return nil
+	}
+	var out []string
+	for _, container := range v.res.Detail(v.item).Containers {
+		if container.Type == "" && container.Image != "" {
+			out = append(out, container.Image)
+		}
+	}
+	return out
+}
+
+// Image returns the first container image, or "" when there is none.
+func (v Vars) Image() string {
+	if images := v.Images(); len(images) > 0 {
+		return images[0]
+	}
+	return ""
+}
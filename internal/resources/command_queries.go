@@ -4,6 +4,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // UnhealthyItems returns non-healthy resources across selected types.
@@ -89,24 +91,16 @@ func isUnhealthy(item ResourceItem) bool {
 	return true
 }
 
-// MatchesLabelSelector reports whether item labels satisfy key=value pairs.
+// MatchesLabelSelector reports whether item labels satisfy a Kubernetes label
+// selector such as "app=api,env!=dev", "tier in (web,api)" or "!canary". An
+// empty or malformed selector matches nothing.
 func MatchesLabelSelector(item ResourceItem, selector string) bool {
-	selector = strings.TrimSpace(selector)
-	if selector == "" {
+	if strings.TrimSpace(selector) == "" {
 		return false
 	}
-	parts := strings.Split(selector, ",")
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		kv := strings.SplitN(part, "=", 2)
-		if len(kv) != 2 {
-			return false
-		}
-		k := strings.TrimSpace(kv[0])
-		v := strings.TrimSpace(kv[1])
-		if k == "" || item.Labels[k] != v {
-			return false
-		}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return false
 	}
-	return true
+	return parsed.Matches(labels.Set(item.Labels))
 }
@@ -38,8 +38,15 @@ func TestMatchesLabelSelector(t *testing.T) {
 	if MatchesLabelSelector(item, "app=worker") {
 		t.Fatal("expected mismatched label selector to fail")
 	}
-	if MatchesLabelSelector(item, "app") {
-		t.Fatal("expected malformed selector to fail")
+	for _, selector := range []string{"env!=dev", "app in (api,web)", "env notin (dev)", "app", "!canary", "app,!canary"} {
+		if !MatchesLabelSelector(item, selector) {
+			t.Errorf("expected %q to match", selector)
+		}
+	}
+	for _, selector := range []string{"env!=prod", "app notin (api)", "canary", "!app", "app in (api", ""} {
+		if MatchesLabelSelector(item, selector) {
+			t.Errorf("expected %q not to match", selector)
+		}
 	}
 }
 
@@ -0,0 +1,193 @@
+package resources
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Content formats reported for config map keys.
+const (
+	FormatJSON       = "json"
+	FormatYAML       = "yaml"
+	FormatProperties = "properties"
+	FormatShell      = "shell"
+	FormatText       = "text"
+	FormatBinary     = "binary"
+)
+
+// ConfigMapKeys lists the keys of one config map with their size and
+// detected format, so a key can be opened on its own. Binary keys are listed
+// after the text keys and marked as such. Object-level operations delegate to
+// the config map.
+type ConfigMapKeys struct {
+	configMap ResourceItem
+	parent    ResourceType
+	data      ConfigMapData
+}
+
+func NewConfigMapKeys(configMap ResourceItem, parent ResourceType, data ConfigMapData) *ConfigMapKeys {
+	return &ConfigMapKeys{configMap: configMap, parent: parent, data: data}
+}
+
+func (c *ConfigMapKeys) Name() string { return "keys" }
+func (c *ConfigMapKeys) Key() rune    { return 0 }
+
+// ConfigMap returns the config map the keys belong to.
+func (c *ConfigMapKeys) ConfigMap() ResourceItem { return c.configMap }
+
+func (c *ConfigMapKeys) Items() []ResourceItem {
+	items := make([]ResourceItem, 0, len(c.data.Data)+len(c.data.BinaryData))
+	for _, key := range sortedKeys(c.data.Data) {
+		value := c.data.Data[key]
+		items = append(items, ResourceItem{
+			Name: key,
+			Kind: DetectFormat(key, []byte(value)),
+			Extra: map[string]string{
+				"size":   strconv.Itoa(len(value)),
+				"lines":  strconv.Itoa(strings.Count(strings.TrimRight(value, "\n"), "\n") + 1),
+				"source": "data",
+			},
+		})
+	}
+	for _, key := range sortedKeys(c.data.BinaryData) {
+		items = append(items, ResourceItem{
+			Name: key,
+			Kind: FormatBinary,
+			Extra: map[string]string{
+				"size":   strconv.Itoa(len(c.data.BinaryData[key])),
+				"lines":  "-",
+				"source": "binaryData",
+			},
+		})
+	}
+	return items
+}
+
+// Sort keeps text keys before binary keys, each alphabetical.
+func (c *ConfigMapKeys) Sort(_ []ResourceItem) {}
+
+// Value returns the content of a key and whether it came from binaryData.
+func (c *ConfigMapKeys) Value(item ResourceItem) ([]byte, bool) {
+	if item.Extra["source"] == "binaryData" {
+		return c.data.BinaryData[item.Name], true
+	}
+	return []byte(c.data.Data[item.Name]), false
+}
+
+func (c *ConfigMapKeys) Detail(item ResourceItem) DetailData { return c.parent.Detail(c.configMap) }
+func (c *ConfigMapKeys) Logs(item ResourceItem) []string     { return c.parent.Logs(c.configMap) }
+func (c *ConfigMapKeys) Events(item ResourceItem) []string   { return c.parent.Events(c.configMap) }
+func (c *ConfigMapKeys) YAML(item ResourceItem) string       { return c.parent.YAML(c.configMap) }
+func (c *ConfigMapKeys) Describe(item ResourceItem) string   { return c.parent.Describe(c.configMap) }
+
+func (c *ConfigMapKeys) TableColumns() []TableColumn {
+	return []TableColumn{
+		{ID: "name", Name: "KEY", Width: 36, Default: true},
+		{ID: "format", Name: "FORMAT", Width: 11, Default: true},
+		{ID: "size", Name: "SIZE", Width: 9, Default: true},
+		{ID: "lines", Name: "LINES", Width: 6, Default: true},
+		{ID: "source", Name: "SOURCE", Width: 11, Default: false},
+	}
+}
+
+func (c *ConfigMapKeys) TableRow(item ResourceItem) map[string]string {
+	size, _ := strconv.Atoi(item.Extra["size"])
+	return map[string]string{
+		"name":   item.Name,
+		"format": item.Kind,
+		"size":   byteSize(size),
+		"lines":  item.Extra["lines"],
+		"source": item.Extra["source"],
+	}
+}
+
+func (c *ConfigMapKeys) EmptyMessage(filtered bool, filter string) string {
+	if filtered {
+		return "No matches."
+	}
+	return "No keys."
+}
+
+// DetectFormat guesses the format of a config value from the key's file
+// extension, then from its content.
+func DetectFormat(key string, value []byte) string {
+	switch strings.ToLower(path.Ext(key)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".properties", ".env":
+		return FormatProperties
+	case ".sh", ".bash":
+		return FormatShell
+	}
+	text := strings.TrimSpace(string(value))
+	switch {
+	case text == "":
+		return FormatText
+	case strings.HasPrefix(text, "#!"):
+		return FormatShell
+	case (strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[")) && json.Valid([]byte(text)):
+		return FormatJSON
+	case looksLikeProperties(text):
+		return FormatProperties
+	case looksLikeYAML(text):
+		return FormatYAML
+	}
+	return FormatText
+}
+
+// looksLikeProperties accepts key=value lines with optional comments.
+func looksLikeProperties(text string) bool {
+	pairs := 0
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		key, _, ok := strings.Cut(line, "=")
+		if !ok || strings.ContainsAny(strings.TrimSpace(key), " \t:") {
+			return false
+		}
+		pairs++
+	}
+	return pairs > 0
+}
+
+// looksLikeYAML accepts documents that parse to a map or list; plain scalars
+// are left to text.
+func looksLikeYAML(text string) bool {
+	var parsed any
+	if err := yaml.Unmarshal([]byte(text), &parsed); err != nil {
+		return false
+	}
+	switch parsed.(type) {
+	case map[string]any, []any:
+		return true
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func byteSize(n int) string {
+	switch {
+	case n < 1024:
+		return strconv.Itoa(n) + "B"
+	case n < 1024*1024:
+		return strconv.FormatFloat(float64(n)/1024, 'f', 1, 64) + "KiB"
+	}
+	return strconv.FormatFloat(float64(n)/(1024*1024), 'f', 1, 64) + "MiB"
+}
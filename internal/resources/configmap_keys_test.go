@@ -0,0 +1,59 @@
+package resources
+
+import "testing"
+
+func TestDetectFormatUsesExtensionThenContent(t *testing.T) {
+	cases := []struct {
+		key, value, want string
+	}{
+		{"settings.json", "not json at all", FormatJSON},
+		{"values.yml", "", FormatYAML},
+		{"run.sh", "", FormatShell},
+		{"payload", `{"a": [1, 2]}`, FormatJSON},
+		{"start", "#!/bin/bash\necho hi", FormatShell},
+		{"env", "# comment\nA=1\nB.c=two", FormatProperties},
+		{"config", "server:\n  port: 8080", FormatYAML},
+		{"motd", "Welcome to the cluster", FormatText},
+		{"broken", "{not json", FormatText},
+	}
+	for _, tc := range cases {
+		if got := DetectFormat(tc.key, []byte(tc.value)); got != tc.want {
+			t.Errorf("DetectFormat(%q, %q) = %q, want %q", tc.key, tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestConfigMapKeysListsTextKeysThenBinary(t *testing.T) {
+	cms := NewConfigMaps()
+	cm := ResourceItem{Name: "nginx-config"}
+	data, err := cms.ConfigMapData(cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := NewConfigMapKeys(cm, cms, data)
+
+	items := keys.Items()
+	want := []string{"app.properties", "config.yaml", "database.yaml", "entrypoint.sh", "features.json", "favicon.ico"}
+	if len(items) != len(want) {
+		t.Fatalf("expected %d keys, got %#v", len(want), items)
+	}
+	for i, name := range want {
+		if items[i].Name != name {
+			t.Fatalf("key %d: expected %s, got %s", i, name, items[i].Name)
+		}
+	}
+
+	last := items[len(items)-1]
+	if row := keys.TableRow(last); row["format"] != FormatBinary || row["source"] != "binaryData" || row["size"] != "62B" {
+		t.Fatalf("unexpected binary row: %#v", row)
+	}
+	if value, binary := keys.Value(last); !binary || len(value) != 62 {
+		t.Fatalf("expected 62 binary bytes, got %d (binary=%v)", len(value), binary)
+	}
+	if row := keys.TableRow(items[0]); row["format"] != FormatProperties || row["lines"] != "4" {
+		t.Fatalf("unexpected properties row: %#v", row)
+	}
+	if keys.YAML(items[0]) != cms.YAML(cm) {
+		t.Fatal("expected YAML to delegate to the config map")
+	}
+}
@@ -3,6 +3,8 @@ package resources
 import (
 	"fmt"
 	"strings"
+
+	"sigs.k8s.io/yaml"
 )
 
 type ConfigMaps struct {
@@ -23,7 +25,7 @@ func (c *ConfigMaps) TableColumns() []TableColumn {
 }
 
 func (c *ConfigMaps) TableRow(item ResourceItem) map[string]string {
-	dataCount := 3
+	dataCount := 5
 	switch item.Name {
 	case "coredns":
 		dataCount = 1
@@ -109,7 +111,7 @@ func (c *ConfigMaps) Detail(item ResourceItem) DetailData {
 	return DetailData{
 		Summary: []SummaryField{
 			{Key: "status", Label: "Status", Value: "Healthy"},
-			{Key: "data-keys", Label: "Data keys", Value: "3"},
+			{Key: "data-keys", Label: "Data keys", Value: "5"},
 			{Key: "age", Label: "Age", Value: item.Age},
 		},
 		Events: []string{
@@ -161,6 +163,12 @@ func (c *ConfigMaps) Describe(item ResourceItem) string {
 }
 
 func (c *ConfigMaps) YAML(item ResourceItem) string {
+	binaryData := ""
+	if item.Name == "nginx-config" {
+		binaryData = `
+binaryData:
+  favicon.ico: AAABAAEAEBAAAAEAIABoBAAAFgAAACgAAAAQAAAAIAAAAAEAIAAAAAAAAAQAABMLAAATCwAAAAAAAAAAAAA=`
+	}
 	return strings.TrimSpace(`apiVersion: v1
 kind: ConfigMap
 metadata:
@@ -193,5 +201,28 @@ data:
       "enableNewUI": true,
       "enableBetaAPI": false,
       "maintenanceMode": false
-    }`)
+    }
+  app.properties: |
+    # Overrides for the ` + item.Name + ` service
+    http.port=8080
+    cache.ttl.seconds=300
+    upstream.url=http://backend.` + c.Namespace() + `.svc:8080
+  entrypoint.sh: |
+    #!/bin/sh
+    set -eu
+    export CONFIG_DIR=/etc/` + item.Name + `
+    exec /app/server --config "$CONFIG_DIR/config.yaml"` + binaryData)
+}
+
+// ConfigMapData reads the keys back out of the mock YAML, so the key browser
+// and the YAML view always agree.
+func (c *ConfigMaps) ConfigMapData(item ResourceItem) (ConfigMapData, error) {
+	var obj struct {
+		Data       map[string]string `json:"data"`
+		BinaryData map[string][]byte `json:"binaryData"`
+	}
+	if err := yaml.Unmarshal([]byte(c.YAML(item)), &obj); err != nil {
+		return ConfigMapData{}, err
+	}
+	return ConfigMapData{Data: obj.Data, BinaryData: obj.BinaryData}, nil
 }
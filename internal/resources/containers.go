@@ -2,6 +2,8 @@ package resources
 
 // ContainerResource wraps a pod's containers as a ResourceType + TableResource,
 // so that the container picker can reuse listview.View for table rendering.
+// Init and ephemeral containers are listed alongside app containers, labelled
+// by type, so their logs are reachable from the picker as well.
 type ContainerResource struct {
 	podItem    ResourceItem
 	parentRes  ResourceType
@@ -19,6 +21,12 @@ func NewContainerResource(podItem ResourceItem, parent ResourceType) *ContainerR
 func (c *ContainerResource) PodItem() ResourceItem        { return c.podItem }
 func (c *ContainerResource) ParentResource() ResourceType { return c.parentRes }
 
+// Reload re-reads the pod's containers, e.g. after an ephemeral container
+// was added.
+func (c *ContainerResource) Reload() {
+	c.containers = c.parentRes.Detail(c.podItem).Containers
+}
+
 func (c *ContainerResource) Name() string { return "containers" }
 func (c *ContainerResource) Key() rune    { return 0 }
 
@@ -27,8 +35,14 @@ func (c *ContainerResource) Items() []ResourceItem {
 	for _, cr := range c.containers {
 		items = append(items, ResourceItem{
 			Name:     cr.Name,
+			Kind:     ContainerTypeLabel(cr.Type),
 			Status:   cr.State,
 			Restarts: cr.Restarts,
+			Extra: map[string]string{
+				"image":  cr.Image,
+				"exit":   cr.ExitCode,
+				"reason": cr.Reason,
+			},
 		})
 	}
 	return items
@@ -58,18 +72,37 @@ func (c *ContainerResource) Describe(item ResourceItem) string {
 
 func (c *ContainerResource) TableColumns() []TableColumn {
 	return []TableColumn{
-		{Name: "NAME", Width: 16},
-		{Name: "STATUS", Width: 16},
-		{Name: "RESTARTS", Width: 9},
-		{Name: "IMAGE", Width: 57},
+		{ID: "name", Name: "NAME", Width: 20, Default: true},
+		{ID: "type", Name: "TYPE", Width: 9, Default: true},
+		{ID: "status", Name: "STATUS", Width: 16, Default: true},
+		{ID: "restarts", Name: "RESTARTS", Width: 9, Default: true},
+		{ID: "exit", Name: "EXIT", Width: 5, Default: true},
+		{ID: "reason", Name: "REASON", Width: 18, Default: false},
+		{ID: "image", Name: "IMAGE", Width: 40, Default: true},
 	}
 }
 
-func (c *ContainerResource) TableRow(item ResourceItem) []string {
+func (c *ContainerResource) TableRow(item ResourceItem) map[string]string {
 	for _, cr := range c.containers {
 		if cr.Name == item.Name {
-			return []string{cr.Name, cr.State, cr.Restarts, cr.Image}
+			return map[string]string{
+				"name":     cr.Name,
+				"type":     ContainerTypeLabel(cr.Type),
+				"status":   cr.State,
+				"restarts": cr.Restarts,
+				"exit":     cr.ExitCode,
+				"reason":   cr.Reason,
+				"image":    cr.Image,
+			}
 		}
 	}
-	return []string{item.Name, item.Status, item.Restarts, ""}
+	return map[string]string{
+		"name":     item.Name,
+		"type":     item.Kind,
+		"status":   item.Status,
+		"restarts": item.Restarts,
+		"exit":     item.Extra["exit"],
+		"reason":   item.Extra["reason"],
+		"image":    item.Extra["image"],
+	}
 }
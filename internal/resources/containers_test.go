@@ -0,0 +1,21 @@
+package resources
+
+import "testing"
+
+func TestContainerResourceListsInitContainersWithType(t *testing.T) {
+	pods := NewPods()
+	pod := pods.Items()[0]
+	res := NewContainerResource(pod, pods)
+
+	items := res.Items()
+	if len(items) == 0 || items[0].Name != "migrate" || items[0].Kind != ContainerTypeInit {
+		t.Fatalf("expected init container listed first, got %#v", items)
+	}
+	row := res.TableRow(items[0])
+	if row["type"] != "init" || row["exit"] != "0" || row["status"] != "Terminated" {
+		t.Fatalf("unexpected init row: %#v", row)
+	}
+	if row := res.TableRow(items[1]); row["type"] != "app" {
+		t.Fatalf("expected app container type label, got %#v", row)
+	}
+}
@@ -0,0 +1,210 @@
+package resources
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CronJobJobs lists the Jobs a CronJob has created, newest first, with the
+// duration of each run. Live CronJobs match Jobs by their controller
+// reference, listed through the resource the CronJob came from; the
+// CronJob's schedule and last successful run are shown as the list banner.
+type CronJobJobs struct {
+	namespaceScope
+	cronJob ResourceItem
+	source  ResourceLister
+}
+
+// NewJobsForCronJob lists the runs of cronJob, an item of source. Live Jobs
+// are read through source when it is a ResourceLister.
+func NewJobsForCronJob(cronJob ResourceItem, source ResourceType, registry *Registry) *CronJobJobs {
+	c := &CronJobJobs{namespaceScope: newNamespaceScope(), cronJob: cronJob}
+	c.source, _ = source.(ResourceLister)
+	if registry != nil {
+		c.SetNamespace(registry.Namespace())
+	}
+	return c
+}
+
+func (c *CronJobJobs) Name() string { return "jobs (" + c.cronJob.Name + ")" }
+func (c *CronJobJobs) Key() rune    { return 0 }
+
+// CronJob returns the CronJob whose runs are listed.
+func (c *CronJobJobs) CronJob() ResourceItem { return c.cronJob }
+
+func (c *CronJobJobs) Items() []ResourceItem {
+	var items []ResourceItem
+	if strings.TrimSpace(c.cronJob.UID) != "" {
+		if workloads, err := c.ListResource("workloads"); err == nil {
+			for _, item := range workloads {
+				if item.Kind == "JOB" && item.Extra["controlled-by-uid"] == c.cronJob.UID {
+					items = append(items, item)
+				}
+			}
+		}
+	} else {
+		items = mockCronJobRuns(c.cronJob)
+	}
+	c.Sort(items)
+	return items
+}
+
+// ListResource lists through the CronJob's source, so the pods of a run are
+// read from the same data source as the runs.
+func (c *CronJobJobs) ListResource(resourceName string) ([]ResourceItem, error) {
+	if c.source == nil {
+		return nil, errors.New("list resource unavailable")
+	}
+	return c.source.ListResource(resourceName)
+}
+
+// Sort orders runs newest first by creation time, then by name.
+func (c *CronJobJobs) Sort(items []ResourceItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ci, _ := strconv.ParseInt(items[i].Extra["created"], 10, 64)
+		cj, _ := strconv.ParseInt(items[j].Extra["created"], 10, 64)
+		if ci != cj {
+			return ci > cj
+		}
+		return items[i].Name > items[j].Name
+	})
+}
+
+func (c *CronJobJobs) Banner() string {
+	schedule := c.cronJob.Extra["schedule"]
+	lastSuccess := c.cronJob.Extra["last-success"]
+	if c.cronJob.UID == "" && schedule == "" {
+		schedule, lastSuccess = mockCronJobSchedule(c.cronJob.Name)
+	}
+	if schedule == "" {
+		return ""
+	}
+	banner := "schedule: " + schedule + "    last successful run: " + valueOrDash(lastSuccess)
+	if c.cronJob.Status == "Suspended" {
+		banner += "    suspended"
+	}
+	return banner
+}
+
+func (c *CronJobJobs) TableColumns() []TableColumn {
+	return namespacedColumnsFor(c.Namespace(), []TableColumn{
+		{ID: "name", Name: "NAME", Width: 40, Default: true},
+		{ID: "status", Name: "STATUS", Width: 12, Default: true},
+		{ID: "completions", Name: "COMPLETIONS", Width: 11, Default: true},
+		{ID: "duration", Name: "DURATION", Width: 9, Default: true},
+		{ID: "age", Name: "AGE", Width: 6, Default: true},
+	})
+}
+
+func (c *CronJobJobs) TableRow(item ResourceItem) map[string]string {
+	return map[string]string{
+		"namespace":   item.Namespace,
+		"name":        item.Name,
+		"status":      item.Status,
+		"completions": item.Ready,
+		"duration":    valueOrDash(item.Extra["duration"]),
+		"age":         item.Age,
+	}
+}
+
+func (c *CronJobJobs) EmptyMessage(filtered bool, filter string) string {
+	if filtered {
+		return "No jobs match `" + filter + "`."
+	}
+	return "No jobs have run for CronJob `" + c.cronJob.Name + "` yet."
+}
+
+func (c *CronJobJobs) Detail(item ResourceItem) DetailData {
+	return DetailData{
+		Summary: []SummaryField{
+			{Key: "status", Label: "Status", Value: item.Status},
+			{Key: "completions", Label: "Completions", Value: item.Ready},
+			{Key: "duration", Label: "Duration", Value: valueOrDash(item.Extra["duration"])},
+			{Key: "cronjob", Label: "CronJob", Value: c.cronJob.Name},
+		},
+	}
+}
+
+func (c *CronJobJobs) Logs(item ResourceItem) []string {
+	return []string{"Open the job's pods for logs."}
+}
+
+func (c *CronJobJobs) Events(item ResourceItem) []string {
+	return []string{"—   No recent events"}
+}
+
+func (c *CronJobJobs) YAML(item ResourceItem) string {
+	return strings.TrimSpace(`apiVersion: batch/v1
+kind: Job
+metadata:
+  name: ` + item.Name + `
+  ownerReferences:
+  - apiVersion: batch/v1
+    kind: CronJob
+    name: ` + c.cronJob.Name + `
+    controller: true`)
+}
+
+func (c *CronJobJobs) Describe(item ResourceItem) string {
+	return "Name:        " + item.Name + "\nControlled By:  CronJob/" + c.cronJob.Name + "\nStatus:      " + item.Status
+}
+
+func mockCronJobSchedule(name string) (schedule, lastSuccess string) {
+	switch name {
+	case "nightly-backup":
+		return "0 2 * * *", "6h ago"
+	case "sync-reports":
+		return "*/30 * * * *", "—"
+	case "cleanup-tmp":
+		return "*/15 * * * *", "37m ago"
+	case "old-data-prune":
+		return "0 4 * * 0", "3d ago"
+	}
+	return "*/15 * * * *", "14m ago"
+}
+
+// mockCronJobRuns returns a short run history; the created values are
+// relative so newer runs sort first.
+func mockCronJobRuns(cronJob ResourceItem) []ResourceItem {
+	run := func(suffix, status, ready, duration, age string, created int) ResourceItem {
+		return ResourceItem{
+			Name:      cronJob.Name + "-" + suffix,
+			Namespace: cronJob.Namespace,
+			Kind:      "JOB",
+			Status:    status,
+			Ready:     ready,
+			Restarts:  "0",
+			Age:       age,
+			Extra:     map[string]string{"duration": duration, "created": strconv.Itoa(created)},
+		}
+	}
+	switch cronJob.Name {
+	case "sync-reports":
+		return nil
+	case "nightly-backup":
+		return []ResourceItem{
+			run("289173", "Progressing", "0/1", "2m4s", "2m", 3),
+			run("289172", "Healthy", "1/1", "4m12s", "6h", 2),
+			run("289171", "Healthy", "1/1", "3m58s", "30h", 1),
+		}
+	case "cleanup-tmp":
+		return []ResourceItem{
+			run("99211", "Failed", "0/1", "1m2s", "22m", 3),
+			run("99196", "Failed", "0/1", "58s", "37m", 2),
+			run("99181", "Healthy", "1/1", "41s", "52m", 1),
+		}
+	}
+	return []ResourceItem{
+		run("99211", "Healthy", "1/1", "12s", "8m", 2),
+		run("99196", "Healthy", "1/1", "15s", "23m", 1),
+	}
+}
+
+func valueOrDash(v string) string {
+	if strings.TrimSpace(v) == "" {
+		return "-"
+	}
+	return v
+}
@@ -359,3 +359,15 @@ status:
     reason: NewReplicaSetAvailable
     message: ReplicaSet "` + item.Name + `-7d8f9c" has successfully progressed.`)
 }
+
+// HelmManifest returns the chart's rendering of the mock deployment. It asks
+// for three replicas while the live object runs two, so the drift view has
+// something to show.
+func (d *Deployments) HelmManifest(namespace, release string) (string, error) {
+	live := d.YAML(ResourceItem{Name: release})
+	if idx := strings.Index(live, "\nstatus:"); idx >= 0 {
+		live = live[:idx]
+	}
+	manifest := strings.Replace(live, "  replicas: 2\n", "  replicas: 3\n", 1)
+	return "---\n# Source: " + release + "/templates/deployment.yaml\n" + manifest + "\n", nil
+}
@@ -0,0 +1,17 @@
+package resources
+
+import "strings"
+
+const logBoundaryMark = "────"
+
+// LogBoundary returns a separator line that a follow stream inserts where it
+// lost and regained the log source, naming the cause (restart, reschedule,
+// connection loss).
+func LogBoundary(cause string) string {
+	return logBoundaryMark + " " + strings.TrimSpace(cause) + " " + logBoundaryMark
+}
+
+// IsLogBoundary reports whether line was produced by LogBoundary.
+func IsLogBoundary(line string) bool {
+	return strings.HasPrefix(line, logBoundaryMark+" ") && strings.HasSuffix(line, " "+logBoundaryMark)
+}
@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LogHit is one log line matching a cross-pod grep. Line indexes the line
+// within the tail that was searched; Container is empty for a pod read with
+// its default container.
+type LogHit struct {
+	Pod       ResourceItem
+	Container string
+	Line      int
+	Text      string
+}
+
+// LogGrepResource lists grep hits grouped by pod so the list view can show
+// them and open the pod's logs at the selected line. Pod-level operations
+// delegate to the pods resource the hits came from.
+type LogGrepResource struct {
+	pattern string
+	hits    []LogHit
+	pods    ResourceType
+}
+
+func NewLogGrepResource(pattern string, hits []LogHit, pods ResourceType) *LogGrepResource {
+	copyHits := make([]LogHit, len(hits))
+	copy(copyHits, hits)
+	return &LogGrepResource{pattern: pattern, hits: copyHits, pods: pods}
+}
+
+func (g *LogGrepResource) Name() string { return "grep" }
+func (g *LogGrepResource) Key() rune    { return 0 }
+
+// Pattern returns the search pattern the hits were collected for.
+func (g *LogGrepResource) Pattern() string { return g.pattern }
+
+// Pods returns the resource to open a hit's logs with.
+func (g *LogGrepResource) Pods() ResourceType { return g.pods }
+
+func (g *LogGrepResource) Items() []ResourceItem {
+	items := make([]ResourceItem, 0, len(g.hits))
+	for i, hit := range g.hits {
+		items = append(items, ResourceItem{
+			Name:      hit.Pod.Name,
+			Namespace: hit.Pod.Namespace,
+			Status:    hit.Pod.Status,
+			Extra: map[string]string{
+				"hit":       strconv.Itoa(i),
+				"container": hit.Container,
+				"line":      strconv.Itoa(hit.Line + 1),
+				"match":     strings.TrimSpace(hit.Text),
+			},
+		})
+	}
+	return items
+}
+
+// Sort keeps the hits in pod, container, then line order.
+func (g *LogGrepResource) Sort(_ []ResourceItem) {}
+
+// Hit returns the grep hit an item was built from.
+func (g *LogGrepResource) Hit(item ResourceItem) (LogHit, bool) {
+	idx, err := strconv.Atoi(item.Extra["hit"])
+	if err != nil || idx < 0 || idx >= len(g.hits) {
+		return LogHit{}, false
+	}
+	return g.hits[idx], true
+}
+
+func (g *LogGrepResource) pod(item ResourceItem) ResourceItem {
+	if hit, ok := g.Hit(item); ok {
+		return hit.Pod
+	}
+	return item
+}
+
+func (g *LogGrepResource) Detail(item ResourceItem) DetailData { return g.pods.Detail(g.pod(item)) }
+func (g *LogGrepResource) Logs(item ResourceItem) []string     { return g.pods.Logs(g.pod(item)) }
+func (g *LogGrepResource) Events(item ResourceItem) []string   { return g.pods.Events(g.pod(item)) }
+func (g *LogGrepResource) YAML(item ResourceItem) string       { return g.pods.YAML(g.pod(item)) }
+func (g *LogGrepResource) Describe(item ResourceItem) string   { return g.pods.Describe(g.pod(item)) }
+
+func (g *LogGrepResource) TableColumns() []TableColumn {
+	return []TableColumn{
+		{ID: "name", Name: "POD", Width: 32, Default: true},
+		{ID: "container", Name: "CONTAINER", Width: 16, Default: true},
+		{ID: "line", Name: "LINE", Width: 5, Default: true},
+		{ID: "match", Name: "MATCH", Width: 80, Default: true},
+	}
+}
+
+func (g *LogGrepResource) TableRow(item ResourceItem) map[string]string {
+	return map[string]string{
+		"name":      item.Name,
+		"container": item.Extra["container"],
+		"line":      item.Extra["line"],
+		"match":     item.Extra["match"],
+	}
+}
+
+func (g *LogGrepResource) EmptyMessage(filtered bool, filter string) string {
+	if filtered {
+		return "No matches."
+	}
+	return "No log lines match " + strconv.Quote(g.pattern) + "."
+}
@@ -2,6 +2,7 @@ package resources
 
 import (
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -18,6 +19,10 @@ func (n *Nodes) TableColumns() []TableColumn {
 		{ID: "version", Name: "VERSION", Width: 12, Default: true},
 		{ID: "internal-ip", Name: "INTERNAL-IP", Width: 14, Default: false},
 		{ID: "age", Name: "AGE", Width: 6, Default: true},
+		{ID: "cpu-req", Name: "CPU-REQ", Width: 8, Default: false},
+		{ID: "cpu-lim", Name: "CPU-LIM", Width: 8, Default: false},
+		{ID: "mem-req", Name: "MEM-REQ", Width: 8, Default: false},
+		{ID: "mem-lim", Name: "MEM-LIM", Width: 8, Default: false},
 	}
 }
 
@@ -36,6 +41,10 @@ func (n *Nodes) TableRow(item ResourceItem) map[string]string {
 		"version":     "v1.29.2",
 		"internal-ip": item.Extra["internal-ip"],
 		"age":         item.Age,
+		"cpu-req":     item.Extra["cpu-req"],
+		"cpu-lim":     item.Extra["cpu-lim"],
+		"mem-req":     item.Extra["mem-req"],
+		"mem-lim":     item.Extra["mem-lim"],
 	}
 }
 
@@ -54,6 +63,10 @@ func (n *Nodes) TableColumnsWide() []TableColumn {
 		{ID: "kernel-version", Name: "KERNEL-VERSION", Width: 22, Default: false},
 		{ID: "runtime", Name: "CONTAINER-RUNTIME", Width: 22, Default: false},
 		{ID: "taints", Name: "TAINTS", Width: 6, Default: false},
+		{ID: "cpu-req", Name: "CPU-REQ", Width: 8, Default: false},
+		{ID: "cpu-lim", Name: "CPU-LIM", Width: 8, Default: false},
+		{ID: "mem-req", Name: "MEM-REQ", Width: 8, Default: false},
+		{ID: "mem-lim", Name: "MEM-LIM", Width: 8, Default: false},
 	}
 }
 
@@ -70,6 +83,36 @@ func (n *Nodes) TableRowWide(item ResourceItem) map[string]string {
 	return row
 }
 
+// mockNodeAllocation returns cpu and memory allocation for a mock node.
+func mockNodeAllocation(name string) []AllocationRow {
+	switch name {
+	case "worker-01":
+		return []AllocationRow{
+			{Resource: "cpu", Requests: "3450m", Limits: "6", Allocatable: "3920m", RequestsPercent: 88, LimitsPercent: 153},
+			{Resource: "memory", Requests: "9Gi", Limits: "14Gi", Allocatable: "15Gi", RequestsPercent: 60, LimitsPercent: 93},
+		}
+	case "worker-02":
+		return []AllocationRow{
+			{Resource: "cpu", Requests: "1800m", Limits: "3500m", Allocatable: "3920m", RequestsPercent: 45, LimitsPercent: 89},
+			{Resource: "memory", Requests: "6Gi", Limits: "10Gi", Allocatable: "15Gi", RequestsPercent: 40, LimitsPercent: 66},
+		}
+	case "worker-03":
+		return []AllocationRow{
+			{Resource: "cpu", Requests: "3800m", Limits: "7", Allocatable: "3920m", RequestsPercent: 96, LimitsPercent: 178},
+			{Resource: "memory", Requests: "13Gi", Limits: "18Gi", Allocatable: "15Gi", RequestsPercent: 86, LimitsPercent: 120},
+		}
+	case "worker-04":
+		return []AllocationRow{
+			{Resource: "cpu", Requests: "0", Limits: "0", Allocatable: "3920m"},
+			{Resource: "memory", Requests: "0", Limits: "0", Allocatable: "15Gi"},
+		}
+	}
+	return []AllocationRow{
+		{Resource: "cpu", Requests: "950m", Limits: "1", Allocatable: "1930m", RequestsPercent: 49, LimitsPercent: 51},
+		{Resource: "memory", Requests: "1200Mi", Limits: "2Gi", Allocatable: "7Gi", RequestsPercent: 16, LimitsPercent: 28},
+	}
+}
+
 func NewNodes() *Nodes {
 	return &Nodes{sortMode: "name"}
 }
@@ -86,6 +129,13 @@ func (n *Nodes) Items() []ResourceItem {
 		{Name: "control-plane-01", Status: "Ready", Ready: "12/110", Age: "180d", Extra: map[string]string{"os": "linux", "arch": "amd64", "kernel-version": "5.15.0-76-generic", "internal-ip": "10.0.0.1", "instance-type": "m5.large", "zone": "us-east-1a", "runtime": "containerd://1.7.11", "taints": "1"}},
 		{Name: "control-plane-02", Status: "Ready", Ready: "11/110", Age: "180d", Extra: map[string]string{"os": "linux", "arch": "amd64", "kernel-version": "5.15.0-76-generic", "internal-ip": "10.0.0.2", "instance-type": "m5.large", "zone": "us-east-1b", "runtime": "containerd://1.7.11", "taints": "1"}},
 	}
+	for _, item := range items {
+		alloc := mockNodeAllocation(item.Name)
+		item.Extra["cpu-req"] = strconv.Itoa(alloc[0].RequestsPercent) + "%"
+		item.Extra["cpu-lim"] = strconv.Itoa(alloc[0].LimitsPercent) + "%"
+		item.Extra["mem-req"] = strconv.Itoa(alloc[1].RequestsPercent) + "%"
+		item.Extra["mem-lim"] = strconv.Itoa(alloc[1].LimitsPercent) + "%"
+	}
 	items = expandMockItems(items, 20)
 	n.Sort(items)
 	return items
@@ -174,6 +224,7 @@ func (n *Nodes) Detail(item ResourceItem) DetailData {
 		},
 		Conditions: conditions,
 		Events:     events,
+		Allocation: mockNodeAllocation(item.Name),
 		Labels: []string{
 			"kubernetes.io/hostname=" + item.Name,
 			"node.kubernetes.io/instance-type=m5.xlarge",
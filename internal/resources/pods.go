@@ -2,6 +2,7 @@ package resources
 
 import (
 	"context"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -12,6 +13,7 @@ type Pods struct {
 	sortDesc         bool
 	liveLogsFetcher  func(namespace, pod string, opts LogOptions) ([]string, error)
 	liveEventFetcher func(namespace, pod string) ([]string, error)
+	liveLogStreamer  func(ctx context.Context, item ResourceItem, opts LogOptions, onLine func(string)) error
 }
 
 func NewPods() *Pods {
@@ -157,6 +159,12 @@ func (p *Pods) SetLiveFetchers(
 	p.liveEventFetcher = events
 }
 
+// SetLiveLogStreamer routes follow-mode streams to a live backend, so pod
+// lists that are not read-backed (workload pods, query results) still follow.
+func (p *Pods) SetLiveLogStreamer(stream func(ctx context.Context, item ResourceItem, opts LogOptions, onLine func(string)) error) {
+	p.liveLogStreamer = stream
+}
+
 func (p *Pods) Detail(item ResourceItem) DetailData {
 	status := item.Status
 	if status == "" {
@@ -171,6 +179,7 @@ func (p *Pods) Detail(item ResourceItem) DetailData {
 			{Key: "qos", Label: "QoS", Value: "Burstable"},
 		},
 		Containers: []ContainerRow{
+			{Name: "migrate", Image: "myco/api-migrate:v2.3.1", State: "Terminated", Restarts: "0", Reason: "Completed", Type: ContainerTypeInit, ExitCode: "0"},
 			{Name: "api", Image: "myco/api:v2.3.1", State: "Running", Restarts: "0", Reason: ""},
 			{Name: "sidecar", Image: "envoy:1.28", State: "CrashLoopBackOff", Restarts: "5", Reason: "OOMKilled (10m ago)"},
 		},
@@ -209,9 +218,29 @@ func (p *Pods) LogsWithOptions(_ context.Context, item ResourceItem, opts LogOpt
 			return lines, nil
 		}
 	}
+	if opts.Container == "migrate" {
+		return podMockInitLogs(opts.Timestamps), nil
+	}
 	return expandMockLogs(podMockLogs(opts.Timestamps), 120), nil
 }
 
+func podMockInitLogs(timestamps bool) []string {
+	lines := []string{
+		"Connecting to postgres.default.svc.cluster.local:5432",
+		"Applying migration 0041_add_orders_index",
+		"Applying migration 0042_drop_legacy_sessions",
+		"Migrations complete (2 applied)",
+	}
+	if !timestamps {
+		return lines
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = "2025-06-15T12:02:5" + strconv.Itoa(i) + "Z  " + line
+	}
+	return out
+}
+
 func podMockLogs(timestamps bool) []string {
 	if timestamps {
 		return []string{
@@ -234,6 +263,12 @@ func podMockLogs(timestamps bool) []string {
 }
 
 func (p *Pods) LogsStream(ctx context.Context, item ResourceItem, opts LogOptions, onLine func(string)) error {
+	if opts.Follow && p.liveLogStreamer != nil {
+		if item.Namespace == "" {
+			item.Namespace = p.Namespace()
+		}
+		return p.liveLogStreamer(ctx, item, opts, onLine)
+	}
 	if opts.Follow && p.liveLogsFetcher == nil {
 		// In mock mode, follow should feel like a live tail. The log view already
 		// loads the current snapshot, so streaming only emits incremental lines.
@@ -87,6 +87,41 @@ func (r *Registry) ByName(name string) ResourceType {
 	return nil
 }
 
+// kindAliases maps the short kinds accepted by the command bar and the CLI to
+// registry names.
+var kindAliases = map[string]string{
+	"po":     "pods",
+	"deploy": "deployments",
+	"svc":    "services",
+	"cm":     "configmaps",
+	"sec":    "secrets",
+	"ing":    "ingresses",
+	"pvc":    "persistentvolumeclaims",
+	"pvcs":   "persistentvolumeclaims",
+	"ev":     "events",
+	"ns":     "namespaces",
+	"no":     "nodes",
+	"ctx":    "contexts",
+}
+
+// Lookup returns the resource for a registry name, its singular form or a
+// short alias such as "po" or "deploy", or nil.
+func (r *Registry) Lookup(token string) ResourceType {
+	token = strings.ToLower(strings.TrimSpace(token))
+	if name, ok := kindAliases[token]; ok {
+		token = name
+	}
+	if res := r.ByName(token); res != nil {
+		return res
+	}
+	for _, res := range r.resources {
+		if SingularName(res.Name()) == token {
+			return res
+		}
+	}
+	return nil
+}
+
 func defaultSort(items []ResourceItem) {
 	nameSort(items, false)
 }
@@ -0,0 +1,23 @@
+package resources
+
+import "testing"
+
+func TestRegistryLookupAcceptsAliasesAndSingulars(t *testing.T) {
+	reg := DefaultRegistry()
+	for token, want := range map[string]string{
+		"po":         "pods",
+		"pod":        "pods",
+		"Deploy":     "deployments",
+		"pvc":        "persistentvolumeclaims",
+		"workload":   "workloads",
+		"namespaces": "namespaces",
+	} {
+		res := reg.Lookup(token)
+		if res == nil || res.Name() != want {
+			t.Fatalf("Lookup(%q) = %v, want %s", token, res, want)
+		}
+	}
+	if res := reg.Lookup("widgets"); res != nil {
+		t.Fatalf("expected nil for unknown kind, got %s", res.Name())
+	}
+}
@@ -605,15 +605,6 @@ func NewRelatedStorage(workload string) ResourceType {
 	}
 }
 
-func NewJobsForCronJob(name string) ResourceType {
-	return &relatedResource{
-		namespaceScope: newNamespaceScope(),
-		name:           "jobs (" + name + ")",
-		items:          []ResourceItem{{Name: name + "-289173", Status: "Healthy", Ready: "1/1", Restarts: "-", Age: "6h"}, {Name: name + "-289172", Status: "Healthy", Ready: "1/1", Restarts: "-", Age: "30h"}},
-		empty:          "No jobs found for this CronJob.",
-	}
-}
-
 func NewPodOwner(pod string) ResourceType {
 	// Derive workload name by stripping the pod hash suffix.
 	workload := pod
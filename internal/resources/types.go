@@ -1,6 +1,10 @@
 package resources
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
 type ResourceItem struct {
 	UID        string
@@ -38,6 +42,19 @@ type DetailData struct {
 	Conditions []string
 	Events     []string
 	Labels     []string
+	Allocation []AllocationRow
+}
+
+// AllocationRow compares the summed requests and limits of the pods on a node
+// with what the node can allocate, for one resource such as cpu or memory.
+// Limits may exceed 100 percent on overcommitted nodes.
+type AllocationRow struct {
+	Resource        string
+	Requests        string
+	Limits          string
+	Allocatable     string
+	RequestsPercent int
+	LimitsPercent   int
 }
 
 type SummaryTone string
@@ -63,6 +80,23 @@ type ContainerRow struct {
 	State    string
 	Restarts string
 	Reason   string
+	Type     string // "" for app containers, ContainerTypeInit or ContainerTypeEphemeral
+	ExitCode string // last termination exit code, if any
+}
+
+// Container types for ContainerRow.Type. Regular app containers leave Type
+// empty.
+const (
+	ContainerTypeInit      = "init"
+	ContainerTypeEphemeral = "ephemeral"
+)
+
+// ContainerTypeLabel returns the display label for a container type.
+func ContainerTypeLabel(containerType string) string {
+	if containerType == "" {
+		return "app"
+	}
+	return containerType
 }
 
 type ResourceType interface {
@@ -83,6 +117,12 @@ type LogOptions struct {
 	Previous   bool
 	Container  string
 	Timestamps bool
+	// SinceTime, when set, resumes a log read from that instant instead of
+	// taking the last Tail lines.
+	SinceTime time.Time
+	// FollowOwner lets a follow stream switch to a replacement pod from the
+	// same owner when the followed pod goes away.
+	FollowOwner bool
 }
 
 type EventOptions struct {
@@ -107,6 +147,120 @@ type EventOptionsReader interface {
 	EventsWithOptions(ctx context.Context, item ResourceItem, opts EventOptions) ([]string, error)
 }
 
+// ResourceLister is an optional extension for resources backed by a data
+// source that can list other resources in the same scope, used to find
+// related items such as the Jobs of a CronJob.
+type ResourceLister interface {
+	ListResource(resourceName string) ([]ResourceItem, error)
+}
+
+// HelmManifestReader is an optional extension for resources that can fetch
+// the rendered manifest of the deployed revision of a Helm release, used as
+// the desired state when showing drift.
+type HelmManifestReader interface {
+	HelmManifest(namespace, release string) (string, error)
+}
+
+// ObjectApplier is an optional extension for resources that can write an
+// edited object back. The returned YAML is the resulting object.
+type ObjectApplier interface {
+	ApplyYAML(ctx context.Context, item ResourceItem, manifest string, opts ApplyOptions) (string, error)
+}
+
+// ApplyOptions control the apply of an edited object.
+type ApplyOptions struct {
+	// DryRun has the server validate and merge the change without
+	// persisting it.
+	DryRun bool
+	// Force takes over fields owned by other field managers instead of
+	// failing with ErrApplyConflict.
+	Force bool
+}
+
+// ErrApplyConflict reports an apply that would change fields owned by other
+// field managers. Applying again with Force takes them over.
+var ErrApplyConflict = errors.New("fields are owned by another manager")
+
+// ConfigMapData is the content of a config map: text values in Data and
+// binary values in BinaryData, as in the API object.
+type ConfigMapData struct {
+	Data       map[string]string
+	BinaryData map[string][]byte
+}
+
+// ConfigMapDataReader is an optional extension for resources that can read
+// the keys of a config map.
+type ConfigMapDataReader interface {
+	ConfigMapData(item ResourceItem) (ConfigMapData, error)
+}
+
+// SecretData is the decoded content of a secret. Protected is set when the
+// secret was read from a context where values must not be shown.
+type SecretData struct {
+	Type      string
+	Data      map[string][]byte
+	Protected bool
+}
+
+// SecretDataReader is an optional extension for resources that can read the
+// decoded values of a secret.
+type SecretDataReader interface {
+	SecretData(item ResourceItem) (SecretData, error)
+}
+
+// DrainOptions control a node drain.
+type DrainOptions struct {
+	// DeleteEmptyDirData allows evicting pods whose emptyDir volumes are lost
+	// with them. Without it such pods block the drain.
+	DeleteEmptyDirData bool
+}
+
+// Pod states reported while draining a node.
+const (
+	DrainPending     = "pending"
+	DrainSkipped     = "skipped"
+	DrainBlocked     = "blocked"
+	DrainEvicting    = "evicting"
+	DrainRetrying    = "retrying"
+	DrainTerminating = "terminating"
+	DrainEvicted     = "evicted"
+	DrainFailed      = "failed"
+)
+
+// DrainEvent reports a pod's new state during a drain, with the reason for
+// skips, blocks, retries and failures.
+type DrainEvent struct {
+	Namespace string
+	Pod       string
+	State     string
+	Reason    string
+}
+
+// NodeOperator is an optional extension for resources that can change node
+// scheduling: cordon, uncordon and drain. DrainNode cordons the node first
+// and reports each pod's progress through onEvent until every evictable pod
+// is gone.
+type NodeOperator interface {
+	SetNodeSchedulable(ctx context.Context, item ResourceItem, schedulable bool) error
+	DrainNode(ctx context.Context, item ResourceItem, opts DrainOptions, onEvent func(DrainEvent)) error
+}
+
+// CronJobTrigger is an optional extension for resources that can run a
+// CronJob now. It creates a Job from the CronJob's jobTemplate and returns the
+// new Job.
+type CronJobTrigger interface {
+	TriggerCronJob(ctx context.Context, item ResourceItem) (ResourceItem, error)
+}
+
+// DebugContainerCreator is an optional extension for pod resources that can
+// inject an ephemeral debug container, for images without a shell.
+// AddDebugContainer returns the new container's name once it is running.
+// target names the container whose process namespace it joins and may be
+// empty.
+type DebugContainerCreator interface {
+	AddDebugContainer(ctx context.Context, pod ResourceItem, target, image string) (string, error)
+}
+
 // TableResource lets a resource define custom table columns and row rendering.
 type TableResource interface {
 	TableColumns() []TableColumn
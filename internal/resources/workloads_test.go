@@ -91,6 +91,24 @@ func TestCronJobPodsNameAndEmptyState(t *testing.T) {
 	}
 }
 
+func TestCronJobJobsMockHistoryNewestFirst(t *testing.T) {
+	jobs := NewJobsForCronJob(ResourceItem{Name: "nightly-backup", Kind: "CJ"}, nil, nil)
+
+	items := jobs.Items()
+	if len(items) != 3 || items[0].Name != "nightly-backup-289173" {
+		t.Fatalf("expected newest run first, got %#v", items)
+	}
+	if row := jobs.TableRow(items[1]); row["duration"] != "4m12s" {
+		t.Fatalf("expected run duration, got %#v", row)
+	}
+	if banner := jobs.Banner(); !strings.Contains(banner, "0 2 * * *") || !strings.Contains(banner, "6h ago") {
+		t.Fatalf("expected schedule and last success in banner, got %q", banner)
+	}
+	if items := NewJobsForCronJob(ResourceItem{Name: "sync-reports", Kind: "CJ"}, nil, nil).Items(); len(items) != 0 {
+		t.Fatalf("expected no runs for sync-reports, got %#v", items)
+	}
+}
+
 func TestWorkloadPodsShowsNamespaceColumnInAllNamespacesMode(t *testing.T) {
 	reg := DefaultRegistry()
 	reg.SetNamespace(AllNamespaces)
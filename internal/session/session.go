@@ -0,0 +1,109 @@
+// Package session keeps navigation state across runs: the 1–9 bookmarks and
+// the screen podji showed when it last exited. Screens are stored as Nav
+// descriptors rather than views, so they survive a restart and are rebuilt
+// from fresh data.
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dloss/podji/internal/config"
+	"github.com/dloss/podji/internal/resources"
+	"gopkg.in/yaml.v3"
+)
+
+// Item identifies a resource item across runs.
+type Item struct {
+	Kind      string `yaml:"kind,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+	Name      string `yaml:"name"`
+}
+
+// ItemOf returns the identity of a resource item.
+func ItemOf(item resources.ResourceItem) Item {
+	return Item{Kind: item.Kind, Namespace: item.Namespace, Name: item.Name}
+}
+
+// Matches reports whether item is the one identified.
+func (i Item) Matches(item resources.ResourceItem) bool {
+	return item.Name == i.Name && item.Namespace == i.Namespace && item.Kind == i.Kind
+}
+
+// String names the item for messages, e.g. "pod shop/api-1".
+func (i Item) String() string {
+	name := i.Name
+	if i.Namespace != "" {
+		name = i.Namespace + "/" + name
+	}
+	if i.Kind != "" {
+		return strings.ToLower(i.Kind) + " " + name
+	}
+	return name
+}
+
+// Step is one move down a view stack: the item selected in a list, if any,
+// and the key that opened the next view. View is that view's breadcrumb,
+// checked when the step is replayed. A last step without a key only
+// restores the selection.
+type Step struct {
+	Item Item   `yaml:"item,omitempty"`
+	Key  string `yaml:"key,omitempty"`
+	View string `yaml:"view,omitempty"`
+}
+
+// Nav describes a screen: the scope, the root resource list and the steps
+// taken from it.
+type Nav struct {
+	Context   string `yaml:"context"`
+	Namespace string `yaml:"namespace"`
+	Resource  string `yaml:"resource"`
+	Steps     []Step `yaml:"steps,omitempty"`
+}
+
+// File is the content of the session state file.
+type File struct {
+	Bookmarks map[int]Nav `yaml:"bookmarks,omitempty"`
+	Last      *Nav        `yaml:"last,omitempty"`
+}
+
+// DefaultPath returns the session file location in podji's state directory.
+func DefaultPath() (string, error) {
+	return config.StatePath("session.yaml")
+}
+
+// Load reads the session file at path. A missing file yields an empty File.
+// Bookmark slots outside 1–9 are dropped.
+func Load(path string) (File, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return File{}, nil
+	}
+	if err != nil {
+		return File{}, fmt.Errorf("reading session: %w", err)
+	}
+	var f File
+	if err := yaml.Unmarshal(raw, &f); err != nil {
+		return File{}, fmt.Errorf("reading session %s: %w", path, err)
+	}
+	for slot := range f.Bookmarks {
+		if slot < 1 || slot > 9 {
+			delete(f.Bookmarks, slot)
+		}
+	}
+	return f, nil
+}
+
+// Save writes the session file atomically.
+func Save(path string, f File) error {
+	raw, err := yaml.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if err := config.WriteStateFile(path, raw); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.yaml")
+	want := File{
+		Bookmarks: map[int]Nav{
+			2: {Context: "kind-dev", Namespace: "shop", Resource: "pods", Steps: []Step{
+				{Item: Item{Kind: "Pod", Namespace: "shop", Name: "api-1"}, Key: "o", View: "logs"},
+			}},
+		},
+		Last: &Nav{Context: "kind-dev", Namespace: "default", Resource: "deployments"},
+	}
+	if err := Save(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("roundtrip mismatch:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestLoadMissingFileIsEmpty(t *testing.T) {
+	f, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil || f.Bookmarks != nil || f.Last != nil {
+		t.Fatalf("expected empty session, got %+v, %v", f, err)
+	}
+}
+
+func TestLoadDropsInvalidSlots(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.yaml")
+	raw := "bookmarks:\n  0: {resource: pods}\n  1: {resource: pods}\n  10: {resource: pods}\n"
+	if err := os.WriteFile(path, []byte(raw), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Bookmarks) != 1 || f.Bookmarks[1].Resource != "pods" {
+		t.Fatalf("expected only slot 1, got %+v", f.Bookmarks)
+	}
+}
+
+func TestItemString(t *testing.T) {
+	item := Item{Kind: "Pod", Namespace: "shop", Name: "api-1"}
+	if got := item.String(); got != "pod shop/api-1" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	bubbletea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/ui/style"
 	"github.com/dloss/podji/internal/ui/viewstate"
 )
 
@@ -13,6 +14,7 @@ import (
 type PickedMsg struct {
 	ResourceName string
 	Visible      []string // column IDs in pool order
+	ContextOnly  bool     // save for the current kube context only
 }
 
 type rowKind int
@@ -37,6 +39,8 @@ type Picker struct {
 	pool         []resources.TableColumn // ordered pool for emitting results
 	cursor       int
 	defaults     map[string]bool
+	context      string // kube context offered for a context-only choice
+	contextOnly  bool
 	width        int
 	height       int
 }
@@ -96,6 +100,13 @@ func New(resourceName string, pool, labelPool []resources.TableColumn, current [
 	return p
 }
 
+// SetContext offers saving the selection for the named kube context only;
+// only is the initial state of that choice.
+func (p *Picker) SetContext(name string, only bool) {
+	p.context = name
+	p.contextOnly = only && name != ""
+}
+
 func (p *Picker) SetSize(w, h int) {
 	p.width = w
 	p.height = h
@@ -197,12 +208,10 @@ func (p *Picker) Update(msg bubbletea.Msg) viewstate.Update {
 
 	case "enter":
 		visible := p.visibleIDs()
-		resourceName := p.resourceName
+		msg := PickedMsg{ResourceName: p.resourceName, Visible: visible, ContextOnly: p.contextOnly}
 		return viewstate.Update{
 			Action: viewstate.Pop,
-			Cmd: func() bubbletea.Msg {
-				return PickedMsg{ResourceName: resourceName, Visible: visible}
-			},
+			Cmd:    func() bubbletea.Msg { return msg },
 		}
 
 	case "d":
@@ -211,6 +220,10 @@ func (p *Picker) Update(msg bubbletea.Msg) viewstate.Update {
 		p.setAll(true)
 	case "A":
 		p.setAll(false)
+	case "c":
+		if p.context != "" {
+			p.contextOnly = !p.contextOnly
+		}
 
 	case "up", "k":
 		p.moveCursor(-1)
@@ -235,14 +248,14 @@ func (p *Picker) View() string {
 	lines = append(lines, titleStyle.Render("  Column  "))
 	lines = append(lines, strings.Repeat("─", innerWidth))
 
-	muted := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	cursorStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("0")).
-		Background(lipgloss.Color("250")).
-		Bold(true)
+	muted := style.Muted
+	cursorStyle := style.Highlight
 	wideTag := muted.Render(" [wide]")
 
 	maxItems := p.height - 8 // reserve space for border, title, sep, footer
+	if p.context != "" {
+		maxItems-- // the save-for line
+	}
 	if maxItems < 3 {
 		maxItems = 3
 	}
@@ -305,10 +318,17 @@ func (p *Picker) View() string {
 	footerSecondary := muted.Render("d default  enter apply  esc cancel")
 	lines = append(lines, footerPrimary)
 	lines = append(lines, footerSecondary)
+	if p.context != "" {
+		scope := "all contexts"
+		if p.contextOnly {
+			scope = "this context"
+		}
+		lines = append(lines, muted.Render("c save for: "+scope))
+	}
 
 	box := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("241")).
+		BorderForeground(style.Border).
 		Width(innerWidth).
 		Render(strings.Join(lines, "\n"))
 
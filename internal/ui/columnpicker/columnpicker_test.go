@@ -127,3 +127,25 @@ func TestAllAndNoneShortcutsApplyToAllColumns(t *testing.T) {
 		t.Fatalf("expected no visible columns after none, got %v", gotNone)
 	}
 }
+
+func TestContextToggleIsCarriedInPickedMsg(t *testing.T) {
+	pool := []resources.TableColumn{{ID: "name", Name: "NAME", Default: true}}
+	p := New("pods", pool, nil, []string{"name"})
+	p.SetSize(80, 20)
+
+	p.Update(keyRunes('c'))
+	if strings.Contains(p.View(), "save for") {
+		t.Fatal("expected no context choice without a context")
+	}
+
+	p.SetContext("prod-eu", false)
+	if !strings.Contains(p.View(), "save for: all contexts") {
+		t.Fatalf("expected context choice in footer, got %q", p.View())
+	}
+	p.Update(keyRunes('c'))
+	update := p.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	msg, ok := update.Cmd().(PickedMsg)
+	if !ok || !msg.ContextOnly {
+		t.Fatalf("expected context-only PickedMsg, got %#v", update.Cmd())
+	}
+}
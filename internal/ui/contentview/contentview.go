@@ -0,0 +1,160 @@
+package contentview
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/ui/style"
+	"github.com/dloss/podji/internal/ui/viewstate"
+)
+
+// hexRows caps the hex preview of binary values.
+const hexRows = 64
+
+// View shows the content of one config map key, highlighted according to its
+// detected format. Binary values are summarized with a hex preview.
+type View struct {
+	item     resources.ResourceItem
+	resource resources.ResourceType
+	viewport viewport.Model
+
+	format  string
+	size    int
+	lines   []string
+	numbers bool
+}
+
+func New(item resources.ResourceItem, resource resources.ResourceType) *View {
+	v := &View{item: item, resource: resource, viewport: viewport.New(0, 0), numbers: true}
+	keys, ok := resource.(*resources.ConfigMapKeys)
+	if !ok {
+		v.lines = []string{style.Error.Render("content unavailable for " + resource.Name())}
+		v.render()
+		return v
+	}
+	value, binary := keys.Value(item)
+	v.size = len(value)
+	v.format = item.Kind
+	if binary || v.format == resources.FormatBinary {
+		v.format = resources.FormatBinary
+		v.lines = hexDump(value)
+	} else {
+		v.lines = highlight(v.format, strings.Split(strings.TrimRight(string(value), "\n"), "\n"))
+	}
+	v.render()
+	return v
+}
+
+func (v *View) render() {
+	if !v.numbers || v.format == resources.FormatBinary {
+		v.viewport.SetContent(strings.Join(v.lines, "\n"))
+		return
+	}
+	width := len(strconv.Itoa(len(v.lines)))
+	out := make([]string, len(v.lines))
+	for i, line := range v.lines {
+		out[i] = style.Muted.Render(fmt.Sprintf("%*d ", width, i+1)) + line
+	}
+	v.viewport.SetContent(strings.Join(out, "\n"))
+}
+
+func (v *View) Init() bubbletea.Cmd { return nil }
+
+func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
+	if key, ok := msg.(bubbletea.KeyMsg); ok {
+		switch key.String() {
+		case "n":
+			v.numbers = !v.numbers
+			v.render()
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "home", "g":
+			v.viewport.GotoTop()
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "end", "G":
+			v.viewport.GotoBottom()
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		}
+	}
+	updated, cmd := v.viewport.Update(msg)
+	v.viewport = updated
+	return viewstate.Update{Action: viewstate.None, Next: v, Cmd: cmd}
+}
+
+func (v *View) View() string {
+	return v.viewport.View()
+}
+
+func (v *View) Breadcrumb() string {
+	return v.item.Name
+}
+
+func (v *View) Footer() string {
+	indicators := []style.Binding{
+		style.B("format", v.format),
+		style.B("size", strconv.Itoa(v.size)+" bytes"),
+	}
+	if v.format != resources.FormatBinary {
+		indicators = append(indicators, style.B("lines", strconv.Itoa(len(v.lines))))
+	}
+	line1 := style.FormatBindings(indicators)
+	if v.viewport.Width > 0 {
+		line1 = ansi.Truncate(line1, v.viewport.Width-2, "…")
+	}
+
+	var actions []style.Binding
+	if v.format != resources.FormatBinary {
+		label := "hide line numbers"
+		if !v.numbers {
+			label = "line numbers"
+		}
+		actions = append(actions, style.B("n", label))
+	}
+	actions = append(actions, style.B("g/G", "top/bottom"), style.B("←", "back"))
+	return line1 + "\n" + style.ActionFooter(actions, v.viewport.Width)
+}
+
+func (v *View) SetSize(width, height int) {
+	if width == 0 || height == 0 {
+		return
+	}
+	v.viewport.Width = width
+	v.viewport.Height = height
+	v.render()
+}
+
+// hexDump renders binary data in the usual offset, hex and ASCII columns.
+func hexDump(value []byte) []string {
+	lines := []string{style.Muted.Render(fmt.Sprintf("binary data, %d bytes", len(value))), ""}
+	for offset := 0; offset < len(value); offset += 16 {
+		if offset/16 == hexRows {
+			lines = append(lines, style.Muted.Render(fmt.Sprintf("… %d more bytes", len(value)-offset)))
+			break
+		}
+		row := value[offset:min(offset+16, len(value))]
+		var hex, text strings.Builder
+		for i := 0; i < 16; i++ {
+			if i < len(row) {
+				fmt.Fprintf(&hex, "%02x ", row[i])
+			} else {
+				hex.WriteString("   ")
+			}
+			if i == 7 {
+				hex.WriteString(" ")
+			}
+		}
+		for _, b := range row {
+			if b >= 0x20 && b < 0x7f {
+				text.WriteByte(b)
+			} else {
+				text.WriteByte('.')
+			}
+		}
+		lines = append(lines, style.Muted.Render(fmt.Sprintf("%08x", offset))+"  "+hex.String()+" "+text.String())
+	}
+	return lines
+}
@@ -0,0 +1,65 @@
+package contentview
+
+import (
+	"strings"
+	"testing"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/resources"
+)
+
+func keyView(t *testing.T, key string) *View {
+	t.Helper()
+	cms := resources.NewConfigMaps()
+	cm := resources.ResourceItem{Name: "nginx-config"}
+	data, err := cms.ConfigMapData(cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := resources.NewConfigMapKeys(cm, cms, data)
+	for _, item := range keys.Items() {
+		if item.Name == key {
+			v := New(item, keys)
+			v.SetSize(100, 30)
+			return v
+		}
+	}
+	t.Fatalf("key %s not found", key)
+	return nil
+}
+
+func TestTextKeyShowsNumberedContent(t *testing.T) {
+	v := keyView(t, "entrypoint.sh")
+	out := ansi.Strip(v.View())
+	if !strings.Contains(out, "1 #!/bin/sh") || !strings.Contains(out, `exec /app/server --config "$CONFIG_DIR/config.yaml"`) {
+		t.Fatalf("expected numbered script, got:\n%s", out)
+	}
+	if footer := ansi.Strip(v.Footer()); !strings.Contains(footer, "shell") || !strings.Contains(footer, "4") {
+		t.Fatalf("expected format and line count in footer, got %q", footer)
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("n")})
+	if out := ansi.Strip(v.View()); !strings.HasPrefix(out, "#!/bin/sh") {
+		t.Fatalf("expected line numbers hidden, got:\n%s", out)
+	}
+}
+
+func TestBinaryKeyShowsHexPreview(t *testing.T) {
+	v := keyView(t, "favicon.ico")
+	out := ansi.Strip(v.View())
+	if !strings.Contains(out, "binary data, 62 bytes") || !strings.Contains(out, "00000000  00 00 01 00") {
+		t.Fatalf("expected hex preview, got:\n%s", out)
+	}
+}
+
+func TestJSONHighlightKeepsText(t *testing.T) {
+	line := `  "enableNewUI": true, "n": -1.5, "s": "a\"b"`
+	if got := ansi.Strip(highlightJSON(line)); got != line {
+		t.Fatalf("highlighting changed the text: %q", got)
+	}
+	shell := `if [ -n "$X" ]; then echo ${HOME} $1 # done`
+	if got := ansi.Strip(highlightShell(shell)); got != shell {
+		t.Fatalf("highlighting changed the text: %q", got)
+	}
+}
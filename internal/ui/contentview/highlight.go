@@ -0,0 +1,178 @@
+package contentview
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/ui/style"
+	"github.com/dloss/podji/internal/ui/yamlview"
+)
+
+// shellKeywords are highlighted at the start of a shell command.
+var shellKeywords = map[string]bool{
+	"if": true, "then": true, "else": true, "elif": true, "fi": true,
+	"for": true, "while": true, "until": true, "do": true, "done": true,
+	"case": true, "esac": true, "in": true, "function": true, "return": true,
+	"exit": true, "export": true, "local": true, "set": true, "exec": true,
+}
+
+func highlight(format string, lines []string) []string {
+	var fn func(string) string
+	switch format {
+	case resources.FormatJSON:
+		fn = highlightJSON
+	case resources.FormatYAML:
+		fn = yamlview.HighlightLine
+	case resources.FormatProperties:
+		fn = highlightProperties
+	case resources.FormatShell:
+		fn = highlightShell
+	default:
+		return lines
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = fn(line)
+	}
+	return out
+}
+
+// highlightJSON colors strings, keys, numbers and literals. A string followed
+// by a colon is a key.
+func highlightJSON(line string) string {
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(line))
+			token := line[i:end]
+			if strings.HasPrefix(strings.TrimLeft(line[end:], " "), ":") {
+				b.WriteString(style.SyntaxKey.Render(token))
+			} else {
+				b.WriteString(style.SyntaxString.Render(token))
+			}
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(line) && strings.IndexByte("0123456789.eE+-", line[end]) >= 0 {
+				end++
+			}
+			b.WriteString(style.SyntaxLiteral.Render(line[i:end]))
+			i = end
+		case strings.HasPrefix(line[i:], "true"), strings.HasPrefix(line[i:], "null"):
+			b.WriteString(style.SyntaxLiteral.Render(line[i : i+4]))
+			i += 4
+		case strings.HasPrefix(line[i:], "false"):
+			b.WriteString(style.SyntaxLiteral.Render(line[i : i+5]))
+			i += 5
+		case strings.IndexByte("{}[],:", c) >= 0:
+			b.WriteString(style.SyntaxPunct.Render(string(c)))
+			i++
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// highlightProperties colors key=value and key: value pairs and comments.
+func highlightProperties(line string) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	indent := line[:len(line)-len(trimmed)]
+	if trimmed == "" {
+		return line
+	}
+	if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "!") {
+		return indent + style.SyntaxComment.Render(trimmed)
+	}
+	idx := strings.IndexAny(trimmed, "=:")
+	if idx <= 0 {
+		return indent + style.SyntaxKey.Render(trimmed)
+	}
+	return indent + style.SyntaxKey.Render(trimmed[:idx]) + style.SyntaxPunct.Render(trimmed[idx:idx+1]) + style.SyntaxString.Render(trimmed[idx+1:])
+}
+
+// highlightShell colors comments, quoted strings, variables and keywords at
+// the start of a command. Heredocs and multi-line strings are not tracked.
+func highlightShell(line string) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	indent := line[:len(line)-len(trimmed)]
+	if strings.HasPrefix(trimmed, "#") {
+		return indent + style.SyntaxComment.Render(trimmed)
+	}
+	var b strings.Builder
+	b.WriteString(indent)
+	commandStart := true
+	for i := 0; i < len(trimmed); {
+		c := trimmed[i]
+		switch {
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(trimmed[i+1:], c)
+			if end < 0 {
+				end = len(trimmed)
+			} else {
+				end += i + 2
+			}
+			b.WriteString(style.SyntaxString.Render(trimmed[i:end]))
+			i = end
+			commandStart = false
+		case c == '$':
+			end := i + 1
+			switch {
+			case end < len(trimmed) && trimmed[end] == '{':
+				if close := strings.IndexByte(trimmed[end:], '}'); close >= 0 {
+					end += close + 1
+				}
+			case end < len(trimmed) && strings.IndexByte("?@#*$!0123456789", trimmed[end]) >= 0:
+				end++
+			default:
+				for end < len(trimmed) && (trimmed[end] == '_' || unicode.IsLetter(rune(trimmed[end])) || unicode.IsDigit(rune(trimmed[end]))) {
+					end++
+				}
+			}
+			b.WriteString(style.SyntaxLiteral.Render(trimmed[i:end]))
+			i = end
+			commandStart = false
+		case c == '#' && i > 0 && (trimmed[i-1] == ' ' || trimmed[i-1] == '\t'):
+			b.WriteString(style.SyntaxComment.Render(trimmed[i:]))
+			i = len(trimmed)
+		case isWordByte(c):
+			end := i
+			for end < len(trimmed) && isWordByte(trimmed[end]) {
+				end++
+			}
+			word := trimmed[i:end]
+			if commandStart && shellKeywords[word] {
+				b.WriteString(style.SyntaxKey.Render(word))
+			} else {
+				b.WriteString(word)
+				commandStart = false
+			}
+			i = end
+		default:
+			if c == ';' || c == '|' || c == '&' || c == '(' {
+				commandStart = true
+			} else if !unicode.IsSpace(rune(c)) {
+				commandStart = false
+			}
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '-' || c == '.' || c == '/' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -6,21 +6,11 @@ import (
 
 	"github.com/charmbracelet/bubbles/viewport"
 	bubbletea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/dloss/podji/internal/resources"
 	"github.com/dloss/podji/internal/ui/style"
 	"github.com/dloss/podji/internal/ui/viewstate"
 )
 
-var (
-	sectionStyle   = lipgloss.NewStyle().Bold(true)
-	containerStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
-	keyStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
-	imageStyle     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255"))
-	resourceStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
-	probeStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
-)
-
 type View struct {
 	item     resources.ResourceItem
 	resource resources.ResourceType
@@ -98,17 +88,17 @@ func highlightLine(line string, inLimits, inRequests *bool) string {
 			key := strings.TrimSuffix(trimmed, ":")
 			switch indent {
 			case 0:
-				return sectionStyle.Render(line)
+				return style.Header.Render(line)
 			case 2:
-				return containerStyle.Render(line)
+				return style.Accent.Bold(true).Render(line)
 			case 4:
 				switch key {
 				case "Limits":
 					*inLimits = true
-					return resourceStyle.Render(line)
+					return style.Warning.Bold(false).Render(line)
 				case "Requests":
 					*inRequests = true
-					return resourceStyle.Render(line)
+					return style.Warning.Bold(false).Render(line)
 				}
 			}
 		}
@@ -136,7 +126,7 @@ func highlightLine(line string, inLimits, inRequests *bool) string {
 	switch indent {
 	case 0:
 		// Top-level fields: Name, Namespace, Status, IP, …
-		styledKey := keyStyle.Render(keyPart)
+		styledKey := style.Label.Render(keyPart)
 		if key == "Status" {
 			return styledKey + valueSep + style.Status(value)
 		}
@@ -144,14 +134,14 @@ func highlightLine(line string, inLimits, inRequests *bool) string {
 
 	case 4:
 		// Container fields.
-		styledKey := keyStyle.Render(keyPart)
+		styledKey := style.Label.Render(keyPart)
 		switch key {
 		case "Image":
-			return styledKey + valueSep + imageStyle.Render(value)
+			return styledKey + valueSep + style.Strong.Render(value)
 		case "State":
 			return styledKey + valueSep + style.Status(value)
 		case "Liveness", "Readiness", "Startup":
-			return styledKey + valueSep + probeStyle.Render(value)
+			return styledKey + valueSep + style.Info.Render(value)
 		case "Restart Count":
 			n := parseRestarts(value)
 			if n > 10 {
@@ -164,10 +154,10 @@ func highlightLine(line string, inLimits, inRequests *bool) string {
 
 	case 6:
 		// Nested values: resource quantities or state sub-fields (Reason, Started).
-		styledKey := keyStyle.Render(keyPart)
+		styledKey := style.Label.Render(keyPart)
 		if *inLimits || *inRequests {
 			if key == "memory" {
-				return styledKey + valueSep + resourceStyle.Render(value)
+				return styledKey + valueSep + style.Warning.Bold(false).Render(value)
 			}
 			return styledKey + valueSep + value
 		}
@@ -178,7 +168,7 @@ func highlightLine(line string, inLimits, inRequests *bool) string {
 		return styledKey + valueSep + value
 	}
 
-	return keyStyle.Render(keyPart) + valueSep + value
+	return style.Label.Render(keyPart) + valueSep + value
 }
 
 // parseRestarts returns the integer restart count from strings like "5" or "5 (10m ago)".
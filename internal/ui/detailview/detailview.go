@@ -74,6 +74,7 @@ func (v *View) View() string {
 		leftWidth, rightWidth := splitWidths(v.width, 2)
 		left := []string{}
 		left = append(left, renderContainers(detail.Containers, leftWidth)...)
+		left = append(left, renderAllocation(detail.Allocation)...)
 		left = append(left, titledSection("CONDITIONS", detail.Conditions)...)
 		left = append(left, titledSection("LABELS", detail.Labels)...)
 
@@ -87,6 +88,7 @@ func (v *View) View() string {
 	}
 
 	sections = append(sections, renderContainers(detail.Containers, v.width)...)
+	sections = append(sections, renderAllocation(detail.Allocation)...)
 	sections = append(sections, titledSection("CONDITIONS", detail.Conditions)...)
 	sections = append(sections, titledSection("RECENT EVENTS", detail.Events)...)
 	sections = append(sections, titledSection("LABELS", detail.Labels)...)
@@ -129,19 +131,62 @@ func renderContainers(rows []resources.ContainerRow, width int) []string {
 	}
 
 	for _, row := range rows {
+		name := row.Name
+		if row.Type != "" {
+			name += " (" + row.Type + ")"
+		}
+		reason := row.Reason
+		if row.ExitCode != "" {
+			reason = strings.TrimSpace(reason + " exit " + row.ExitCode)
+		}
 		lines = append(lines, fmt.Sprintf(
 			"%s %s %s %s %s",
-			cell(row.Name, nameW),
+			cell(name, nameW),
 			cell(row.Image, imageW),
 			cell(row.State, stateW),
 			cell(row.Restarts, restartW),
-			cell(row.Reason, reasonW),
+			cell(reason, reasonW),
 		))
 	}
 
 	return lines
 }
 
+// allocationBarWidth is the number of cells in an allocation bar; a full bar
+// is 100 percent of allocatable.
+const allocationBarWidth = 20
+
+// renderAllocation shows requests and limits against allocatable as bars.
+// Limits beyond 100 percent fill the bar and are flagged as overcommitted.
+func renderAllocation(rows []resources.AllocationRow) []string {
+	if len(rows) == 0 {
+		return nil
+	}
+	lines := []string{"ALLOCATED (of allocatable)"}
+	for _, row := range rows {
+		lines = append(lines,
+			fmt.Sprintf("%s requests %s  %s / %s", cell(row.Resource, 7), allocationBar(row.RequestsPercent), row.Requests, row.Allocatable),
+			fmt.Sprintf("%s limits   %s  %s / %s", cell("", 7), allocationBar(row.LimitsPercent), row.Limits, row.Allocatable),
+		)
+	}
+	return lines
+}
+
+func allocationBar(percent int) string {
+	filled := clamp(percent*allocationBarWidth/100, 0, allocationBarWidth)
+	bar := strings.Repeat("█", filled) + style.Muted.Render(strings.Repeat("░", allocationBarWidth-filled))
+	label := fmt.Sprintf("%4d%%", percent)
+	switch {
+	case percent > 100:
+		label = style.Error.Render(label)
+	case percent >= 85:
+		label = style.Warning.Render(label)
+	default:
+		label = style.Healthy.Render(label)
+	}
+	return bar + " " + label
+}
+
 func titledSection(title string, lines []string) []string {
 	if len(lines) == 0 {
 		return nil
@@ -157,7 +202,7 @@ func compactSections(lines []string) []string {
 		if line == "" {
 			continue
 		}
-		if len(out) > 0 && (strings.HasPrefix(line, "CONDITIONS") || strings.HasPrefix(line, "RECENT EVENTS") || strings.HasPrefix(line, "LABELS")) {
+		if len(out) > 0 && (strings.HasPrefix(line, "ALLOCATED") || strings.HasPrefix(line, "CONDITIONS") || strings.HasPrefix(line, "RECENT EVENTS") || strings.HasPrefix(line, "LABELS")) {
 			out = append(out, "")
 		}
 		out = append(out, line)
@@ -208,7 +253,7 @@ func useTwoColumnLayout(width int, detail resources.DetailData) bool {
 		return false
 	}
 	// Reserve two-column layout for resources with richer primary detail.
-	return len(detail.Containers) > 0 || len(detail.Conditions) > 0
+	return len(detail.Containers) > 0 || len(detail.Conditions) > 0 || len(detail.Allocation) > 0
 }
 
 func renderSummary(fields []resources.SummaryField) string {
@@ -34,6 +34,12 @@ func TestUseTwoColumnLayout(t *testing.T) {
 			detail: resources.DetailData{Conditions: []string{"Ready=True"}},
 			want:   true,
 		},
+		{
+			name:   "wide with node allocation uses two column",
+			width:  140,
+			detail: resources.DetailData{Allocation: []resources.AllocationRow{{Resource: "cpu"}}},
+			want:   true,
+		},
 		{
 			name:  "wide labels and events only stays single column",
 			width: 140,
@@ -73,3 +79,26 @@ func TestRenderSummary(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderAllocation(t *testing.T) {
+	t.Parallel()
+
+	lines := renderAllocation([]resources.AllocationRow{
+		{Resource: "cpu", Requests: "3450m", Limits: "6", Allocatable: "3920m", RequestsPercent: 88, LimitsPercent: 153},
+	})
+	if len(lines) != 3 {
+		t.Fatalf("expected title and two bar lines, got %q", lines)
+	}
+	if !strings.Contains(lines[1], "cpu") || !strings.Contains(lines[1], "88%") || !strings.Contains(lines[1], "3450m / 3920m") {
+		t.Fatalf("unexpected requests line %q", lines[1])
+	}
+	if got := strings.Count(lines[2], "█"); got != allocationBarWidth {
+		t.Fatalf("overcommitted limits should fill the bar, got %d cells in %q", got, lines[2])
+	}
+	if !strings.Contains(lines[2], "153%") {
+		t.Fatalf("expected limits percent in %q", lines[2])
+	}
+	if renderAllocation(nil) != nil {
+		t.Fatal("expected no section without allocation")
+	}
+}
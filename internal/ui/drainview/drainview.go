@@ -0,0 +1,264 @@
+package drainview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/ui/style"
+	"github.com/dloss/podji/internal/ui/viewstate"
+)
+
+type drainEventMsg struct {
+	event resources.DrainEvent
+}
+
+type drainDoneMsg struct {
+	err error
+}
+
+// row is the latest known state of one pod on the node.
+type row struct {
+	namespace string
+	pod       string
+	state     string
+	reason    string
+	retries   int
+}
+
+// View drains a node and shows the progress of every pod on it. The drain
+// runs while the view is open; esc stops it, leaving the node cordoned.
+type View struct {
+	node     resources.ResourceItem
+	operator resources.NodeOperator
+	opts     resources.DrainOptions
+	viewport viewport.Model
+
+	rows    []*row
+	byKey   map[string]*row
+	running bool
+	stopped bool
+	err     error
+
+	cancel   context.CancelFunc
+	streamCh <-chan bubbletea.Msg
+}
+
+func New(node resources.ResourceItem, operator resources.NodeOperator, opts resources.DrainOptions) *View {
+	return &View{
+		node:     node,
+		operator: operator,
+		opts:     opts,
+		viewport: viewport.New(0, 0),
+		byKey:    map[string]*row{},
+	}
+}
+
+func (v *View) Init() bubbletea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	v.running = true
+	streamCh := make(chan bubbletea.Msg, 256)
+	v.streamCh = streamCh
+	go func() {
+		err := v.operator.DrainNode(ctx, v.node, v.opts, func(event resources.DrainEvent) {
+			select {
+			case streamCh <- drainEventMsg{event: event}:
+			case <-ctx.Done():
+			}
+		})
+		select {
+		case streamCh <- drainDoneMsg{err: err}:
+		default:
+		}
+		close(streamCh)
+	}()
+	v.render()
+	return v.nextStreamMsgCmd()
+}
+
+func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
+	switch msg := msg.(type) {
+	case drainEventMsg:
+		v.apply(msg.event)
+		v.render()
+		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.nextStreamMsgCmd()}
+	case drainDoneMsg:
+		v.running = false
+		if !errors.Is(msg.err, context.Canceled) {
+			v.err = msg.err
+		}
+		v.streamCh = nil
+		v.render()
+		return viewstate.Update{Action: viewstate.None, Next: v}
+	case bubbletea.KeyMsg:
+		if msg.String() == "esc" && v.running {
+			v.stop()
+			v.render()
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		}
+	}
+	updated, cmd := v.viewport.Update(msg)
+	v.viewport = updated
+	return viewstate.Update{Action: viewstate.None, Next: v, Cmd: cmd}
+}
+
+func (v *View) apply(event resources.DrainEvent) {
+	key := event.Namespace + "/" + event.Pod
+	r, ok := v.byKey[key]
+	if !ok {
+		r = &row{namespace: event.Namespace, pod: event.Pod}
+		v.byKey[key] = r
+		v.rows = append(v.rows, r)
+	}
+	if event.State == resources.DrainRetrying {
+		r.retries++
+	}
+	r.state = event.State
+	r.reason = event.Reason
+}
+
+func (v *View) stop() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+	v.running = false
+	v.stopped = true
+	v.streamCh = nil
+}
+
+func (v *View) render() {
+	var lines []string
+	switch {
+	case v.running:
+		lines = append(lines, style.Muted.Render("Draining node "+v.node.Name+" …"))
+	case v.stopped:
+		lines = append(lines, style.Warning.Render("Drain stopped. The node stays cordoned."))
+	case v.err != nil:
+		lines = append(lines, style.Error.Render("Drain failed: "+v.err.Error()))
+	default:
+		lines = append(lines, style.Healthy.Render("Node "+v.node.Name+" drained."))
+	}
+	lines = append(lines, "")
+	if len(v.rows) == 0 {
+		lines = append(lines, style.Muted.Render("No pods reported yet."))
+		v.viewport.SetContent(strings.Join(lines, "\n"))
+		return
+	}
+
+	podWidth := len("POD")
+	for _, r := range v.rows {
+		podWidth = max(podWidth, len(r.namespace)+1+len(r.pod))
+	}
+	pad := func(s string, w int) string { return s + strings.Repeat(" ", max(0, w-ansi.StringWidth(s))) }
+	lines = append(lines, style.Muted.Render(pad("POD", podWidth)+"  "+pad("STATE", 12)+"  "+pad("RETRIES", 7)+"  REASON"))
+	for _, r := range v.rows {
+		retries := "-"
+		if r.retries > 0 {
+			retries = strconv.Itoa(r.retries)
+		}
+		lines = append(lines, pad(r.namespace+"/"+r.pod, podWidth)+"  "+pad(stateStyle(r.state), 12)+"  "+pad(retries, 7)+"  "+r.reason)
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+func stateStyle(state string) string {
+	switch state {
+	case resources.DrainEvicted:
+		return style.Healthy.Render(state)
+	case resources.DrainRetrying, resources.DrainBlocked:
+		return style.Warning.Render(state)
+	case resources.DrainFailed:
+		return style.Error.Render(state)
+	case resources.DrainSkipped, resources.DrainPending:
+		return style.Muted.Render(state)
+	}
+	return state
+}
+
+func (v *View) counts() map[string]int {
+	counts := map[string]int{}
+	for _, r := range v.rows {
+		counts[r.state]++
+	}
+	return counts
+}
+
+func (v *View) View() string {
+	return v.viewport.View()
+}
+
+func (v *View) Breadcrumb() string {
+	return "drain"
+}
+
+func (v *View) Footer() string {
+	counts := v.counts()
+	evictable := len(v.rows) - counts[resources.DrainSkipped] - counts[resources.DrainBlocked]
+	indicators := []style.Binding{
+		style.B("evicted", fmt.Sprintf("%d/%d", counts[resources.DrainEvicted], evictable)),
+	}
+	for _, state := range []string{resources.DrainRetrying, resources.DrainSkipped, resources.DrainBlocked, resources.DrainFailed} {
+		if counts[state] > 0 {
+			indicators = append(indicators, style.B(state, strconv.Itoa(counts[state])))
+		}
+	}
+	if v.opts.DeleteEmptyDirData {
+		indicators = append(indicators, style.B("emptyDir", "delete"))
+	}
+	line1 := style.FormatBindings(indicators)
+	if v.viewport.Width > 0 {
+		line1 = ansi.Truncate(line1, v.viewport.Width-2, "…")
+	}
+
+	var actions []style.Binding
+	if v.running {
+		actions = append(actions, style.B("esc", "stop drain"))
+	} else {
+		actions = append(actions, style.B("←", "back"))
+	}
+	return line1 + "\n" + style.ActionFooter(actions, v.viewport.Width)
+}
+
+func (v *View) SetSize(width, height int) {
+	if width == 0 || height == 0 {
+		return
+	}
+	v.viewport.Width = width
+	v.viewport.Height = height
+	v.render()
+}
+
+// SuppressGlobalKeys keeps esc from leaving the view while the drain runs,
+// so the first esc stops it.
+func (v *View) SuppressGlobalKeys() bool {
+	return v.running
+}
+
+func (v *View) Dispose() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+}
+
+func (v *View) nextStreamMsgCmd() bubbletea.Cmd {
+	if v.streamCh == nil {
+		return nil
+	}
+	ch := v.streamCh
+	return func() bubbletea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
@@ -0,0 +1,104 @@
+package drainview
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/resources"
+)
+
+type fakeOperator struct {
+	events []resources.DrainEvent
+	err    error
+	block  bool
+}
+
+func (f *fakeOperator) SetNodeSchedulable(ctx context.Context, item resources.ResourceItem, schedulable bool) error {
+	return nil
+}
+
+func (f *fakeOperator) DrainNode(ctx context.Context, item resources.ResourceItem, opts resources.DrainOptions, onEvent func(resources.DrainEvent)) error {
+	for _, event := range f.events {
+		onEvent(event)
+	}
+	if f.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return f.err
+}
+
+// run feeds stream messages back into the view until the stream ends.
+func run(v *View, cmd bubbletea.Cmd) {
+	for cmd != nil {
+		msg := cmd()
+		if msg == nil {
+			return
+		}
+		cmd = v.Update(msg).Cmd
+	}
+}
+
+func TestDrainViewTracksPodStatesAndRetries(t *testing.T) {
+	op := &fakeOperator{events: []resources.DrainEvent{
+		{Namespace: "kube-system", Pod: "exporter", State: resources.DrainSkipped, Reason: "DaemonSet-managed"},
+		{Namespace: "shop", Pod: "api-1", State: resources.DrainPending},
+		{Namespace: "shop", Pod: "api-1", State: resources.DrainEvicting},
+		{Namespace: "shop", Pod: "api-1", State: resources.DrainRetrying, Reason: "disruption budget"},
+		{Namespace: "shop", Pod: "api-1", State: resources.DrainRetrying, Reason: "disruption budget"},
+		{Namespace: "shop", Pod: "api-1", State: resources.DrainEvicted},
+	}}
+	v := New(resources.ResourceItem{Name: "worker-1"}, op, resources.DrainOptions{})
+	v.SetSize(120, 20)
+	run(v, v.Init())
+
+	if v.running || v.SuppressGlobalKeys() {
+		t.Fatal("expected drain to be finished")
+	}
+	if len(v.rows) != 2 || v.byKey["shop/api-1"].retries != 2 {
+		t.Fatalf("unexpected rows: %+v", v.rows)
+	}
+	view := ansi.Strip(v.View())
+	for _, want := range []string{"Node worker-1 drained.", "kube-system/exporter", "DaemonSet-managed", "shop/api-1"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in view:\n%s", want, view)
+		}
+	}
+	if footer := ansi.Strip(v.Footer()); !strings.Contains(footer, "1/1") || !strings.Contains(footer, "skipped") {
+		t.Fatalf("unexpected footer: %s", footer)
+	}
+}
+
+func TestDrainViewReportsFailure(t *testing.T) {
+	op := &fakeOperator{
+		events: []resources.DrainEvent{{Namespace: "shop", Pod: "cache-0", State: resources.DrainBlocked, Reason: "uses emptyDir volume data"}},
+		err:    errors.New("1 pod(s) blocked"),
+	}
+	v := New(resources.ResourceItem{Name: "worker-1"}, op, resources.DrainOptions{})
+	v.SetSize(120, 20)
+	run(v, v.Init())
+
+	if view := ansi.Strip(v.View()); !strings.Contains(view, "Drain failed: 1 pod(s) blocked") || !strings.Contains(view, "uses emptyDir volume data") {
+		t.Fatalf("unexpected view:\n%s", view)
+	}
+}
+
+func TestDrainViewEscStopsRunningDrain(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "worker-1"}, &fakeOperator{block: true}, resources.DrainOptions{})
+	v.SetSize(120, 20)
+	v.Init()
+	if !v.SuppressGlobalKeys() {
+		t.Fatal("expected global keys to be suppressed while draining")
+	}
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEsc})
+	if v.running || !v.stopped || v.SuppressGlobalKeys() {
+		t.Fatal("expected esc to stop the drain")
+	}
+	if view := ansi.Strip(v.View()); !strings.Contains(view, "Drain stopped") {
+		t.Fatalf("unexpected view:\n%s", view)
+	}
+}
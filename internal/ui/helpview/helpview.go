@@ -39,7 +39,7 @@ TABLE (filterable lists, including A)
   esc                  Clear filter
   s                    Sort (name/problem)
   w                    Wide columns on/off
-  p                    Column visibility picker
+  p                    Column visibility picker (saved across sessions)
   f <char>             Jump to first item by char
   d                    Describe
   y                    YAML
@@ -48,7 +48,38 @@ TABLE (filterable lists, including A)
   o                    Logs (or next table)
   space / pgup / pgdn  Page up / down
   c                    Copy mode (n name, k kind/name, p -n ns name)
+  ctrl+e               Export visible rows (c csv, j json, m markdown; y clipboard, f file)
   x                    Execute mode (d delete, r restart, s scale, f port-fwd, x shell)
+  =                    Mark for compare, then = on a second item to compare
+
+YAML (yaml view)
+  /                    Search
+  n / b                Next / previous match
+  s                    Hide managedFields and status
+  c                    Copy mode (v value, p JSONPath)
+  d                    Drift against last-applied or Helm manifest
+  e                    Edit in $EDITOR, review dry-run diff, y to apply
+
+SECRET DATA (enter on a secret)
+  v / space            Reveal / hide selected value
+  c                    Copy selected value
+                       Reveal and copy are disabled in protected (prod) contexts
+
+NODES (x on a node)
+  c / u                Cordon / uncordon
+  n                    Drain (e toggles emptyDir deletion, y starts)
+  esc                  Stop a running drain; the node stays cordoned
+
+PODS (x on a pod or container)
+  b                    Debug: add an ephemeral container (image prompt,
+                       PODJI_DEBUG_IMAGE sets the default) and attach to it
+
+CRONJOBS (x on a cronjob)
+  t                    Trigger now: create a job from the template, open its pods
+
+CONFIGMAP KEYS (enter on a configmap)
+  enter                Open key content (JSON, YAML, properties, shell)
+  n                    Line numbers on/off (content view)
 
 LOGS (logs view)
   f                    Follow on/off
@@ -58,6 +89,7 @@ LOGS (logs view)
   /                    Search
   &                    Filter
   n / b                Next / previous match
+  B                    Previous match on disk (older than the buffer)
   , / .                Cycle since window
   c                    Container picker (from container logs)
   up / down / j / k    Scroll
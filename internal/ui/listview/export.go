@@ -0,0 +1,192 @@
+package listview
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/dloss/podji/internal/resources"
+)
+
+// Export formats offered by the export prompt.
+const (
+	ExportCSV      = "csv"
+	ExportJSON     = "json"
+	ExportMarkdown = "markdown"
+)
+
+// ExportMsg asks app.go to export the visible rows of a list. The list does
+// not know the kube context, so app.go supplies it when writing.
+type ExportMsg struct {
+	Format      string
+	ToClipboard bool
+	Table       ExportTable
+}
+
+// ExportTable is a snapshot of a list as shown: the active columns and the
+// visible rows in their current filter and sort order.
+type ExportTable struct {
+	Resource  string
+	Namespace string
+	Filter    string
+	Sort      string
+	Columns   []resources.TableColumn
+	Rows      [][]string
+}
+
+// exportTable captures the visible rows with the active column set.
+func (v *View) exportTable() ExportTable {
+	t := ExportTable{
+		Resource: v.resource.Name(),
+		Columns:  append([]resources.TableColumn(nil), v.columns...),
+	}
+	if scoped, ok := v.resource.(resources.NamespaceScoped); ok {
+		t.Namespace = scoped.Namespace()
+	} else if v.registry != nil {
+		t.Namespace = v.registry.Namespace()
+	}
+	if v.list.IsFiltered() || v.list.SettingFilter() {
+		t.Filter = v.list.FilterValue()
+	}
+	if v.sortMode != "" {
+		t.Sort = v.sortMode
+		if v.sortDesc {
+			t.Sort += " (desc)"
+		}
+	}
+	for _, listItem := range v.list.VisibleItems() {
+		if it, ok := listItem.(item); ok {
+			t.Rows = append(t.Rows, append([]string(nil), it.row...))
+		}
+	}
+	return t
+}
+
+// Render formats the table with a scope header: comment lines for CSV, a
+// summary line for Markdown and top-level fields for JSON.
+func (t ExportTable) Render(format, kubeContext string, now time.Time) (string, error) {
+	namespace := t.Namespace
+	if namespace == resources.AllNamespaces {
+		namespace = "all namespaces"
+	}
+	meta := [][2]string{
+		{"resource", t.Resource},
+		{"context", kubeContext},
+		{"namespace", namespace},
+		{"filter", t.Filter},
+		{"sort", t.Sort},
+		{"exported", now.UTC().Format(time.RFC3339)},
+	}
+	headers := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		headers[i] = col.Name
+	}
+
+	switch format {
+	case ExportCSV:
+		var buf bytes.Buffer
+		for _, kv := range meta {
+			if kv[1] != "" {
+				fmt.Fprintf(&buf, "# %s: %s\n", kv[0], kv[1])
+			}
+		}
+		w := csv.NewWriter(&buf)
+		_ = w.Write(headers)
+		_ = w.WriteAll(t.Rows)
+		return buf.String(), w.Error()
+
+	case ExportJSON:
+		doc := map[string]any{}
+		for _, kv := range meta {
+			if kv[1] != "" {
+				doc[kv[0]] = kv[1]
+			}
+		}
+		ids := make([]string, len(t.Columns))
+		for i, col := range t.Columns {
+			ids[i] = col.ID
+		}
+		rows := make([]map[string]string, 0, len(t.Rows))
+		for _, row := range t.Rows {
+			obj := make(map[string]string, len(ids))
+			for i, id := range ids {
+				if i < len(row) {
+					obj[id] = row[i]
+				}
+			}
+			rows = append(rows, obj)
+		}
+		doc["columns"] = ids
+		doc["rows"] = rows
+		out, err := json.MarshalIndent(doc, "", "  ")
+		return string(out) + "\n", err
+
+	case ExportMarkdown:
+		var buf bytes.Buffer
+		var parts []string
+		for _, kv := range meta[1:] {
+			if kv[1] != "" {
+				parts = append(parts, kv[0]+" `"+kv[1]+"`")
+			}
+		}
+		fmt.Fprintf(&buf, "**%s** (%d rows) · %s\n\n", t.Resource, len(t.Rows), strings.Join(parts, " · "))
+		buf.WriteString("| " + strings.Join(markdownCells(headers), " | ") + " |\n")
+		buf.WriteString("|" + strings.Repeat(" --- |", len(headers)) + "\n")
+		for _, row := range t.Rows {
+			buf.WriteString("| " + strings.Join(markdownCells(row), " | ") + " |\n")
+		}
+		return buf.String(), nil
+	}
+	return "", fmt.Errorf("unknown export format %q", format)
+}
+
+func markdownCells(cells []string) []string {
+	out := make([]string, len(cells))
+	for i, cell := range cells {
+		out[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	return out
+}
+
+var exportNameUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// exportExtensions maps formats to file extensions.
+var exportExtensions = map[string]string{ExportCSV: ".csv", ExportJSON: ".json", ExportMarkdown: ".md"}
+
+// ExportFileName is the file an export is written to in the working
+// directory, e.g. "pods-20260102-150405.csv".
+func (t ExportTable) ExportFileName(format string, now time.Time) string {
+	name := strings.Trim(exportNameUnsafe.ReplaceAllString(strings.ToLower(t.Resource), "-"), "-")
+	if name == "" {
+		name = "export"
+	}
+	return name + "-" + now.Format("20060102-150405") + exportExtensions[format]
+}
+
+// Write renders the export and copies it to the clipboard or writes it to a
+// file in dir. It returns a short result for the status line.
+func (msg ExportMsg) Write(kubeContext, dir string, now time.Time) (string, error) {
+	content, err := msg.Table.Render(msg.Format, kubeContext, now)
+	if err != nil {
+		return "", err
+	}
+	rows := fmt.Sprintf("%d rows", len(msg.Table.Rows))
+	if msg.ToClipboard {
+		if err := clipboard.WriteAll(content); err != nil {
+			return "", err
+		}
+		return "copied " + rows + " as " + msg.Format, nil
+	}
+	path := filepath.Join(dir, msg.Table.ExportFileName(msg.Format, now))
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", err
+	}
+	return "exported " + rows + " to " + path, nil
+}
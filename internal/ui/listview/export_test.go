@@ -0,0 +1,104 @@
+package listview
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/dloss/podji/internal/resources"
+)
+
+func testExportTable() ExportTable {
+	return ExportTable{
+		Resource:  "pods",
+		Namespace: "shop",
+		Filter:    "api",
+		Columns: []resources.TableColumn{
+			{ID: "name", Name: "NAME"},
+			{ID: "status", Name: "STATUS"},
+		},
+		Rows: [][]string{{"api-1", "CrashLoop"}, {"api-2", "a|b, c"}},
+	}
+}
+
+func TestExportTableRenderFormats(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	table := testExportTable()
+
+	csvOut, err := table.Render(ExportCSV, "prod", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# context: prod\n", "# namespace: shop\n", "# filter: api\n", "NAME,STATUS\n", "api-2,\"a|b, c\"\n"} {
+		if !strings.Contains(csvOut, want) {
+			t.Fatalf("expected %q in csv:\n%s", want, csvOut)
+		}
+	}
+
+	jsonOut, err := table.Render(ExportJSON, "prod", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Context  string              `json:"context"`
+		Exported string              `json:"exported"`
+		Rows     []map[string]string `json:"rows"`
+	}
+	if err := json.Unmarshal([]byte(jsonOut), &doc); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if doc.Context != "prod" || doc.Exported != "2026-01-02T15:04:05Z" || doc.Rows[0]["status"] != "CrashLoop" {
+		t.Fatalf("unexpected json export %+v", doc)
+	}
+
+	md, err := table.Render(ExportMarkdown, "prod", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"**pods** (2 rows) · context `prod`", "| NAME | STATUS |", "| api-2 | a\\|b, c |"} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("expected %q in markdown:\n%s", want, md)
+		}
+	}
+}
+
+func TestExportKeysEmitVisibleRowsAndWriteFile(t *testing.T) {
+	view := New(resources.NewPods(), resources.DefaultRegistry())
+	view.SetSize(120, 40)
+
+	view.Update(bubbletea.KeyMsg{Type: bubbletea.KeyCtrlE})
+	if footer := view.Footer(); !strings.Contains(footer, "markdown") {
+		t.Fatalf("expected format prompt, got %q", footer)
+	}
+	view.Update(keyRunes('c'))
+	update := view.Update(keyRunes('f'))
+	if update.Cmd == nil || view.exportMode {
+		t.Fatal("expected export command and prompt closed")
+	}
+	msg, ok := update.Cmd().(ExportMsg)
+	if !ok || msg.Format != ExportCSV || msg.ToClipboard {
+		t.Fatalf("unexpected export message %#v", msg)
+	}
+	if len(msg.Table.Rows) != len(view.list.VisibleItems()) || len(msg.Table.Columns) != len(view.columns) {
+		t.Fatalf("expected visible rows with active columns, got %d rows %d columns", len(msg.Table.Rows), len(msg.Table.Columns))
+	}
+
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	result, err := msg.Write("default", dir, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "pods-20260102-150405.csv")
+	if !strings.Contains(result, path) {
+		t.Fatalf("unexpected result %q", result)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(content), "# context: default") {
+		t.Fatalf("unexpected export file (%v):\n%s", err, content)
+	}
+}
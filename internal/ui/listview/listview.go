@@ -1,7 +1,9 @@
 package listview
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"sort"
 	"strconv"
@@ -17,11 +19,14 @@ import (
 	"github.com/charmbracelet/x/ansi"
 	"github.com/dloss/podji/internal/columnconfig"
 	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/ui/contentview"
 	"github.com/dloss/podji/internal/ui/describeview"
 	"github.com/dloss/podji/internal/ui/detailview"
+	"github.com/dloss/podji/internal/ui/drainview"
 	"github.com/dloss/podji/internal/ui/eventview"
 	"github.com/dloss/podji/internal/ui/filterbar"
 	"github.com/dloss/podji/internal/ui/logview"
+	"github.com/dloss/podji/internal/ui/secretview"
 	"github.com/dloss/podji/internal/ui/style"
 	"github.com/dloss/podji/internal/ui/viewstate"
 	"github.com/dloss/podji/internal/ui/yamlview"
@@ -35,6 +40,14 @@ type OpenColumnPickerMsg struct {
 	Pool         []resources.TableColumn // resource-defined columns (normal + wide extras)
 	LabelPool    []resources.TableColumn // dynamic label-derived columns
 	Current      []string                // currently active column IDs
+	ContextOnly  bool                    // columns are saved for the current context only
+}
+
+// CompareMsg asks app.go to mark Item for comparison, or to compare it with
+// the item marked before.
+type CompareMsg struct {
+	Item     resources.ResourceItem
+	Resource resources.ResourceType
 }
 
 type clearCopiedMsg struct{}
@@ -65,6 +78,30 @@ func clearExecResultCmd() bubbletea.Cmd {
 
 type shellExecResultMsg struct{ err error }
 type portForwardResultMsg struct{ err error }
+type nodeActionResultMsg struct {
+	label string
+	verb  string
+	err   error
+}
+type cronJobTriggerResultMsg struct {
+	job resources.ResourceItem
+	err error
+}
+type debugContainerReadyMsg struct {
+	pod       resources.ResourceItem
+	container string
+	err       error
+}
+type debugSessionResultMsg struct{ err error }
+
+// DefaultDebugImage is the image offered for ephemeral debug containers.
+// app.go sets it from the config file; PODJI_DEBUG_IMAGE overrides both.
+var DefaultDebugImage = "busybox:1.36"
+
+// ConfirmAction reports whether a destructive action ("delete", "restart" or
+// "drain") asks for confirmation first. app.go installs the policy from the
+// config file; by default every such action asks.
+var ConfirmAction = func(action string) bool { return true }
 
 type executeState int
 
@@ -75,15 +112,18 @@ const (
 	execConfirmRestart
 	execInputScale
 	execInputPortFwd
+	execConfirmDrain
+	execInputDebugImage
 )
 
 type item struct {
-	data        resources.ResourceItem
-	row         []string
-	status      string
-	widths      []int
-	matchColumn int
-	dimPodName  bool
+	data         resources.ResourceItem
+	row          []string
+	status       string
+	statusColumn int // STATUS column, which shows a severity symbol; -1 if none
+	widths       []int
+	matchColumn  int
+	dimPodName   bool
 }
 
 func (i item) Title() string {
@@ -91,8 +131,8 @@ func (i item) Title() string {
 	for idx, value := range i.row {
 		width := i.widths[idx]
 		cellValue := padCell(value, width)
-		if idx > 0 && i.status != "" && i.row[idx] == i.status {
-			cellValue = statusStyle(cellValue)
+		if i.isStatusCell(idx) {
+			cellValue = statusStyle(i.statusCell(idx, value, width))
 		}
 		cells = append(cells, cellValue)
 	}
@@ -124,36 +164,41 @@ func (i item) FilterValue() string {
 }
 
 type View struct {
-	resource     resources.ResourceType
-	registry     *resources.Registry
-	list         list.Model
-	columns      []resources.TableColumn
-	colWidths    []int
-	wideMode     bool
-	labelPool    []resources.TableColumn
-	sortPickMode bool
-	sortMode     string
-	sortDesc     bool
-	findMode     bool
-	findTargets  map[int]bool
-	searchActive bool
-	searchQuery  string
-	searchInput  textinput.Model
-	matchRows    []int
-	matchIndex   int
-	copyMode     bool
-	copiedMsg    string
-	actionMsg    string
-	execState    executeState
-	execInput    string
-	execResult   string
+	resource      resources.ResourceType
+	registry      *resources.Registry
+	list          list.Model
+	columns       []resources.TableColumn
+	colWidths     []int
+	wideMode      bool
+	labelPool     []resources.TableColumn
+	sortPickMode  bool
+	sortMode      string
+	sortDesc      bool
+	findMode      bool
+	findTargets   map[int]bool
+	searchActive  bool
+	searchQuery   string
+	searchInput   textinput.Model
+	matchRows     []int
+	matchIndex    int
+	copyMode      bool
+	copiedMsg     string
+	actionMsg     string
+	execState     executeState
+	execInput     string
+	execResult    string
+	drainEmptyDir bool
+	// exportFormat is set while the export prompt asks for a destination;
+	// exportMode is set while it asks for a format.
+	exportMode   bool
+	exportFormat string
 }
 
 func New(resource resources.ResourceType, registry *resources.Registry) *View {
 	items := resource.Items()
 	labelPool := labelColumnsFromItems(items)
 	pool := buildColumnPool(resource, labelPool)
-	columns := columnconfig.Default().Get(resource.Name(), pool)
+	columns := columnconfig.Default().Get(columnLayoutName(resource), pool)
 	rows := assembleRows(resource, false, columns, items)
 	firstHeader := strings.ToUpper(resources.SingularName(breadcrumbLabel(resource.Name())))
 	widths := columnWidthsForRows(columns, rows, 0, firstHeader)
@@ -220,6 +265,53 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: clearExecResultCmd()}
 	}
 
+	if msg, ok := msg.(nodeActionResultMsg); ok {
+		if msg.err != nil {
+			v.execResult = msg.verb + " failed: " + msg.err.Error()
+		} else {
+			v.execResult = msg.verb + "ed " + msg.label
+			v.refreshItems()
+		}
+		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: clearExecResultCmd()}
+	}
+
+	if msg, ok := msg.(debugContainerReadyMsg); ok {
+		if msg.err != nil {
+			v.execResult = "debug failed: " + msg.err.Error()
+			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: clearExecResultCmd()}
+		}
+		v.execResult = ""
+		c := exec.Command("kubectl", v.debugAttachArgs(msg.pod, msg.container)...)
+		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: bubbletea.ExecProcess(c, func(err error) bubbletea.Msg {
+			return debugSessionResultMsg{err: err}
+		})}
+	}
+	if msg, ok := msg.(debugSessionResultMsg); ok {
+		if msg.err != nil {
+			v.execResult = "debug attach failed: " + msg.err.Error()
+		} else {
+			v.execResult = "debug session ended"
+		}
+		if cr, ok := v.resource.(*resources.ContainerResource); ok {
+			cr.Reload()
+			v.refreshItems()
+		}
+		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: clearExecResultCmd()}
+	}
+
+	if msg, ok := msg.(cronJobTriggerResultMsg); ok {
+		if msg.err != nil {
+			v.execResult = "trigger failed: " + msg.err.Error()
+			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: clearExecResultCmd()}
+		}
+		v.refreshItems()
+		var pods resources.ResourceType = resources.NewWorkloadPods(msg.job, v.registry)
+		if livePods, ok := v.liveWorkloadPods(msg.job); ok {
+			pods = resources.NewQueryResource(pods.Name(), livePods, pods)
+		}
+		return viewstate.Update{Action: viewstate.Push, Next: New(pods, v.registry)}
+	}
+
 	if v.searchActive {
 		updated, cmd := v.searchInput.Update(msg)
 		v.searchInput = updated
@@ -334,18 +426,43 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 			return viewstate.Update{Action: viewstate.None, Next: v}
 		}
 
+		// Export mode: pick a format, then clipboard or file.
+		if v.exportMode {
+			switch key.String() {
+			case "c":
+				v.exportFormat = ExportCSV
+			case "j":
+				v.exportFormat = ExportJSON
+			case "m":
+				v.exportFormat = ExportMarkdown
+			case "y", "f":
+				if v.exportFormat != "" {
+					msg := ExportMsg{Format: v.exportFormat, ToClipboard: key.String() == "y", Table: v.exportTable()}
+					v.exportMode, v.exportFormat = false, ""
+					return viewstate.Update{Action: viewstate.None, Next: v, Cmd: func() bubbletea.Msg { return msg }}
+				}
+			case "esc":
+				v.exportMode, v.exportFormat = false, ""
+			}
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		}
+
 		// Execute mode: sub-menu (x was pressed; pick an operation).
 		if v.execState == execMenu {
 			switch key.String() {
 			case "esc":
 				v.execState = execNone
 			case "d":
-				v.execState = execNone
 				v.execState = execConfirmDelete
+				if !ConfirmAction("delete") {
+					return v.runConfirmedExec()
+				}
 			case "r":
 				if v.supportsRestart() {
-					v.execState = execNone
 					v.execState = execConfirmRestart
+					if !ConfirmAction("restart") {
+						return v.runConfirmedExec()
+					}
 				}
 			case "s":
 				if v.supportsScale() {
@@ -364,27 +481,85 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 					v.execState = execNone
 					return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.shellExecCmd()}
 				}
+			case "c", "u":
+				if v.supportsNodeOps() {
+					v.execState = execNone
+					return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.nodeSchedulableCmd(key.String() == "u")}
+				}
+			case "n":
+				if v.supportsNodeOps() {
+					// The emptyDir choice is offered even when the drain
+					// policy skips the confirmation; enter then starts it.
+					v.execState = execConfirmDrain
+					v.drainEmptyDir = false
+				}
+			case "b":
+				if v.supportsDebug() {
+					v.execState = execInputDebugImage
+					v.execInput = debugImage()
+				}
+			case "t":
+				if v.supportsTrigger() {
+					v.execState = execNone
+					return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.triggerCronJobCmd()}
+				}
+			}
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		}
+
+		// Execute mode: drain confirmation with the emptyDir option.
+		if v.execState == execConfirmDrain {
+			switch key.String() {
+			case "e":
+				v.drainEmptyDir = !v.drainEmptyDir
+			case "y":
+				if ConfirmAction("drain") {
+					return v.startDrain()
+				}
+			case "enter":
+				if !ConfirmAction("drain") {
+					return v.startDrain()
+				}
+			case "esc":
+				v.execState = execNone
 			}
 			return viewstate.Update{Action: viewstate.None, Next: v}
 		}
 
 		// Execute mode: delete / restart confirmation.
 		if v.execState == execConfirmDelete || v.execState == execConfirmRestart {
-			op := v.execState
 			switch key.String() {
 			case "y":
+				return v.runConfirmedExec()
+			case "esc":
 				v.execState = execNone
-				label := v.execTargetLabel()
-				var resultMsg string
-				if op == execConfirmDelete {
-					resultMsg = "deleted " + label + " (simulated)"
-				} else {
-					resultMsg = "restarted " + label + " (simulated)"
+			}
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		}
+
+		// Execute mode: debug container image input.
+		if v.execState == execInputDebugImage {
+			switch key.String() {
+			case "enter":
+				image := strings.TrimSpace(v.execInput)
+				v.execState = execNone
+				cmd := v.debugContainerCmd(image)
+				if cmd == nil {
+					return viewstate.Update{Action: viewstate.None, Next: v}
 				}
-				v.execResult = resultMsg
-				return viewstate.Update{Action: viewstate.None, Next: v, Cmd: clearExecResultCmd()}
+				v.execResult = "starting " + image + " in " + v.execTargetLabel() + "…"
+				return viewstate.Update{Action: viewstate.None, Next: v, Cmd: cmd}
 			case "esc":
 				v.execState = execNone
+			case "backspace", "ctrl+h":
+				runes := []rune(v.execInput)
+				if len(runes) > 0 {
+					v.execInput = string(runes[:len(runes)-1])
+				}
+			default:
+				if key.Type == bubbletea.KeyRunes {
+					v.execInput += string(key.Runes)
+				}
 			}
 			return viewstate.Update{Action: viewstate.None, Next: v}
 		}
@@ -497,8 +672,9 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 				}
 				pool := buildColumnPool(v.resource, v.labelPool)
 				current := columnIDs(v.columns)
-				resourceName := v.resource.Name()
+				resourceName := columnLayoutName(v.resource)
 				labelPool := v.labelPool
+				contextOnly := columnconfig.Default().HasContextOverride(resourceName)
 				return viewstate.Update{
 					Action: viewstate.None,
 					Next:   v,
@@ -508,6 +684,7 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 							Pool:         pool,
 							LabelPool:    labelPool,
 							Current:      current,
+							ContextOnly:  contextOnly,
 						}
 					},
 				}
@@ -563,6 +740,16 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 				return viewstate.Update{Action: viewstate.None, Next: v, Cmd: clearActionCmd()}
 			}
 			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "ctrl+e":
+			v.exportMode = true
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "=":
+			if selected, ok := v.list.SelectedItem().(item); ok && selected.data.Name != "" {
+				msg := CompareMsg{Item: selected.data, Resource: v.resource}
+				return viewstate.Update{Action: viewstate.None, Next: v, Cmd: func() bubbletea.Msg { return msg }}
+			}
+			v.actionMsg = "= unavailable: no selected item"
+			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: clearActionCmd()}
 		case "x":
 			if selected, ok := v.list.SelectedItem().(item); ok && selected.data.Name != "" {
 				v.execState = execMenu
@@ -689,7 +876,7 @@ func (v *View) Footer() string {
 	if v.wideMode {
 		indicators = append(indicators, style.B("wide", ""))
 	}
-	if columnconfig.Default().IsCustom(v.resource.Name()) && !v.wideMode {
+	if columnconfig.Default().IsCustom(columnLayoutName(v.resource)) && !v.wideMode {
 		indicators = append(indicators, style.B("columns", "custom"))
 	}
 	if v.findMode {
@@ -726,6 +913,20 @@ func (v *View) Footer() string {
 		if v.list.Width() > 0 {
 			line2 = ansi.Truncate(line2, v.list.Width()-2, "…")
 		}
+	} else if v.exportMode {
+		exportLabel := style.FooterKey.Render("export")
+		var opts []style.Binding
+		if v.exportFormat == "" {
+			opts = []style.Binding{style.B("c", "csv"), style.B("j", "json"), style.B("m", "markdown")}
+		} else {
+			exportLabel += " " + style.FooterLabel.Render(v.exportFormat)
+			opts = []style.Binding{style.B("y", "clipboard"), style.B("f", "file")}
+		}
+		opts = append(opts, style.B("esc", "cancel"))
+		line2 = exportLabel + "  " + style.FormatBindings(opts)
+		if v.list.Width() > 0 {
+			line2 = ansi.Truncate(line2, v.list.Width()-2, "…")
+		}
 	} else if v.execState == execMenu {
 		execLabel := style.FooterKey.Render("exec")
 		var opts []style.Binding
@@ -742,6 +943,15 @@ func (v *View) Footer() string {
 		if v.supportsShellExec() {
 			opts = append(opts, style.B("x", "shell"))
 		}
+		if v.supportsNodeOps() {
+			opts = append(opts, style.B("c", "cordon"), style.B("u", "uncordon"), style.B("n", "drain"))
+		}
+		if v.supportsDebug() {
+			opts = append(opts, style.B("b", "debug"))
+		}
+		if v.supportsTrigger() {
+			opts = append(opts, style.B("t", "trigger"))
+		}
 		opts = append(opts, style.B("esc", "cancel"))
 		line2 = execLabel + "  " + style.FormatBindings(opts)
 		if v.list.Width() > 0 {
@@ -764,6 +974,27 @@ func (v *View) Footer() string {
 		if v.list.Width() > 0 {
 			line2 = ansi.Truncate(line2, v.list.Width()-2, "…")
 		}
+	} else if v.execState == execConfirmDrain {
+		drainLabel := style.FooterKey.Render("drain")
+		emptyDir := "keep emptyDir pods"
+		if v.drainEmptyDir {
+			emptyDir = "delete emptyDir data"
+		}
+		target := style.FooterLabel.Render(v.execTargetLabel() + "?")
+		start := style.B("y", "confirm")
+		if !ConfirmAction("drain") {
+			target = style.FooterLabel.Render(v.execTargetLabel())
+			start = style.B("enter", "start")
+		}
+		opts := style.FormatBindings([]style.Binding{
+			style.B("e", emptyDir),
+			start,
+			style.B("esc", "cancel"),
+		})
+		line2 = drainLabel + " " + target + "  " + opts
+		if v.list.Width() > 0 {
+			line2 = ansi.Truncate(line2, v.list.Width()-2, "…")
+		}
 	} else if v.execState == execInputScale {
 		scaleLabel := style.FooterKey.Render("scale")
 		target := style.FooterLabel.Render(v.execTargetLabel())
@@ -777,6 +1008,19 @@ func (v *View) Footer() string {
 		if v.list.Width() > 0 {
 			line2 = ansi.Truncate(line2, v.list.Width()-2, "…")
 		}
+	} else if v.execState == execInputDebugImage {
+		debugLabel := style.FooterKey.Render("debug")
+		target := style.FooterLabel.Render(v.execTargetLabel())
+		prompt := style.FooterLabel.Render("  image: ")
+		inputVal := style.FooterKey.Render(v.execInput + "█")
+		opts := "  " + style.FormatBindings([]style.Binding{
+			style.B("enter", "start"),
+			style.B("esc", "cancel"),
+		})
+		line2 = debugLabel + " " + target + prompt + inputVal + opts
+		if v.list.Width() > 0 {
+			line2 = ansi.Truncate(line2, v.list.Width()-2, "…")
+		}
 	} else if v.execState == execInputPortFwd {
 		fwdLabel := style.FooterKey.Render("port-fwd")
 		target := style.FooterLabel.Render(v.execTargetLabel())
@@ -861,7 +1105,13 @@ func (v *View) SetSize(width, height int) {
 }
 
 func (v *View) SuppressGlobalKeys() bool {
-	return v.list.SettingFilter() || v.list.IsFiltered() || v.findMode || v.searchActive || len(v.matchRows) > 0 || v.copyMode || v.execState != execNone || v.sortPickMode
+	return v.CapturesKeys() || v.list.IsFiltered() || len(v.matchRows) > 0
+}
+
+// CapturesKeys reports whether a prompt or key mode reads the next keys
+// literally, so remapped bindings do not apply to them.
+func (v *View) CapturesKeys() bool {
+	return v.list.SettingFilter() || v.findMode || v.searchActive || v.copyMode || v.exportMode || v.execState != execNone || v.sortPickMode
 }
 
 // SelectedItem returns the currently highlighted resource item.
@@ -899,15 +1149,39 @@ func (v *View) Resource() resources.ResourceType {
 	return v.resource
 }
 
-// ApplyColumnConfig is called by app.go after the column picker confirms a selection.
-func (v *View) ApplyColumnConfig(resourceName string, visible []string) {
-	if resourceName != v.resource.Name() {
-		return
+// columnLayoutName is the name a list's column layout is saved under: the
+// resource name without its qualifier, so "pods (node: n1)" shares the layout
+// of "pods" and the state file does not gain an entry per node or workload.
+func columnLayoutName(res resources.ResourceType) string {
+	name := strings.ToLower(res.Name())
+	if head, _, ok := strings.Cut(name, " "); ok {
+		return head
+	}
+	return name
+}
+
+// ApplyColumnConfig is called by app.go after the column picker confirms a
+// selection. contextOnly saves it for the current kube context only. The
+// returned command clears a save error shown in the footer.
+func (v *View) ApplyColumnConfig(resourceName string, visible []string, contextOnly bool) bubbletea.Cmd {
+	if resourceName != columnLayoutName(v.resource) {
+		return nil
+	}
+	store := columnconfig.Default()
+	var err error
+	if contextOnly {
+		err = store.SetForContext(resourceName, visible)
+	} else {
+		err = store.Set(resourceName, visible)
 	}
-	columnconfig.Default().Set(resourceName, visible)
 	pool := buildColumnPool(v.resource, v.labelPool)
-	v.columns = columnconfig.Default().Get(resourceName, pool)
+	v.columns = store.Get(resourceName, pool)
 	v.refreshItems()
+	if err != nil {
+		v.actionMsg = "columns not saved: " + err.Error()
+		return clearActionCmd()
+	}
+	return nil
 }
 
 func (v *View) NextBreadcrumb() string {
@@ -930,6 +1204,19 @@ func (v *View) NextBreadcrumb() string {
 	if resourceName == "containers" {
 		return "logs"
 	}
+	if resourceName == "secrets" {
+		if _, ok := v.resource.(resources.SecretDataReader); ok {
+			return "data"
+		}
+	}
+	if resourceName == "configmaps" {
+		if _, ok := v.resource.(resources.ConfigMapDataReader); ok {
+			return "keys"
+		}
+	}
+	if _, ok := v.resource.(*resources.ConfigMapKeys); ok {
+		return selected.data.Name
+	}
 	return "detail"
 }
 
@@ -974,7 +1261,22 @@ func (v *View) paginationStatus() string {
 }
 
 func statusStyle(status string) string {
-	return style.Status(status)
+	return style.StatusStyle(status).Render(status)
+}
+
+// isStatusCell reports whether the cell at idx shows the item's status.
+func (i item) isStatusCell(idx int) bool {
+	return idx > 0 && i.status != "" && i.row[idx] == i.status
+}
+
+// statusCell pads the status cell at idx to width. In the STATUS column the
+// status is preceded by its severity symbol, which columnWidthsForRows makes
+// room for.
+func (i item) statusCell(idx int, value string, width int) string {
+	if idx != i.statusColumn || width <= style.StatusSymbolWidth {
+		return padCell(value, width)
+	}
+	return style.StatusSymbol(value) + " " + padCell(value, width-style.StatusSymbolWidth)
 }
 
 func headerRow(columns []resources.TableColumn, firstLabel string) string {
@@ -1133,6 +1435,7 @@ func makeListItems(resource resources.ResourceType, items []resources.ResourceIt
 	if matchColumn < 0 {
 		matchColumn = 0
 	}
+	statusColumn := firstColumnWithID(columns, "status")
 	dimPodName := shouldDimPodNameSuffixes(resource)
 	for idx, res := range items {
 		var row []string
@@ -1140,12 +1443,13 @@ func makeListItems(resource resources.ResourceType, items []resources.ResourceIt
 			row = rows[idx]
 		}
 		listItems = append(listItems, item{
-			data:        res,
-			row:         row,
-			status:      res.Status,
-			widths:      widths,
-			matchColumn: matchColumn,
-			dimPodName:  dimPodName,
+			data:         res,
+			row:          row,
+			status:       res.Status,
+			statusColumn: statusColumn,
+			widths:       widths,
+			matchColumn:  matchColumn,
+			dimPodName:   dimPodName,
 		})
 	}
 	return listItems
@@ -1166,6 +1470,14 @@ func columnWidths(columns []resources.TableColumn) []int {
 	return widths
 }
 
+// statusSymbolWidth returns the room a column needs for status symbols.
+func statusSymbolWidth(col resources.TableColumn) int {
+	if col.ID == "status" {
+		return style.StatusSymbolWidth
+	}
+	return 0
+}
+
 func columnWidthsForRows(columns []resources.TableColumn, rows [][]string, availableWidth int, firstHeader string) []int {
 	if len(columns) == 0 {
 		return nil
@@ -1183,6 +1495,9 @@ func columnWidthsForRows(columns []resources.TableColumn, rows [][]string, avail
 				continue
 			}
 			cellWidth := len([]rune(strings.TrimSpace(row[idx])))
+			if cellWidth > 0 {
+				cellWidth += statusSymbolWidth(col)
+			}
 			if cellWidth > maxContent {
 				maxContent = cellWidth
 			}
@@ -1509,7 +1824,7 @@ func (v *View) refreshColumns() {
 	}
 	// Normal mode: apply column config.
 	pool := buildColumnPool(v.resource, v.labelPool)
-	v.columns = columnconfig.Default().Get(v.resource.Name(), pool)
+	v.columns = columnconfig.Default().Get(columnLayoutName(v.resource), pool)
 	v.refreshItems()
 }
 
@@ -1522,7 +1837,7 @@ func (v *View) refreshItems() {
 	// In normal mode, re-apply column config (label pool may have changed).
 	if !v.wideMode {
 		pool := buildColumnPool(v.resource, v.labelPool)
-		v.columns = columnconfig.Default().Get(v.resource.Name(), pool)
+		v.columns = columnconfig.Default().Get(columnLayoutName(v.resource), pool)
 	}
 	v.sortMode = normalizeSortMode(v.resource, v.columns, v.sortMode)
 
@@ -1716,13 +2031,15 @@ func matchSummary(index, total int) string {
 
 func (v *View) forwardView(selected resources.ResourceItem, key string) (viewstate.Action, viewstate.View) {
 	resourceName := strings.ToLower(v.resource.Name())
+	_, cronJobRuns := v.resource.(*resources.CronJobJobs)
 
-	if resourceName == "workloads" {
+	if resourceName == "workloads" || cronJobRuns {
 		if livePods, ok := v.liveWorkloadPods(selected); ok {
 			base := resources.NewWorkloadPods(selected, v.registry)
 			pods := resources.NewQueryResource(base.Name(), livePods, base)
 			if key == "o" && len(livePods) > 0 {
 				lv := logview.New(preferredLogPod(livePods), pods)
+				lv.FollowWorkload = true
 				lv.ContainerViewFactory = func(item resources.ResourceItem, res resources.ResourceType) viewstate.View {
 					return New(resources.NewContainerResource(item, res), v.registry)
 				}
@@ -1737,6 +2054,7 @@ func (v *View) forwardView(selected resources.ResourceItem, key string) (viewsta
 		}
 		if key == "o" {
 			lv := logview.New(preferredLogPod(items), pods)
+			lv.FollowWorkload = true
 			lv.ContainerViewFactory = func(item resources.ResourceItem, res resources.ResourceType) viewstate.View {
 				return New(resources.NewContainerResource(item, res), v.registry)
 			}
@@ -1745,6 +2063,17 @@ func (v *View) forwardView(selected resources.ResourceItem, key string) (viewsta
 		return viewstate.Push, New(pods, v.registry)
 	}
 
+	if grep, ok := v.resource.(*resources.LogGrepResource); ok {
+		if hit, found := grep.Hit(selected); found {
+			lv := logview.NewWithContainer(hit.Pod, grep.Pods(), hit.Container)
+			lv.SeekLine(hit.Text, hit.Line)
+			lv.ContainerViewFactory = func(item resources.ResourceItem, res resources.ResourceType) viewstate.View {
+				return New(resources.NewContainerResource(item, res), v.registry)
+			}
+			return viewstate.Push, lv
+		}
+	}
+
 	if strings.HasPrefix(resourceName, "pods") || resourceName == "pods" {
 		containers := v.resource.Detail(selected).Containers
 		if len(containers) <= 1 {
@@ -1788,6 +2117,25 @@ func (v *View) forwardView(selected resources.ResourceItem, key string) (viewsta
 		return viewstate.Push, New(resources.NewIngressServices(selected.Name), v.registry)
 	}
 
+	if resourceName == "secrets" {
+		if _, ok := v.resource.(resources.SecretDataReader); ok {
+			return viewstate.Push, secretview.New(selected, v.resource)
+		}
+	}
+
+	if resourceName == "configmaps" {
+		if reader, ok := v.resource.(resources.ConfigMapDataReader); ok {
+			data, err := reader.ConfigMapData(selected)
+			if err == nil {
+				return viewstate.Push, New(resources.NewConfigMapKeys(selected, v.resource, data), v.registry)
+			}
+		}
+	}
+
+	if _, ok := v.resource.(*resources.ConfigMapKeys); ok {
+		return viewstate.Push, contentview.New(selected, v.resource)
+	}
+
 	if resourceName == "nodes" {
 		if pods, ok := v.livePodsForNode(selected); ok {
 			base := resources.NewNodePods(selected.Name)
@@ -1958,6 +2306,136 @@ func (v *View) supportsShellExec() bool {
 	return name == "pods" || strings.HasPrefix(name, "pods") || name == "containers"
 }
 
+// supportsNodeOps reports whether the current resource supports cordon,
+// uncordon and drain.
+func (v *View) supportsNodeOps() bool {
+	_, ok := v.resource.(resources.NodeOperator)
+	return ok && strings.ToLower(v.resource.Name()) == "nodes"
+}
+
+// nodeSchedulableCmd cordons or uncordons the selected node.
+func (v *View) nodeSchedulableCmd(schedulable bool) bubbletea.Cmd {
+	selected, ok := v.list.SelectedItem().(item)
+	operator, isOperator := v.resource.(resources.NodeOperator)
+	if !ok || !isOperator {
+		return nil
+	}
+	label := v.execTargetLabel()
+	verb := "cordon"
+	if schedulable {
+		verb = "uncordon"
+	}
+	return func() bubbletea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := operator.SetNodeSchedulable(ctx, selected.data, schedulable)
+		return nodeActionResultMsg{label: label, verb: verb, err: err}
+	}
+}
+
+// supportsTrigger reports whether the selected row is a CronJob that can be
+// run now.
+func (v *View) supportsTrigger() bool {
+	if _, ok := v.resource.(resources.CronJobTrigger); !ok || strings.ToLower(v.resource.Name()) != "workloads" {
+		return false
+	}
+	selected, ok := v.list.SelectedItem().(item)
+	return ok && selected.data.Kind == "CJ"
+}
+
+// triggerCronJobCmd creates a Job from the selected CronJob's template.
+func (v *View) triggerCronJobCmd() bubbletea.Cmd {
+	selected, ok := v.list.SelectedItem().(item)
+	trigger, isTrigger := v.resource.(resources.CronJobTrigger)
+	if !ok || !isTrigger {
+		return nil
+	}
+	return func() bubbletea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		job, err := trigger.TriggerCronJob(ctx, selected.data)
+		return cronJobTriggerResultMsg{job: job, err: err}
+	}
+}
+
+// debugTarget resolves the pod, the container whose process namespace a
+// debug container joins, and the resource that can create it. In a pod's
+// container list the selected app container is the target; in a pod list it
+// is the pod's first container.
+func (v *View) debugTarget() (resources.DebugContainerCreator, resources.ResourceItem, string, bool) {
+	selected, ok := v.list.SelectedItem().(item)
+	if !ok {
+		return nil, resources.ResourceItem{}, "", false
+	}
+	if cr, ok := v.resource.(*resources.ContainerResource); ok {
+		creator, ok := v.debugCreator(cr.ParentResource())
+		target := ""
+		if selected.data.Kind == resources.ContainerTypeLabel("") {
+			target = selected.data.Name
+		}
+		return creator, cr.PodItem(), target, ok
+	}
+	if !v.supportsShellExec() {
+		return nil, resources.ResourceItem{}, "", false
+	}
+	creator, ok := v.debugCreator(v.resource)
+	target, _, _ := strings.Cut(selected.data.Extra["containers"], ",")
+	return creator, selected.data, strings.TrimSpace(target), ok
+}
+
+// debugCreator returns the pod resource that adds debug containers. Derived
+// pod lists, such as a workload's pods, fall back to the registry's pods.
+func (v *View) debugCreator(res resources.ResourceType) (resources.DebugContainerCreator, bool) {
+	if creator, ok := res.(resources.DebugContainerCreator); ok {
+		return creator, true
+	}
+	if v.registry != nil {
+		creator, ok := v.registry.ByName("pods").(resources.DebugContainerCreator)
+		return creator, ok
+	}
+	return nil, false
+}
+
+// supportsDebug reports whether an ephemeral debug container can be added
+// for the selected pod or container.
+func (v *View) supportsDebug() bool {
+	_, _, _, ok := v.debugTarget()
+	return ok
+}
+
+// debugImage is the image prefilled in the debug prompt.
+func debugImage() string {
+	if image := strings.TrimSpace(os.Getenv("PODJI_DEBUG_IMAGE")); image != "" {
+		return image
+	}
+	return DefaultDebugImage
+}
+
+// debugContainerCmd adds an ephemeral container with image to the selected
+// pod and waits for it to run.
+func (v *View) debugContainerCmd(image string) bubbletea.Cmd {
+	creator, pod, target, ok := v.debugTarget()
+	if !ok || image == "" {
+		return nil
+	}
+	return func() bubbletea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		name, err := creator.AddDebugContainer(ctx, pod, target, image)
+		return debugContainerReadyMsg{pod: pod, container: name, err: err}
+	}
+}
+
+// debugAttachArgs builds "kubectl [-n <namespace>] attach -it <pod> -c
+// <container>" for a running debug container.
+func (v *View) debugAttachArgs(pod resources.ResourceItem, container string) []string {
+	var args []string
+	if ns := v.execNamespace(pod); ns != "" && ns != resources.AllNamespaces {
+		args = append(args, "-n", ns)
+	}
+	return append(args, "attach", "-it", pod.Name, "-c", container)
+}
+
 // shellExecCmd returns a bubbletea command that suspends the TUI and runs
 // "kubectl exec -it <pod> [-c <container>] -- sh".
 func (v *View) shellExecCmd() bubbletea.Cmd {
@@ -2055,3 +2533,41 @@ func (v *View) execTargetLabel() string {
 	kind := resources.SingularName(strings.ToLower(breadcrumbLabel(v.resource.Name())))
 	return kind + "/" + selected.data.Name
 }
+
+// runConfirmedExec performs the pending delete or restart once confirmed, or
+// straight from the menu when the policy skips the confirmation.
+func (v *View) runConfirmedExec() viewstate.Update {
+	op := v.execState
+	v.execState = execNone
+	label := v.execTargetLabel()
+	if op == execConfirmDelete {
+		v.execResult = "deleted " + label + " (simulated)"
+	} else {
+		v.execResult = "restarted " + label + " (simulated)"
+	}
+	return viewstate.Update{Action: viewstate.None, Next: v, Cmd: clearExecResultCmd()}
+}
+
+// startDrain opens the drain progress view for the selected node.
+func (v *View) startDrain() viewstate.Update {
+	v.execState = execNone
+	selected, ok := v.list.SelectedItem().(item)
+	operator, isOperator := v.resource.(resources.NodeOperator)
+	if ok && isOperator {
+		opts := resources.DrainOptions{DeleteEmptyDirData: v.drainEmptyDir}
+		return viewstate.Update{Action: viewstate.Push, Next: drainview.New(selected.data, operator, opts)}
+	}
+	return viewstate.Update{Action: viewstate.None, Next: v}
+}
+
+// SelectItem moves the cursor to the first visible row whose item satisfies
+// match and reports whether there was one.
+func (v *View) SelectItem(match func(resources.ResourceItem) bool) bool {
+	for i, listItem := range v.list.VisibleItems() {
+		if it, ok := listItem.(item); ok && match(it.data) {
+			v.list.Select(i)
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package listview
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"strings"
@@ -8,7 +9,9 @@ import (
 
 	bubbletea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/columnconfig"
 	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/ui/drainview"
 	"github.com/dloss/podji/internal/ui/viewstate"
 )
 
@@ -57,9 +60,9 @@ func TestContainersSortPickSupportsCharAndCount(t *testing.T) {
 	}
 
 	view.Update(keyRunes('s'))
-	view.Update(keyRunes('3'))
-	if view.sortMode != "ready" {
-		t.Fatalf("expected 3rd column mode from count key, got %q", view.sortMode)
+	view.Update(keyRunes('4'))
+	if view.sortMode != "restarts" {
+		t.Fatalf("expected 4th column mode from count key, got %q", view.sortMode)
 	}
 }
 
@@ -325,6 +328,226 @@ func TestExecMenuKeepsStateForUnsupportedAction(t *testing.T) {
 	}
 }
 
+func TestExecMenuDeleteSkipsConfirmationWhenPolicyAllows(t *testing.T) {
+	prev := ConfirmAction
+	ConfirmAction = func(action string) bool { return action != "delete" }
+	t.Cleanup(func() { ConfirmAction = prev })
+
+	view := New(resources.NewWorkloads(), resources.DefaultRegistry())
+	view.SetSize(120, 40)
+	view.Update(keyRunes('x'))
+	view.Update(keyRunes('d'))
+	if view.execState != execNone || !strings.HasPrefix(view.execResult, "deleted ") {
+		t.Fatalf("expected delete without confirmation, got state %v result %q", view.execState, view.execResult)
+	}
+
+	view.Update(keyRunes('x'))
+	view.Update(keyRunes('r'))
+	if view.execState != execConfirmRestart {
+		t.Fatalf("expected restart to still ask, got %v", view.execState)
+	}
+}
+
+type fakeNodeOperator struct {
+	*resources.Nodes
+	schedulable []bool
+}
+
+func (f *fakeNodeOperator) SetNodeSchedulable(ctx context.Context, item resources.ResourceItem, schedulable bool) error {
+	f.schedulable = append(f.schedulable, schedulable)
+	return nil
+}
+
+func (f *fakeNodeOperator) DrainNode(ctx context.Context, item resources.ResourceItem, opts resources.DrainOptions, onEvent func(resources.DrainEvent)) error {
+	return nil
+}
+
+func TestExecMenuNodeActions(t *testing.T) {
+	nodes := &fakeNodeOperator{Nodes: &resources.Nodes{}}
+	view := New(nodes, resources.DefaultRegistry())
+	view.SetSize(120, 40)
+
+	view.Update(keyRunes('x'))
+	if footer := ansi.Strip(view.Footer()); !strings.Contains(footer, "cordon") || !strings.Contains(footer, "drain") {
+		t.Fatalf("expected node actions in exec menu, got %q", footer)
+	}
+	update := view.Update(keyRunes('c'))
+	if update.Cmd == nil {
+		t.Fatal("expected cordon command")
+	}
+	view.Update(update.Cmd())
+	if len(nodes.schedulable) != 1 || nodes.schedulable[0] {
+		t.Fatalf("expected node to be cordoned, got %v", nodes.schedulable)
+	}
+	if footer := ansi.Strip(view.Footer()); !strings.Contains(footer, "cordoned node/") {
+		t.Fatalf("expected cordon result in footer, got %q", footer)
+	}
+
+	view.Update(keyRunes('x'))
+	view.Update(keyRunes('n'))
+	if view.execState != execConfirmDrain {
+		t.Fatalf("expected drain confirmation, got %v", view.execState)
+	}
+	view.Update(keyRunes('e'))
+	if footer := ansi.Strip(view.Footer()); !strings.Contains(footer, "delete emptyDir data") {
+		t.Fatalf("expected emptyDir option in footer, got %q", footer)
+	}
+	update = view.Update(keyRunes('y'))
+	drain, ok := update.Next.(*drainview.View)
+	if update.Action != viewstate.Push || !ok {
+		t.Fatalf("expected drain view push, got %v %T", update.Action, update.Next)
+	}
+	if drain.Breadcrumb() != "drain" {
+		t.Fatalf("unexpected breadcrumb %q", drain.Breadcrumb())
+	}
+}
+
+func TestExecMenuDrainOffersEmptyDirWithoutConfirmation(t *testing.T) {
+	prev := ConfirmAction
+	ConfirmAction = func(action string) bool { return action != "drain" }
+	t.Cleanup(func() { ConfirmAction = prev })
+
+	view := New(&fakeNodeOperator{Nodes: &resources.Nodes{}}, resources.DefaultRegistry())
+	view.SetSize(120, 40)
+	view.Update(keyRunes('x'))
+	view.Update(keyRunes('n'))
+	if view.execState != execConfirmDrain {
+		t.Fatalf("expected the drain options, got %v", view.execState)
+	}
+	view.Update(keyRunes('e'))
+	footer := ansi.Strip(view.Footer())
+	if !strings.Contains(footer, "delete emptyDir data") || strings.Contains(footer, "confirm") {
+		t.Fatalf("expected emptyDir option without a confirmation, got %q", footer)
+	}
+	if update := view.Update(keyRunes('y')); update.Action == viewstate.Push {
+		t.Fatal("expected y not to start an unconfirmed drain")
+	}
+	update := view.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if _, ok := update.Next.(*drainview.View); update.Action != viewstate.Push || !ok || !view.drainEmptyDir {
+		t.Fatalf("expected enter to start the drain deleting emptyDir data, got %v %T", update.Action, update.Next)
+	}
+}
+
+func TestExecMenuHidesNodeActionsForOtherResources(t *testing.T) {
+	view := New(resources.NewWorkloads(), resources.DefaultRegistry())
+	view.SetSize(120, 40)
+	view.Update(keyRunes('x'))
+	if footer := ansi.Strip(view.Footer()); strings.Contains(footer, "cordon") {
+		t.Fatalf("unexpected node actions for workloads: %q", footer)
+	}
+}
+
+type fakeCronJobTrigger struct {
+	*resources.Workloads
+	triggered []string
+}
+
+func (f *fakeCronJobTrigger) TriggerCronJob(ctx context.Context, item resources.ResourceItem) (resources.ResourceItem, error) {
+	f.triggered = append(f.triggered, item.Name)
+	return resources.ResourceItem{Name: item.Name + "-manual-1", Kind: "JOB", Status: "Progressing", Ready: "0/1", Age: "0s"}, nil
+}
+
+func TestExecMenuTriggerCronJobOpensJobPods(t *testing.T) {
+	workloads := &fakeCronJobTrigger{Workloads: resources.NewWorkloads()}
+	view := New(workloads, resources.DefaultRegistry())
+	view.SetSize(120, 40)
+
+	view.Update(keyRunes('x'))
+	if footer := ansi.Strip(view.Footer()); strings.Contains(footer, "trigger") {
+		t.Fatalf("trigger offered for a non-cronjob row: %q", footer)
+	}
+	view.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEsc})
+
+	for i, listItem := range view.list.Items() {
+		if listItem.(item).data.Kind == "CJ" {
+			view.list.Select(i)
+			break
+		}
+	}
+	view.Update(keyRunes('x'))
+	if footer := ansi.Strip(view.Footer()); !strings.Contains(footer, "trigger") {
+		t.Fatalf("expected trigger in exec menu, got %q", footer)
+	}
+	update := view.Update(keyRunes('t'))
+	if update.Cmd == nil {
+		t.Fatal("expected trigger command")
+	}
+	update = view.Update(update.Cmd())
+	if len(workloads.triggered) != 1 {
+		t.Fatalf("expected one trigger, got %v", workloads.triggered)
+	}
+	pods, ok := update.Next.(*View)
+	if update.Action != viewstate.Push || !ok {
+		t.Fatalf("expected pod list push, got %v %T", update.Action, update.Next)
+	}
+	if name := pods.resource.Name(); name != "pods ("+workloads.triggered[0]+"-manual-1)" {
+		t.Fatalf("unexpected pushed resource %q", name)
+	}
+}
+
+type fakeDebugPods struct {
+	*resources.Pods
+	target, image string
+}
+
+func (f *fakeDebugPods) AddDebugContainer(ctx context.Context, pod resources.ResourceItem, target, image string) (string, error) {
+	f.target, f.image = target, image
+	return "debugger-1", nil
+}
+
+func TestExecMenuDebugContainerPromptsForImage(t *testing.T) {
+	t.Setenv("PODJI_DEBUG_IMAGE", "")
+	pods := &fakeDebugPods{Pods: resources.NewPods()}
+	view := New(pods, resources.DefaultRegistry())
+	view.SetSize(120, 40)
+
+	view.Update(keyRunes('x'))
+	if footer := ansi.Strip(view.Footer()); !strings.Contains(footer, "debug") {
+		t.Fatalf("expected debug in exec menu, got %q", footer)
+	}
+	view.Update(keyRunes('b'))
+	if view.execState != execInputDebugImage || view.execInput != DefaultDebugImage {
+		t.Fatalf("expected image prompt with default, got %v %q", view.execState, view.execInput)
+	}
+	view.execInput = "nicolaka/netshoot"
+	update := view.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if update.Cmd == nil {
+		t.Fatal("expected debug command")
+	}
+	msg, ok := update.Cmd().(debugContainerReadyMsg)
+	if !ok || msg.err != nil || msg.container != "debugger-1" {
+		t.Fatalf("unexpected ready message %#v", msg)
+	}
+	if pods.image != "nicolaka/netshoot" {
+		t.Fatalf("expected typed image, got %q", pods.image)
+	}
+
+	msg.pod.Namespace = "shop"
+	args := strings.Join(view.debugAttachArgs(msg.pod, msg.container), " ")
+	if args != "-n shop attach -it "+msg.pod.Name+" -c debugger-1" {
+		t.Fatalf("unexpected attach args %q", args)
+	}
+}
+
+func TestDebugTargetUsesSelectedAppContainer(t *testing.T) {
+	pods := &fakeDebugPods{Pods: resources.NewPods()}
+	pod := pods.Items()[0]
+	view := New(resources.NewContainerResource(pod, pods), resources.DefaultRegistry())
+	view.SetSize(120, 40)
+
+	for i, listItem := range view.list.Items() {
+		if listItem.(item).data.Kind == "app" {
+			view.list.Select(i)
+			break
+		}
+	}
+	_, target, name, ok := view.debugTarget()
+	selected := view.list.SelectedItem().(item).data
+	if !ok || target.Name != pod.Name || name != selected.Name {
+		t.Fatalf("expected pod %s and container %s, got %s %q (ok=%v)", pod.Name, selected.Name, target.Name, name, ok)
+	}
+}
+
 func TestPortForwardArgsForPodUsesResourceNamespace(t *testing.T) {
 	registry := resources.DefaultRegistry()
 	view := New(resources.NewPods(), registry)
@@ -938,3 +1161,54 @@ func keyEnter() bubbletea.KeyMsg {
 func keyDown() bubbletea.KeyMsg {
 	return bubbletea.KeyMsg{Type: bubbletea.KeyDown}
 }
+
+func TestCronJobTriggerShowsLivePodsOfJob(t *testing.T) {
+	cronJob := resources.ResourceItem{UID: "cj-uid-1", Name: "backup", Kind: "CJ"}
+	view := New(fakeLiveListResource{
+		name:  "cronjobs",
+		items: []resources.ResourceItem{cronJob},
+		lists: map[string][]resources.ResourceItem{
+			"pods": {
+				{Name: "backup-manual-abcde", Namespace: "default", Labels: map[string]string{"job-name": "backup-manual"}},
+				{Name: "api-1", Namespace: "default", Labels: map[string]string{"app": "api"}},
+			},
+		},
+	}, resources.DefaultRegistry())
+
+	job := resources.ResourceItem{
+		UID:      "job-uid-1",
+		Name:     "backup-manual",
+		Kind:     "JOB",
+		Selector: map[string]string{"job-name": "backup-manual"},
+	}
+	update := view.Update(cronJobTriggerResultMsg{job: job})
+	if update.Action != viewstate.Push {
+		t.Fatalf("expected push into the job's pods, got %v", update.Action)
+	}
+	items := update.Next.(*View).resource.Items()
+	if len(items) != 1 || items[0].Name != "backup-manual-abcde" {
+		t.Fatalf("expected the live pod of the triggered job, got %#v", items)
+	}
+}
+
+func TestColumnLayoutIsSavedUnderTheBaseListName(t *testing.T) {
+	prev := columnconfig.Default()
+	store, err := columnconfig.Open(t.TempDir() + "/columns.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	columnconfig.SetDefault(store)
+	t.Cleanup(func() { columnconfig.SetDefault(prev) })
+
+	view := New(resources.NewNodePods("worker-1"), resources.DefaultRegistry())
+	if cmd := view.ApplyColumnConfig("pods", []string{"name", "status"}, false); cmd != nil {
+		t.Fatalf("unexpected save error %q", view.actionMsg)
+	}
+	if !store.IsCustom("pods") || store.IsCustom("pods (node: worker-1)") {
+		t.Fatal("expected the layout to be saved for pods, not for the node's pods")
+	}
+	pods := New(resources.NewPods(), resources.DefaultRegistry())
+	if got := columnIDs(pods.columns); !reflect.DeepEqual(got, []string{"name", "status"}) {
+		t.Fatalf("expected the pods list to share the layout, got %v", got)
+	}
+}
@@ -10,21 +10,17 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/ui/style"
 )
 
 var podGeneratedSegmentRE = regexp.MustCompile(`^[a-z0-9]{4,6}$`)
 
-const podSuffixMutedANSI = "\x1b[38;5;247m"
-
 func newTableDelegate(findMode *bool, findTargets *map[int]bool) tableDelegate {
 	delegate := list.NewDefaultDelegate()
 	delegate.SetHeight(1)
 	delegate.SetSpacing(0)
 	delegate.ShowDescription = false
-	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Bold(true).
-		Foreground(lipgloss.Color("15")).
-		Background(lipgloss.Color("236")).
+	delegate.Styles.SelectedTitle = style.SelectedRow(delegate.Styles.SelectedTitle).
 		BorderLeft(true).
 		BorderStyle(lipgloss.Border{Left: "▌"})
 	return tableDelegate{DefaultDelegate: delegate, findMode: findMode, findTargets: findTargets}
@@ -89,11 +85,19 @@ func renderRowWithNameMatch(
 	for idx, value := range it.row {
 		width := it.widths[idx]
 		cellValue := padCell(value, width)
+		isStatus := it.isStatusCell(idx)
+		if isStatus {
+			cellValue = it.statusCell(idx, value, width)
+		}
 		if idx == matchColumn {
 			cellValue = dimPodGeneratedSuffix(cellValue, it.data.Name, it.dimPodName, isSelected, len(matches) > 0)
 		}
 
-		if localMatches := visibleMatchesForCell(cellValue, columnMatches[idx]); len(localMatches) > 0 {
+		cellMatches := columnMatches[idx]
+		if isStatus && idx == it.statusColumn {
+			cellMatches = shiftMatches(cellMatches, style.StatusSymbolWidth)
+		}
+		if localMatches := visibleMatchesForCell(cellValue, cellMatches); len(localMatches) > 0 {
 			cellValue = lipgloss.StyleRunes(cellValue, localMatches, matchStyle, unmatchedStyle)
 		}
 
@@ -101,7 +105,7 @@ func renderRowWithNameMatch(
 			cellValue = underlineFirstChar(cellValue)
 		}
 
-		if idx > 0 && it.status != "" && it.row[idx] == it.status {
+		if isStatus {
 			cellValue = inlineStatusStyle(cellValue, isSelected)
 		}
 
@@ -135,6 +139,18 @@ func splitMatchesByColumn(row []string, matches []int) [][]int {
 	return perColumn
 }
 
+// shiftMatches moves match positions right by n, past a cell's symbol.
+func shiftMatches(matches []int, n int) []int {
+	if len(matches) == 0 {
+		return nil
+	}
+	out := make([]int, len(matches))
+	for i, pos := range matches {
+		out[i] = pos + n
+	}
+	return out
+}
+
 func visibleMatchesForCell(cellValue string, matches []int) []int {
 	if len(matches) == 0 {
 		return nil
@@ -149,17 +165,16 @@ func visibleMatchesForCell(cellValue string, matches []int) []int {
 	return out
 }
 
-// underlineFirstChar applies underline + bright foreground to the first
-// visible character of value using raw ANSI sequences so it composes with
-// existing row styling.
+// underlineFirstChar underlines and brightens the first visible character
+// of value using raw ANSI sequences so it composes with existing row styling.
 func underlineFirstChar(value string) string {
 	runes := []rune(value)
 	if len(runes) == 0 {
 		return value
 	}
-	// \x1b[4m = underline on, \x1b[1m = bold, \x1b[97m = bright white fg
-	// \x1b[22;24;39m = bold off, underline off, default fg
-	return "\x1b[4;1;97m" + string(runes[0]) + "\x1b[22;24;39m" + string(runes[1:])
+	// 4 = underline on, 1 = bold; 22;24;39 = bold off, underline off,
+	// default fg.
+	return style.SGR(style.Current().FindTarget, "4", "1") + string(runes[0]) + "\x1b[22;24;39m" + string(runes[1:])
 }
 
 // inlineStatusStyle applies status coloring. When preserveBg is true it uses
@@ -169,7 +184,7 @@ func inlineStatusStyle(value string, preserveBg bool) string {
 	if !preserveBg {
 		return statusStyle(value)
 	}
-	code := statusANSI(value)
+	code := style.StatusSGR(value)
 	if code == "" {
 		return value
 	}
@@ -178,31 +193,6 @@ func inlineStatusStyle(value string, preserveBg bool) string {
 	return code + value + "\x1b[22;39m"
 }
 
-// statusANSI returns raw ANSI SGR sequences for the status foreground color
-// (with bold). Returns "" for unrecognised statuses.
-func statusANSI(value string) string {
-	trimmed := strings.ToLower(strings.TrimSpace(value))
-	switch {
-	case strings.Contains(trimmed, "crashloop"),
-		strings.Contains(trimmed, "error"),
-		strings.Contains(trimmed, "fail"),
-		strings.Contains(trimmed, "oom"),
-		strings.Contains(trimmed, "backoff"):
-		return "\x1b[1;31m" // bold red
-	case strings.Contains(trimmed, "pending"),
-		strings.Contains(trimmed, "warning"),
-		strings.Contains(trimmed, "degraded"),
-		strings.Contains(trimmed, "progress"),
-		strings.Contains(trimmed, "terminat"),
-		strings.Contains(trimmed, "unknown"):
-		return "\x1b[1;33m" // bold yellow
-	case strings.Contains(trimmed, "suspend"):
-		return "\x1b[38;5;241m" // muted (no bold)
-	default:
-		return "\x1b[92m" // bright green
-	}
-}
-
 func dimPodGeneratedSuffix(cellValue, podName string, enabled, isSelected, hasMatches bool) string {
 	if !enabled || isSelected || hasMatches {
 		return cellValue
@@ -218,7 +208,7 @@ func dimPodGeneratedSuffix(cellValue, podName string, enabled, isSelected, hasMa
 	if end > len(runes) {
 		end = len(runes)
 	}
-	return string(runes[:start]) + podSuffixMutedANSI + string(runes[start:end]) + "\x1b[39m" + string(runes[end:])
+	return string(runes[:start]) + style.SGR(style.Current().Dim) + string(runes[start:end]) + "\x1b[39m" + string(runes[end:])
 }
 
 // podGeneratedSuffixRange returns the [start,end) rune range for generated pod
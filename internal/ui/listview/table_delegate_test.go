@@ -123,3 +123,27 @@ func TestRenderRowDimsGeneratedPodSuffixButKeepsOrdinalTail(t *testing.T) {
 		t.Fatalf("expected ordinal tail to remain undimmed, got %q", row)
 	}
 }
+
+func TestStatusColumnShowsSeveritySymbolWithinWidth(t *testing.T) {
+	columns := []resources.TableColumn{
+		{ID: "name", Name: "NAME"},
+		{ID: "status", Name: "STATUS"},
+		{ID: "age", Name: "AGE"},
+	}
+	rows := [][]string{{"api", "CrashLoopBackOff", "5m"}, {"db", "Running", "2d"}}
+	widths := columnWidthsForRows(columns, rows, 80, "")
+	if widths[1] != len("CrashLoopBackOff")+2 {
+		t.Fatalf("expected room for the status symbol, got width %d", widths[1])
+	}
+
+	items := makeListItems(resources.NewPods(), []resources.ResourceItem{
+		{Name: "api", Status: "CrashLoopBackOff"},
+		{Name: "db", Status: "Running"},
+	}, rows, widths, columns)
+	for idx, want := range []string{"✗ CrashLoopBackOff", "● Running         "} {
+		row := ansi.Strip(renderRowWithNameMatch(items[idx].(item), false, nil, lipgloss.NewStyle(), lipgloss.NewStyle(), false))
+		if !strings.Contains(row, want+columnSeparator) {
+			t.Fatalf("expected %q in row, got %q", want, row)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package logview
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/ui/style"
+)
+
+// foldBlock is a stack trace: a header line followed by the continuation lines
+// that belong to it. start is the header index, end is exclusive.
+type foldBlock struct {
+	start int
+	end   int
+}
+
+func (b foldBlock) hidden() int { return b.end - b.start - 1 }
+
+// minFoldLines is the smallest number of continuation lines worth folding; a
+// single "at ..." line reads better inline than behind a marker.
+const minFoldLines = 2
+
+var pythonExceptionLine = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*(Error|Exception|Warning|Exit|Interrupt)\b`)
+
+// detectFoldBlocks groups Java and Python stack traces in lines into blocks.
+// Continuation lines are indented "at ..." frames, "Caused by:"/"... N more"
+// markers, and Python "Traceback" sections up to and including the final
+// exception line.
+func detectFoldBlocks(lines []string) []foldBlock {
+	var blocks []foldBlock
+	inTraceback := false
+	start := -1
+	closeBlock := func(end int) {
+		if start >= 0 && end-start-1 >= minFoldLines {
+			blocks = append(blocks, foldBlock{start: start, end: end})
+		}
+		start = -1
+		inTraceback = false
+	}
+	for i, line := range lines {
+		text := foldText(line)
+		cont, traceback, last := stackContinuation(text, inTraceback)
+		if !cont {
+			closeBlock(i)
+			continue
+		}
+		if start < 0 {
+			start = i - 1
+			if start < 0 || resources.IsLogBoundary(ansi.Strip(lines[start])) {
+				// No header to hang the trace on: the first frame heads it.
+				start = i
+			}
+		}
+		if traceback {
+			inTraceback = true
+		}
+		if last {
+			inTraceback = false
+		}
+	}
+	closeBlock(len(lines))
+	return blocks
+}
+
+// stackContinuation reports whether text continues the stack trace above it,
+// whether it opens a Python traceback, and whether it is the exception line
+// that closes one.
+func stackContinuation(text string, inTraceback bool) (cont, traceback, last bool) {
+	trimmed := strings.TrimLeft(text, " \t")
+	if trimmed == "" {
+		return false, false, false
+	}
+	indented := len(trimmed) < len(text)
+	switch {
+	case strings.HasPrefix(trimmed, "Traceback (most recent call last)"):
+		return true, true, false
+	case strings.HasPrefix(trimmed, "During handling of the above exception"),
+		strings.HasPrefix(trimmed, "The above exception was the direct cause"):
+		return true, false, false
+	case strings.HasPrefix(trimmed, "Caused by:"):
+		return true, false, false
+	case strings.HasPrefix(trimmed, "... ") && (strings.HasSuffix(trimmed, " more") || strings.Contains(trimmed, "omitted")):
+		return true, false, false
+	case indented && (strings.HasPrefix(trimmed, "at ") || strings.HasPrefix(trimmed, "Suppressed:")):
+		return true, false, false
+	}
+	if inTraceback {
+		if pythonExceptionLine.MatchString(trimmed) {
+			return true, false, true
+		}
+		return indented, false, false
+	}
+	return false, false, false
+}
+
+// foldText strips styling and the timestamp prefix from a log line while
+// keeping the indentation of the message itself.
+func foldText(line string) string {
+	text := ansi.Strip(line)
+	prefix, rest, found := strings.Cut(text, " ")
+	if !found {
+		return text
+	}
+	if _, err := time.Parse(time.RFC3339Nano, prefix); err != nil {
+		return text
+	}
+	return rest
+}
+
+// foldKey identifies a block across refreshes by the buffer ID of its header,
+// so identical traces fold independently and keep their state as the ring
+// rolls. Folds are only detected on unfiltered windows, whose lines map one to
+// one onto the buffer.
+func (v *View) foldKey(b foldBlock) int {
+	from := v.buf.Spilled()
+	if v.inHistory {
+		from = v.historyFrom
+	}
+	return v.buf.ID(from + b.start)
+}
+
+func foldMarker(hidden int) string {
+	return style.SGR(style.Current().Label) + "  ⋯ +" + strconv.Itoa(hidden) + " lines" + timestampPrefixReset
+}
+
+// buildDisplay lays out v.lines with collapsed blocks replaced by a marker row.
+// For every display row it records the block it belongs to (-1 for none), and
+// for marker rows the hidden lines so search can still reach them.
+func (v *View) buildDisplay() {
+	v.display = v.display[:0]
+	v.displayBlock = v.displayBlock[:0]
+	v.displayHidden = make(map[int][]string)
+	next := 0
+	for bi, b := range v.folds {
+		for i := next; i < b.start; i++ {
+			v.display = append(v.display, v.lines[i])
+			v.displayBlock = append(v.displayBlock, -1)
+		}
+		if v.expanded[v.foldKey(b)] {
+			for i := b.start; i < b.end; i++ {
+				v.display = append(v.display, v.lines[i])
+				v.displayBlock = append(v.displayBlock, bi)
+			}
+		} else {
+			v.display = append(v.display, v.lines[b.start])
+			v.displayBlock = append(v.displayBlock, bi)
+			v.displayHidden[len(v.display)] = v.lines[b.start+1 : b.end]
+			v.display = append(v.display, foldMarker(b.hidden()))
+			v.displayBlock = append(v.displayBlock, bi)
+		}
+		next = b.end
+	}
+	for i := next; i < len(v.lines); i++ {
+		v.display = append(v.display, v.lines[i])
+		v.displayBlock = append(v.displayBlock, -1)
+	}
+}
+
+// displayRowStarts returns the viewport row where each display line begins,
+// accounting for wrapping.
+func (v *View) displayRowStarts() []int {
+	starts := make([]int, len(v.display))
+	row := 0
+	for i, line := range v.display {
+		starts[i] = row
+		if v.wrap && v.viewport.Width > 0 {
+			row += len(wrapLine(line, v.viewport.Width))
+		} else {
+			row++
+		}
+	}
+	return starts
+}
+
+// blockAtCursor picks the block to toggle: the one holding the current search
+// match, otherwise the first block with a row on screen.
+func (v *View) blockAtCursor(starts []int) int {
+	rowBlock := func(row int) int {
+		for i := len(starts) - 1; i >= 0; i-- {
+			if starts[i] <= row {
+				return v.displayBlock[i]
+			}
+		}
+		return -1
+	}
+	if len(v.matchLines) > 0 && v.matchIndex < len(v.matchLines) {
+		if bi := rowBlock(v.matchLines[v.matchIndex]); bi >= 0 {
+			return bi
+		}
+	}
+	top := v.viewport.YOffset
+	bottom := top + v.viewport.Height
+	for i, bi := range v.displayBlock {
+		if bi >= 0 && starts[i] >= top && starts[i] < bottom {
+			return bi
+		}
+	}
+	// A block taller than the screen may start above it.
+	return rowBlock(top)
+}
+
+// toggleFold expands or collapses the block under the cursor, keeping its
+// header on screen.
+func (v *View) toggleFold() {
+	if len(v.folds) == 0 {
+		return
+	}
+	bi := v.blockAtCursor(v.displayRowStarts())
+	if bi < 0 {
+		return
+	}
+	key := v.foldKey(v.folds[bi])
+	if v.expanded[key] {
+		delete(v.expanded, key)
+	} else {
+		v.expanded[key] = true
+	}
+	v.buildDisplay()
+	v.viewport.SetContent(v.renderedContent())
+	starts := v.displayRowStarts()
+	for i, b := range v.displayBlock {
+		if b == bi {
+			if starts[i] < v.viewport.YOffset {
+				v.viewport.SetYOffset(starts[i])
+			}
+			break
+		}
+	}
+	v.recomputeMatches()
+}
+
+// toggleAllFolds expands every block, or collapses them all when none is
+// collapsed.
+func (v *View) toggleAllFolds() {
+	if len(v.folds) == 0 {
+		return
+	}
+	collapsed := false
+	for _, b := range v.folds {
+		if !v.expanded[v.foldKey(b)] {
+			collapsed = true
+			break
+		}
+	}
+	for _, b := range v.folds {
+		key := v.foldKey(b)
+		if collapsed {
+			v.expanded[key] = true
+		} else {
+			delete(v.expanded, key)
+		}
+	}
+	v.buildDisplay()
+	v.viewport.SetContent(v.renderedContent())
+	v.recomputeMatches()
+}
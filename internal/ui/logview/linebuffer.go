@@ -0,0 +1,313 @@
+package logview
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// maxBufferedLines bounds how many log lines the view keeps in memory. Older
+// lines spill to a temp file and stay reachable through search.
+const maxBufferedLines = 5000
+
+// spillBlockLines is the granularity of the spill index. Postings point at
+// blocks rather than single lines to keep the index small for long sessions.
+const spillBlockLines = 64
+
+// maxIndexPostings bounds the spill index. When a long session outgrows it,
+// adjacent blocks are merged so the index keeps covering every spilled line at
+// a coarser granularity.
+const maxIndexPostings = 1 << 20
+
+// lineBuffer is a ring of the most recent log lines. When the ring is full the
+// oldest line is appended to a temp file instead of being dropped, and its
+// trigrams are recorded in an inverted index so spilled lines can be searched
+// without rescanning the file.
+type lineBuffer struct {
+	limit int
+	ring  []string
+	head  int
+	size  int
+	// evicted counts lines pushed out of the ring, spilled or not.
+	evicted int
+
+	file    *os.File
+	w       *bufio.Writer
+	offsets []int64
+	pos     int64
+	index   map[string][]int32
+	// postings counts the index entries; shift is how many times blocks
+	// have been merged to stay under maxIndexPostings.
+	postings int
+	shift    uint
+	err      error
+}
+
+func newLineBuffer(limit int) *lineBuffer {
+	if limit <= 0 {
+		limit = maxBufferedLines
+	}
+	return &lineBuffer{limit: limit}
+}
+
+// Reset discards all buffered and spilled lines and loads lines in order.
+func (b *lineBuffer) Reset(lines []string) {
+	b.Close()
+	b.ring = nil
+	b.head = 0
+	b.size = 0
+	b.evicted = 0
+	for _, line := range lines {
+		b.Append(line)
+	}
+}
+
+// Append adds a line, spilling the oldest in-memory line when the ring is full.
+func (b *lineBuffer) Append(line string) {
+	if b.ring == nil {
+		b.ring = make([]string, b.limit)
+	}
+	if b.size < b.limit {
+		b.ring[(b.head+b.size)%b.limit] = line
+		b.size++
+		return
+	}
+	b.spill(b.ring[b.head])
+	b.evicted++
+	b.ring[b.head] = line
+	b.head = (b.head + 1) % b.limit
+}
+
+// Len returns the total number of lines, spilled and in memory.
+func (b *lineBuffer) Len() int {
+	return len(b.offsets) + b.size
+}
+
+// Spilled returns how many of the oldest lines live on disk.
+func (b *lineBuffer) Spilled() int {
+	return len(b.offsets)
+}
+
+// ID returns an identity for line i that stays with the line as the ring
+// rolls, including when older lines were dropped because spilling failed.
+func (b *lineBuffer) ID(i int) int {
+	if i < b.Spilled() {
+		return i
+	}
+	return i - b.Spilled() + b.evicted
+}
+
+// Recent returns the in-memory lines, oldest first.
+func (b *lineBuffer) Recent() []string {
+	out := make([]string, b.size)
+	for i := 0; i < b.size; i++ {
+		out[i] = b.ring[(b.head+i)%b.limit]
+	}
+	return out
+}
+
+// Range returns lines [from, to) across the spilled and in-memory portions.
+func (b *lineBuffer) Range(from, to int) []string {
+	if from < 0 {
+		from = 0
+	}
+	if to > b.Len() {
+		to = b.Len()
+	}
+	if from >= to {
+		return nil
+	}
+	out := make([]string, 0, to-from)
+	if spilled := b.Spilled(); from < spilled {
+		end := to
+		if end > spilled {
+			end = spilled
+		}
+		out = append(out, b.readSpilled(from, end)...)
+		from = end
+	}
+	for i := from; i < to; i++ {
+		out = append(out, b.ring[(b.head+i-b.Spilled())%b.limit])
+	}
+	return out
+}
+
+// SearchSpilled returns the indexes of spilled lines containing query
+// (case-insensitive), in ascending order.
+func (b *lineBuffer) SearchSpilled(query string) []int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" || b.Spilled() == 0 {
+		return nil
+	}
+	blocks := b.candidateBlocks(query)
+	var hits []int
+	for _, block := range blocks {
+		from := int(block) * b.blockLines()
+		to := from + b.blockLines()
+		if to > b.Spilled() {
+			to = b.Spilled()
+		}
+		for i, line := range b.readSpilled(from, to) {
+			if strings.Contains(strings.ToLower(line), query) {
+				hits = append(hits, from+i)
+			}
+		}
+	}
+	return hits
+}
+
+// Close removes the spill file.
+func (b *lineBuffer) Close() {
+	if b.file != nil {
+		name := b.file.Name()
+		_ = b.file.Close()
+		_ = os.Remove(name)
+	}
+	b.file = nil
+	b.w = nil
+	b.offsets = nil
+	b.pos = 0
+	b.index = nil
+	b.postings = 0
+	b.shift = 0
+	b.err = nil
+}
+
+func (b *lineBuffer) spill(line string) {
+	if b.err != nil {
+		return
+	}
+	if b.file == nil {
+		f, err := os.CreateTemp("", "podji-logs-*")
+		if err != nil {
+			// Without a spill file the buffer degrades to a plain ring.
+			b.err = err
+			return
+		}
+		b.file = f
+		b.w = bufio.NewWriter(f)
+		b.index = make(map[string][]int32)
+	}
+	line = strings.ReplaceAll(line, "\n", " ")
+	n, err := b.w.WriteString(line + "\n")
+	if err != nil {
+		b.err = err
+		return
+	}
+	lineNo := len(b.offsets)
+	b.offsets = append(b.offsets, b.pos)
+	b.pos += int64(n)
+
+	block := int32(lineNo / b.blockLines())
+	for _, gram := range trigrams(strings.ToLower(line)) {
+		postings := b.index[gram]
+		if len(postings) > 0 && postings[len(postings)-1] == block {
+			continue
+		}
+		b.index[gram] = append(postings, block)
+		b.postings++
+	}
+	for b.postings > maxIndexPostings && b.shift < 16 {
+		b.coarsen()
+	}
+}
+
+// blockLines is the number of spilled lines an index block covers.
+func (b *lineBuffer) blockLines() int {
+	return spillBlockLines << b.shift
+}
+
+// coarsen merges each pair of adjacent blocks, roughly halving the postings.
+func (b *lineBuffer) coarsen() {
+	b.shift++
+	b.postings = 0
+	for gram, postings := range b.index {
+		merged := postings[:0]
+		for _, block := range postings {
+			block >>= 1
+			if len(merged) > 0 && merged[len(merged)-1] == block {
+				continue
+			}
+			merged = append(merged, block)
+		}
+		b.index[gram] = merged
+		b.postings += len(merged)
+	}
+}
+
+func (b *lineBuffer) readSpilled(from, to int) []string {
+	if b.file == nil || from >= to {
+		return nil
+	}
+	if err := b.w.Flush(); err != nil {
+		return nil
+	}
+	end := b.pos
+	if to < len(b.offsets) {
+		end = b.offsets[to]
+	}
+	start := b.offsets[from]
+	buf := make([]byte, end-start)
+	if _, err := b.file.ReadAt(buf, start); err != nil && err != io.EOF {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(buf), "\n"), "\n")
+}
+
+// candidateBlocks narrows a search to blocks that may contain query. A line
+// containing query contains each of its trigrams, so the intersection of their
+// postings is a superset of the real hits. Queries shorter than a trigram fall
+// back to every block.
+func (b *lineBuffer) candidateBlocks(query string) []int32 {
+	grams := trigrams(query)
+	if len(grams) == 0 {
+		total := int32((b.Spilled() + b.blockLines() - 1) / b.blockLines())
+		out := make([]int32, total)
+		for i := range out {
+			out[i] = int32(i)
+		}
+		return out
+	}
+	sort.Slice(grams, func(i, j int) bool { return len(b.index[grams[i]]) < len(b.index[grams[j]]) })
+	out := append([]int32(nil), b.index[grams[0]]...)
+	for _, gram := range grams[1:] {
+		out = intersectBlocks(out, b.index[gram])
+		if len(out) == 0 {
+			break
+		}
+	}
+	return out
+}
+
+// intersectBlocks keeps the blocks of a that are also in b; both are sorted.
+func intersectBlocks(a, b []int32) []int32 {
+	out := a[:0]
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// trigrams returns the three-rune substrings of s, in order.
+func trigrams(s string) []string {
+	runes := []rune(s)
+	if len(runes) < 3 {
+		return nil
+	}
+	out := make([]string, 0, len(runes)-2)
+	for i := 0; i+3 <= len(runes); i++ {
+		out = append(out, string(runes[i:i+3]))
+	}
+	return out
+}
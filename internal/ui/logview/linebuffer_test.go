@@ -0,0 +1,121 @@
+package logview
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestLineBufferSpillsOldestLines(t *testing.T) {
+	b := newLineBuffer(3)
+	defer b.Close()
+	for i := 0; i < 5; i++ {
+		b.Append(fmt.Sprintf("line-%d", i))
+	}
+
+	if got := b.Len(); got != 5 {
+		t.Fatalf("expected 5 lines, got %d", got)
+	}
+	if got := b.Spilled(); got != 2 {
+		t.Fatalf("expected 2 spilled lines, got %d", got)
+	}
+	recent := b.Recent()
+	if len(recent) != 3 || recent[0] != "line-2" || recent[2] != "line-4" {
+		t.Fatalf("unexpected recent lines: %v", recent)
+	}
+	all := b.Range(0, b.Len())
+	for i, line := range all {
+		if want := fmt.Sprintf("line-%d", i); line != want {
+			t.Fatalf("Range()[%d]=%q, want %q", i, line, want)
+		}
+	}
+}
+
+func TestLineBufferSearchSpilledUsesIndex(t *testing.T) {
+	b := newLineBuffer(2)
+	defer b.Close()
+	lines := []string{
+		"INFO starting",
+		"ERROR connection refused",
+		"INFO ready",
+		"WARN slow request",
+		"error: timeout talking to db",
+		"INFO tail-1",
+		"INFO tail-2",
+	}
+	b.Reset(lines)
+
+	hits := b.SearchSpilled("Error")
+	if len(hits) != 2 || hits[0] != 1 || hits[1] != 4 {
+		t.Fatalf("expected hits [1 4], got %v", hits)
+	}
+	if hits := b.SearchSpilled("rror: time"); len(hits) != 1 || hits[0] != 4 {
+		t.Fatalf("expected partial-token query to hit line 4, got %v", hits)
+	}
+	if hits := b.SearchSpilled(":"); len(hits) != 1 || hits[0] != 4 {
+		t.Fatalf("expected punctuation query to fall back to a scan, got %v", hits)
+	}
+	if hits := b.SearchSpilled("tail-2"); len(hits) != 0 {
+		t.Fatalf("expected in-memory lines to be excluded, got %v", hits)
+	}
+}
+
+func TestLineBufferCloseRemovesSpillFile(t *testing.T) {
+	b := newLineBuffer(1)
+	b.Append("a")
+	b.Append("b")
+	if b.file == nil {
+		t.Fatal("expected spill file to be created")
+	}
+	name := b.file.Name()
+	b.Close()
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Fatalf("expected spill file to be removed, stat err=%v", err)
+	}
+	if b.Spilled() != 0 {
+		t.Fatalf("expected spill state to be cleared, got %d", b.Spilled())
+	}
+}
+
+func TestLineBufferCoarsenedIndexStillFindsHits(t *testing.T) {
+	b := newLineBuffer(1)
+	defer b.Close()
+	for i := 0; i < 4*spillBlockLines; i++ {
+		line := fmt.Sprintf("INFO request %d served", i)
+		if i%100 == 7 {
+			line = fmt.Sprintf("ERROR request %d failed", i)
+		}
+		b.Append(line)
+	}
+	want := b.SearchSpilled("error request")
+	before := b.postings
+
+	b.coarsen()
+	b.coarsen()
+	if b.postings >= before {
+		t.Fatalf("expected coarsening to shrink the index, got %d -> %d postings", before, b.postings)
+	}
+	got := b.SearchSpilled("error request")
+	if len(got) != 3 || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected hits %v after coarsening, got %v", want, got)
+	}
+	b.Append("ERROR late")
+	if hits := b.SearchSpilled("error request 207"); len(hits) != 1 || hits[0] != 207 {
+		t.Fatalf("expected line 207, got %v", hits)
+	}
+}
+
+func TestLineBufferIDFollowsLinesWhenSpillingFails(t *testing.T) {
+	b := newLineBuffer(2)
+	defer b.Close()
+	b.err = os.ErrPermission
+	for i := 0; i < 5; i++ {
+		b.Append(fmt.Sprintf("line-%d", i))
+	}
+	if b.Spilled() != 0 || b.Len() != 2 {
+		t.Fatalf("expected a plain ring of 2 lines, got %d spilled / %d total", b.Spilled(), b.Len())
+	}
+	if got := b.ID(0); got != 3 {
+		t.Fatalf("expected line-3 to keep ID 3, got %d", got)
+	}
+}
@@ -3,6 +3,7 @@ package logview
 import (
 	"context"
 	"errors"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -20,7 +21,32 @@ import (
 
 var sinceWindows = []string{"1m", "5m", "15m", "1h", "all"}
 
-const timestampPrefixSGR = "\x1b[38;5;109m"
+// Options are the settings a log view starts with.
+type Options struct {
+	Since      string
+	Follow     bool
+	Wrap       bool
+	Timestamps bool
+}
+
+// Defaults are the starting settings of new log views. app.go applies the
+// logs section of the config file to them at startup.
+var Defaults = Options{Since: "5m", Follow: true, Wrap: true, Timestamps: true}
+
+// defaultSinceIdx returns the position of Defaults.Since in sinceWindows.
+func defaultSinceIdx() int {
+	for i, window := range sinceWindows {
+		if window == Defaults.Since {
+			return i
+		}
+	}
+	return 1
+}
+
+// historyPageLines is how many buffered lines are shown at once when paging
+// through spilled history.
+const historyPageLines = 1000
+
 const timestampPrefixReset = "\x1b[0m"
 
 type logReloadResultMsg struct {
@@ -43,13 +69,15 @@ type View struct {
 	item       resources.ResourceItem
 	resource   resources.ResourceType
 	container  string
-	allLines   []string
+	buf        *lineBuffer
 	lines      []string
 	viewport   viewport.Model
 	follow     bool
 	wrap       bool
 	previous   bool
 	timestamps bool
+	tsMode     timestampMode
+	tsTick     int
 	sinceIdx   int
 
 	searchActive bool
@@ -57,6 +85,9 @@ type View struct {
 	searchInput  textinput.Model
 	matchLines   []int
 	matchIndex   int
+	spillHits    []int
+	inHistory    bool
+	historyFrom  int
 	filterActive bool
 	filterQuery  string
 	filterValue  string
@@ -66,10 +97,29 @@ type View struct {
 	streamCh     <-chan bubbletea.Msg
 	streamErr    string
 
+	// folds are the stack traces found in lines; expanded holds the foldKey
+	// of the ones the user opened. display is lines with the collapsed
+	// blocks replaced by a marker row.
+	folds         []foldBlock
+	expanded      map[int]bool
+	display       []string
+	displayBlock  []int
+	displayHidden map[int][]string
+
+	// seekText and seekIndex are a line to scroll to once the view has a
+	// size; see SeekLine.
+	seekText  string
+	seekIndex int
+
 	// ContainerViewFactory, when set, is called to produce a container-picker
 	// view for the pod. Pressing c opens that picker so the user can switch
 	// containers without leaving the log view stack.
 	ContainerViewFactory func(item resources.ResourceItem, res resources.ResourceType) viewstate.View
+
+	// FollowWorkload marks a view opened for a workload rather than a single
+	// pod: follow mode then moves on to the replacement pod when the
+	// followed one is deleted.
+	FollowWorkload bool
 }
 
 func New(item resources.ResourceItem, resource resources.ResourceType) *View {
@@ -82,11 +132,13 @@ func NewWithContainer(item resources.ResourceItem, resource resources.ResourceTy
 		item:       item,
 		resource:   resource,
 		container:  container,
+		buf:        newLineBuffer(maxBufferedLines),
 		viewport:   vp,
-		follow:     true,
-		wrap:       true,
-		timestamps: true,
-		sinceIdx:   1, // default to 5m
+		follow:     Defaults.Follow,
+		wrap:       Defaults.Wrap,
+		timestamps: Defaults.Timestamps,
+		sinceIdx:   defaultSinceIdx(),
+		expanded:   make(map[int]bool),
 	}
 	v.filterInput = newPromptInput("& ")
 	v.searchInput = newPromptInput("/ ")
@@ -135,6 +187,7 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 				v.searchActive = false
 				v.searchInput.Blur()
 				v.recomputeMatches()
+				v.spillHits = v.buf.SearchSpilled(v.searchQuery)
 				if len(v.matchLines) > 0 {
 					v.matchIndex = 0
 					v.viewport.SetYOffset(v.matchLines[v.matchIndex])
@@ -146,6 +199,7 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 				v.searchQuery = ""
 				v.matchLines = nil
 				v.matchIndex = 0
+				v.spillHits = nil
 			}
 		}
 		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: cmd}
@@ -158,7 +212,7 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 		}
 		if msg.err == nil && len(msg.lines) > 0 {
 			v.streamErr = ""
-			v.allLines = msg.lines
+			v.setLines(msg.lines)
 			v.refreshWindow()
 			v.refreshContent()
 		}
@@ -167,10 +221,21 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 		if msg.requestID != v.requestID {
 			return viewstate.Update{Action: viewstate.None, Next: v}
 		}
-		v.allLines = append(v.allLines, msg.line)
+		v.buf.Append(msg.line)
+		if !v.inHistory {
+			// Lines keep flowing into the buffer while browsing spilled
+			// history; the live tail is redrawn on return.
+			v.refreshWindow()
+			v.refreshContent()
+		}
+		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.nextStreamMsgCmd()}
+	case timestampTickMsg:
+		if msg.gen != v.tsTick || !v.timestamps || v.tsMode != timestampRelative {
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		}
 		v.refreshWindow()
 		v.refreshContent()
-		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.nextStreamMsgCmd()}
+		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.timestampTickCmd()}
 	case logStreamDoneMsg:
 		if msg.requestID != v.requestID {
 			return viewstate.Update{Action: viewstate.None, Next: v}
@@ -183,6 +248,10 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 	case bubbletea.KeyMsg:
 		switch msg.String() {
 		case "esc":
+			if v.inHistory {
+				v.leaveHistory()
+				return viewstate.Update{Action: viewstate.None, Next: v}
+			}
 			if strings.TrimSpace(v.filterValue) != "" {
 				v.filterValue = ""
 				v.filterQuery = ""
@@ -205,11 +274,20 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 		case "w":
 			v.wrap = !v.wrap
 			v.refreshContent()
+		case "z":
+			v.toggleFold()
+		case "Z":
+			v.toggleAllFolds()
 		case "t":
 			v.timestamps = !v.timestamps
 			v.refreshWindow()
 			v.refreshContent()
-			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.reloadLogsCmd()}
+			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: bubbletea.Batch(v.reloadLogsCmd(), v.timestampTickCmd())}
+		case "T":
+			v.tsMode = v.tsMode.next()
+			v.refreshWindow()
+			v.refreshContent()
+			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.timestampTickCmd()}
 		case "p":
 			v.previous = !v.previous
 			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.reloadLogsCmd()}
@@ -226,15 +304,28 @@ func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
 			v.matchIndex = 0
 			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.searchInput.Focus()}
 		case "n":
+			if v.inHistory && (len(v.matchLines) == 0 || v.matchIndex == len(v.matchLines)-1) {
+				v.nextSpillHit()
+				break
+			}
 			if len(v.matchLines) > 0 {
 				v.matchIndex = (v.matchIndex + 1) % len(v.matchLines)
 				v.viewport.SetYOffset(v.matchLines[v.matchIndex])
 			}
 		case "b":
+			// In the live tail b wraps to the last match; B goes to disk.
+			if (len(v.matchLines) == 0 || v.inHistory && v.matchIndex == 0) && v.prevSpillHit() >= 0 {
+				v.openHistory(v.prevSpillHit()-historyPageLines+1, true)
+				break
+			}
 			if len(v.matchLines) > 0 {
 				v.matchIndex = (v.matchIndex - 1 + len(v.matchLines)) % len(v.matchLines)
 				v.viewport.SetYOffset(v.matchLines[v.matchIndex])
 			}
+		case "B":
+			if hit := v.prevSpillHit(); hit >= 0 {
+				v.openHistory(hit-historyPageLines+1, true)
+			}
 		case ".":
 			v.sinceIdx = (v.sinceIdx + 1) % len(sinceWindows)
 			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.reloadLogsCmd()}
@@ -321,8 +412,10 @@ func (v *View) Footer() string {
 	}
 	if !v.timestamps {
 		indicators = append(indicators, style.B("ts", "off"))
+	} else if v.tsMode != timestampUTC {
+		indicators = append(indicators, style.B("ts", v.tsMode.String()))
 	}
-	if sinceWindows[v.sinceIdx] != "5m" {
+	if sinceWindows[v.sinceIdx] != Defaults.Since {
 		indicators = append(indicators, style.B("since", sinceWindows[v.sinceIdx]))
 	}
 	if v.filterValue != "" {
@@ -331,6 +424,19 @@ func (v *View) Footer() string {
 	if len(v.matchLines) > 0 && !v.searchActive {
 		indicators = append(indicators, style.B("match", matchSummary(v.matchIndex, len(v.matchLines))))
 	}
+	if spilled := v.buf.Spilled(); spilled > 0 {
+		indicators = append(indicators, style.B("disk", strconv.Itoa(spilled)+" lines"))
+	}
+	if len(v.spillHits) > 0 && !v.searchActive {
+		indicators = append(indicators, style.B("disk-match", strconv.Itoa(len(v.spillHits))))
+	}
+	if v.inHistory {
+		to := v.historyFrom + historyPageLines
+		if to > v.buf.Len() {
+			to = v.buf.Len()
+		}
+		indicators = append(indicators, style.B("history", strconv.Itoa(v.historyFrom+1)+"-"+strconv.Itoa(to)))
+	}
 	if v.streamErr != "" {
 		indicators = append(indicators, style.B("stream", v.streamErr))
 	}
@@ -338,15 +444,24 @@ func (v *View) Footer() string {
 
 	actions := []style.Binding{
 		style.B("p", "mode"), style.B("f", "follow"), style.B("w", "wrap"),
-		style.B("t", "timestamps"),
+		style.B("t/T", "timestamps"),
 		style.B("/", "search"), style.B("&", "filter"),
 	}
 	if v.container != "" || v.ContainerViewFactory != nil {
 		actions = append(actions, style.B("c", "container"))
 	}
-	if len(v.matchLines) > 0 {
+	if len(v.matchLines) > 0 || len(v.spillHits) > 0 {
 		actions = append(actions, style.B("n/b", "next/prev"))
 	}
+	if len(v.folds) > 0 {
+		actions = append(actions, style.B("z/Z", "fold"))
+	}
+	if len(v.spillHits) > 0 && v.prevSpillHit() >= 0 {
+		actions = append(actions, style.B("B", "older match"))
+	}
+	if v.inHistory {
+		actions = append(actions, style.B("esc", "live"))
+	}
 	actions = append(actions, style.B(", .", "since"))
 	actions = append(actions, style.B("pgup/pgdn", "page"))
 	line2 := style.ActionFooter(actions, v.viewport.Width)
@@ -361,17 +476,85 @@ func (v *View) SetSize(width, height int) {
 	v.viewport.Height = height
 	v.refreshWindow()
 	v.refreshContent()
+	if v.seekText != "" {
+		v.seekLine(v.seekText, v.seekIndex)
+		v.seekText = ""
+	}
+}
+
+// SeekLine scrolls to a line containing text, expanding a folded stack trace
+// if the line is inside one. Of several such lines it picks the one nearest
+// index, where the line was found by whoever asks: views opened from a grep
+// hit use it, and the view may hold a different window of the log than the
+// grep searched. Before the view is sized the seek is deferred.
+func (v *View) SeekLine(text string, index int) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	if v.viewport.Width == 0 || v.viewport.Height == 0 {
+		v.seekText, v.seekIndex = text, index
+		return
+	}
+	v.seekLine(text, index)
+}
+
+func (v *View) seekLine(text string, near int) {
+	index := -1
+	for i, line := range v.lines {
+		if !strings.Contains(ansi.Strip(line), text) {
+			continue
+		}
+		if index < 0 || absInt(i-near) < absInt(index-near) {
+			index = i
+		}
+	}
+	if index < 0 {
+		return
+	}
+	row := index
+	for _, b := range v.folds {
+		if b.start >= index {
+			break
+		}
+		if index < b.end {
+			if !v.expanded[v.foldKey(b)] {
+				v.expanded[v.foldKey(b)] = true
+				v.buildDisplay()
+				v.viewport.SetContent(v.renderedContent())
+			}
+			break
+		}
+		if !v.expanded[v.foldKey(b)] {
+			// A collapsed block shows its first line and a marker row.
+			row -= b.end - b.start - 2
+		}
+	}
+	if starts := v.displayRowStarts(); row < len(starts) {
+		v.viewport.SetYOffset(starts[row])
+	}
+	v.recomputeMatches()
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
 }
 
 func (v *View) SuppressGlobalKeys() bool {
-	return v.searchActive || v.filterActive || strings.TrimSpace(v.filterValue) != "" || len(v.matchLines) > 0
+	return v.CapturesKeys() || strings.TrimSpace(v.filterValue) != "" || len(v.matchLines) > 0 || v.inHistory
+}
+
+// CapturesKeys reports whether a prompt is being typed into, so remapped
+// bindings do not apply to its keys.
+func (v *View) CapturesKeys() bool {
+	return v.searchActive || v.filterActive
 }
 
 func (v *View) refreshContent() {
-	content := strings.Join(v.lines, "\n")
-	if v.wrap && v.viewport.Width > 0 {
-		content = wrapLines(v.lines, v.viewport.Width)
-	}
+	content := v.renderedContent()
 
 	atBottom := v.viewport.AtBottom()
 	yOffset := v.viewport.YOffset
@@ -384,13 +567,33 @@ func (v *View) refreshContent() {
 	v.recomputeMatches()
 }
 
+func (v *View) renderedContent() string {
+	if v.wrap && v.viewport.Width > 0 {
+		return wrapLines(v.display, v.viewport.Width)
+	}
+	return strings.Join(v.display, "\n")
+}
+
 func (v *View) refreshWindow() {
-	lines := applySinceWindow(v.allLines, sinceWindows[v.sinceIdx])
+	source := v.buf.Recent()
+	if v.inHistory {
+		source = v.buf.Range(v.historyFrom, v.historyFrom+historyPageLines)
+	}
+	lines := applySinceWindow(source, sinceWindows[v.sinceIdx])
+	// Folds are detected before timestamp handling, which drops the
+	// indentation stack frames are recognised by. A filter shows matching
+	// lines on their own, so nothing is folded then.
+	v.folds = nil
+	if strings.TrimSpace(v.filterValue) == "" {
+		v.folds = detectFoldBlocks(lines)
+	}
 	lines = applyTimestampVisibility(lines, v.timestamps)
 	if v.timestamps {
-		lines = styleTimestampPrefixes(lines)
+		lines = styleTimestampPrefixes(lines, v.tsMode, timestampNow())
 	}
+	lines = styleLogBoundaries(lines)
 	v.lines = applyFilter(lines, v.filterValue)
+	v.buildDisplay()
 }
 
 func (v *View) reloadLogs() {
@@ -407,22 +610,32 @@ func (v *View) reloadLogs() {
 		defer cancel()
 		lines, err := reader.LogsWithOptions(ctx, v.item, opts)
 		if err == nil && len(lines) > 0 {
-			v.allLines = lines
+			v.setLines(lines)
 			return
 		}
 	}
-	v.allLines = v.resource.Logs(v.item)
+	v.setLines(v.resource.Logs(v.item))
+}
+
+// setLines replaces the buffered log with a fresh snapshot. Any spilled
+// history and opened folds belong to the previous snapshot, so they end too.
+func (v *View) setLines(lines []string) {
+	v.buf.Reset(lines)
+	v.inHistory = false
+	v.spillHits = nil
+	v.expanded = make(map[int]bool)
 }
 
 func (v *View) reloadLogsCmd() bubbletea.Cmd {
 	v.cancelReload()
 	v.streamErr = ""
 	opts := resources.LogOptions{
-		Tail:       tailForWindow(sinceWindows[v.sinceIdx]),
-		Follow:     v.follow,
-		Previous:   v.previous,
-		Container:  v.container,
-		Timestamps: v.timestamps,
+		Tail:        tailForWindow(sinceWindows[v.sinceIdx]),
+		Follow:      v.follow,
+		Previous:    v.previous,
+		Container:   v.container,
+		Timestamps:  v.timestamps,
+		FollowOwner: v.FollowWorkload,
 	}
 	if streamer, ok := v.resource.(resources.LogStreamReader); ok && opts.Follow {
 		v.requestID++
@@ -456,7 +669,7 @@ func (v *View) reloadLogsCmd() bubbletea.Cmd {
 			return logReloadResultMsg{requestID: requestID, lines: lines, err: err}
 		}
 	}
-	v.allLines = v.resource.Logs(v.item)
+	v.setLines(v.resource.Logs(v.item))
 	v.refreshWindow()
 	v.refreshContent()
 	return nil
@@ -464,6 +677,67 @@ func (v *View) reloadLogsCmd() bubbletea.Cmd {
 
 func (v *View) Dispose() {
 	v.cancelReload()
+	v.buf.Close()
+}
+
+// prevSpillHit returns the last spilled search hit before the lines currently
+// on screen, or -1 if there is none.
+func (v *View) prevSpillHit() int {
+	start := v.buf.Spilled()
+	if v.inHistory {
+		start = v.historyFrom
+	}
+	idx := sort.SearchInts(v.spillHits, start) - 1
+	if idx < 0 {
+		return -1
+	}
+	return v.spillHits[idx]
+}
+
+// nextSpillHit pages forward to the next spilled hit after the history page,
+// or returns to the live tail when the remaining hits are all in memory.
+func (v *View) nextSpillHit() {
+	end := v.historyFrom + historyPageLines
+	idx := sort.SearchInts(v.spillHits, end)
+	if idx >= len(v.spillHits) {
+		v.leaveHistory()
+		if len(v.matchLines) > 0 {
+			v.matchIndex = 0
+			v.viewport.SetYOffset(v.matchLines[0])
+		}
+		return
+	}
+	v.openHistory(v.spillHits[idx], false)
+}
+
+// openHistory shows a page of buffered lines starting at from, positioned on
+// the last match (when paging backwards) or the first match.
+func (v *View) openHistory(from int, backwards bool) {
+	if from < 0 {
+		from = 0
+	}
+	v.inHistory = true
+	v.historyFrom = from
+	v.refreshWindow()
+	v.viewport.SetContent(v.renderedContent())
+	v.recomputeMatches()
+	if len(v.matchLines) == 0 {
+		v.viewport.GotoTop()
+		return
+	}
+	v.matchIndex = 0
+	if backwards {
+		v.matchIndex = len(v.matchLines) - 1
+	}
+	v.viewport.SetYOffset(v.matchLines[v.matchIndex])
+}
+
+func (v *View) leaveHistory() {
+	v.inHistory = false
+	v.refreshWindow()
+	v.viewport.SetContent(v.renderedContent())
+	v.viewport.GotoBottom()
+	v.recomputeMatches()
 }
 
 func (v *View) cancelReload() {
@@ -515,7 +789,9 @@ func tailForWindow(window string) int {
 	case "1h":
 		return 1000
 	case "all":
-		return 2000
+		// Larger than the in-memory buffer on purpose: the excess spills to
+		// disk and stays searchable.
+		return 10000
 	default:
 		return 200
 	}
@@ -539,15 +815,33 @@ func (v *View) recomputeMatches() {
 		return
 	}
 	query := strings.ToLower(v.searchQuery)
-	lines := v.lines
-	if v.wrap && v.viewport.Width > 0 {
-		lines = wrappedLines(v.lines, v.viewport.Width)
+	contains := func(line string) bool {
+		return strings.Contains(strings.ToLower(ansi.Strip(line)), query)
 	}
-	matches := make([]int, 0, len(lines))
-	for i, line := range lines {
-		if strings.Contains(strings.ToLower(ansi.Strip(line)), query) {
-			matches = append(matches, i)
+	matches := make([]int, 0, len(v.display))
+	row := 0
+	for i, line := range v.display {
+		rows := []string{line}
+		if v.wrap && v.viewport.Width > 0 {
+			rows = wrapLine(line, v.viewport.Width)
+		}
+		if hidden, ok := v.displayHidden[i]; ok {
+			// A collapsed block matches on its marker row.
+			for _, h := range hidden {
+				if contains(h) {
+					matches = append(matches, row)
+					break
+				}
+			}
+			row += len(rows)
+			continue
+		}
+		for j, r := range rows {
+			if contains(r) {
+				matches = append(matches, row+j)
+			}
 		}
+		row += len(rows)
 	}
 	v.matchLines = matches
 	if len(v.matchLines) == 0 {
@@ -632,15 +926,18 @@ func stripTimestampPrefix(line string) string {
 	return strings.TrimLeft(trimmed[end:], " \t")
 }
 
-func styleTimestampPrefixes(lines []string) []string {
+func styleTimestampPrefixes(lines []string, mode timestampMode, now time.Time) []string {
 	out := make([]string, len(lines))
+	var prev time.Time
 	for i, line := range lines {
-		out[i] = styleTimestampPrefix(line)
+		out[i] = styleTimestampPrefix(line, mode, &prev, now)
 	}
 	return out
 }
 
-func styleTimestampPrefix(line string) string {
+// styleTimestampPrefix colors the timestamp prefix of line and, outside UTC
+// mode, reformats it. prev carries the previous timestamp for delta mode.
+func styleTimestampPrefix(line string, mode timestampMode, prev *time.Time, now time.Time) string {
 	trimmed := strings.TrimLeft(line, " \t")
 	if trimmed == "" {
 		return line
@@ -650,12 +947,28 @@ func styleTimestampPrefix(line string) string {
 		return line
 	}
 	prefix := trimmed[:end]
-	if _, err := time.Parse(time.RFC3339Nano, prefix); err != nil {
+	ts, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
 		return line
 	}
+	if mode != timestampUTC {
+		prefix = formatTimestamp(ts, *prev, mode, now)
+	}
+	*prev = ts
 	leading := line[:len(line)-len(trimmed)]
 	rest := strings.TrimLeft(trimmed[end:], " \t")
-	return leading + timestampPrefixSGR + prefix + timestampPrefixReset + "  " + rest
+	return leading + style.SGR(style.Current().Timestamp) + prefix + timestampPrefixReset + "  " + rest
+}
+
+// styleLogBoundaries highlights the separator lines a follow stream inserts
+// when it reconnects, so restarts and reschedules stand out in the tail.
+func styleLogBoundaries(lines []string) []string {
+	for i, line := range lines {
+		if resources.IsLogBoundary(line) {
+			lines[i] = style.SGR(style.Current().Warning, "1") + line + timestampPrefixReset
+		}
+	}
+	return lines
 }
 
 func pageStep(height int) int {
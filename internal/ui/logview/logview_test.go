@@ -270,6 +270,18 @@ func TestTimestampPrefixIsStyledWhenVisible(t *testing.T) {
 	}
 }
 
+func TestLogBoundaryLinesAreStyled(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "api"}, resources.NewPods())
+	v.SetSize(120, 20)
+	boundary := resources.LogBoundary("container restarted (restart 1)")
+	v.Update(logStreamAppendMsg{requestID: v.requestID, line: boundary})
+
+	last := v.lines[len(v.lines)-1]
+	if last == boundary || ansi.Strip(last) != boundary {
+		t.Fatalf("expected boundary line to be styled, got %q", last)
+	}
+}
+
 type blockingLogsResource struct {
 	base      resources.ResourceType
 	ctxSeen   chan context.Context
@@ -581,6 +593,46 @@ func TestSearchBackKeyMovesToPreviousMatch(t *testing.T) {
 	}
 }
 
+func TestSearchHistoryKeyPagesIntoSpilledHistory(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "api"}, resources.NewPods())
+	v.SetSize(80, 20)
+	v.buf = newLineBuffer(4)
+	defer v.Dispose()
+	v.setLines([]string{"boom early", "quiet", "quiet", "quiet", "quiet", "boom late", "quiet"})
+	v.refreshWindow()
+	v.refreshContent()
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'/'}})
+	for _, r := range "boom" {
+		v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{r}})
+	}
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if len(v.spillHits) != 1 || v.spillHits[0] != 0 {
+		t.Fatalf("expected one spilled hit at line 0, got %v", v.spillHits)
+	}
+	if !strings.Contains(ansi.Strip(v.Footer()), "disk-match 1") {
+		t.Fatalf("expected footer to report spilled matches, got %q", ansi.Strip(v.Footer()))
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'b'}})
+	if v.inHistory || len(v.matchLines) != 1 || v.matchIndex != 0 {
+		t.Fatalf("expected b at the only in-memory match to wrap in place, history=%v index=%d", v.inHistory, v.matchIndex)
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'B'}})
+	if !v.inHistory {
+		t.Fatal("expected B to open spilled history")
+	}
+	if len(v.lines) == 0 || v.lines[0] != "boom early" {
+		t.Fatalf("expected history page to start with spilled line, got %v", v.lines)
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEsc})
+	if v.inHistory {
+		t.Fatal("expected esc to return to the live tail")
+	}
+}
+
 func TestContainerKeyPopsWhenContainerSelected(t *testing.T) {
 	v := NewWithContainer(resources.ResourceItem{Name: "api"}, resources.NewPods(), "api")
 	update := v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'c'}})
@@ -658,3 +710,288 @@ func TestContainerSelectionPropagatesToLogOptions(t *testing.T) {
 		t.Fatalf("expected refetch to keep container option, got %#v", res.container)
 	}
 }
+
+func TestDetectFoldBlocksGroupsJavaAndPythonTraces(t *testing.T) {
+	lines := []string{
+		"2026-01-01T10:00:00Z INFO ready",
+		"2026-01-01T10:00:01Z ERROR request failed",
+		"2026-01-01T10:00:01Z java.lang.IllegalStateException: boom",
+		"2026-01-01T10:00:01Z \tat com.example.Api.handle(Api.java:42)",
+		"2026-01-01T10:00:01Z \tat com.example.Server.run(Server.java:7)",
+		"2026-01-01T10:00:01Z Caused by: java.io.IOException: closed",
+		"2026-01-01T10:00:01Z \t... 12 more",
+		"INFO next",
+		"Traceback (most recent call last):",
+		`  File "app.py", line 3, in <module>`,
+		"    main()",
+		"ValueError: bad input",
+		"INFO done",
+	}
+	got := detectFoldBlocks(lines)
+	want := []foldBlock{{start: 2, end: 7}, {start: 7, end: 12}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("detectFoldBlocks()=%v, want %v", got, want)
+	}
+}
+
+func TestStackTracesFoldAndExpandUnderCursor(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "api"}, resources.NewPods())
+	v.SetSize(80, 20)
+	v.setLines([]string{
+		"ERROR request failed",
+		"\tat com.example.Api.handle(Api.java:42)",
+		"\tat com.example.Server.run(Server.java:7)",
+		"\tat com.example.Main.main(Main.java:3)",
+		"INFO recovered",
+	})
+	v.refreshWindow()
+	v.refreshContent()
+
+	content := ansi.Strip(v.renderedContent())
+	if strings.Contains(content, "Server.java") || !strings.Contains(content, "+3 lines") {
+		t.Fatalf("expected trace to be collapsed behind a marker, got %q", content)
+	}
+	if !strings.Contains(ansi.Strip(v.Footer()), "fold") {
+		t.Fatalf("expected footer to offer the fold key, got %q", ansi.Strip(v.Footer()))
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'/'}})
+	for _, r := range "server.java" {
+		v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{r}})
+	}
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if len(v.matchLines) != 1 || v.matchLines[0] != 1 {
+		t.Fatalf("expected search to match the folded marker row, got %v", v.matchLines)
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'z'}})
+	content = ansi.Strip(v.renderedContent())
+	if !strings.Contains(content, "Server.java") || strings.Contains(content, "+3 lines") {
+		t.Fatalf("expected z to expand the matched block, got %q", content)
+	}
+	if len(v.matchLines) != 1 || v.matchLines[0] != 2 {
+		t.Fatalf("expected match to move onto the expanded frame, got %v", v.matchLines)
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'z'}})
+	if content := ansi.Strip(v.renderedContent()); !strings.Contains(content, "+3 lines") {
+		t.Fatalf("expected second z to collapse the block again, got %q", content)
+	}
+}
+
+func TestIdenticalTracesFoldIndependentlyAsRingRolls(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "api"}, resources.NewPods())
+	v.SetSize(80, 40)
+	v.buf = newLineBuffer(8)
+	defer v.Dispose()
+	trace := []string{
+		"ERROR request failed",
+		"\tat com.example.Api.handle(Api.java:42)",
+		"\tat com.example.Server.run(Server.java:7)",
+	}
+	v.setLines(append(append([]string{"INFO start"}, trace...), trace...))
+	v.refreshWindow()
+	v.refreshContent()
+	v.viewport.GotoTop()
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'z'}})
+	if got := strings.Count(ansi.Strip(v.renderedContent()), "+2 lines"); got != 1 {
+		t.Fatalf("expected only the first trace to expand, got %d collapsed", got)
+	}
+
+	for _, line := range []string{"INFO a", "INFO b"} {
+		v.Update(logStreamAppendMsg{requestID: v.requestID, line: line})
+	}
+	if v.buf.Spilled() != 1 {
+		t.Fatalf("expected the ring to roll, got %d spilled", v.buf.Spilled())
+	}
+	content := ansi.Strip(v.renderedContent())
+	if got := strings.Count(content, "+2 lines"); got != 1 || strings.Index(content, "Server.java") > strings.Index(content, "+2 lines") {
+		t.Fatalf("expected the first trace to stay expanded after the ring rolled, got %q", content)
+	}
+}
+
+func TestTimestampModeCyclesLocalRelativeAndDelta(t *testing.T) {
+	prevNow, prevLoc := timestampNow, timestampLocation
+	timestampNow = func() time.Time { return time.Date(2026, 1, 1, 10, 1, 0, 0, time.UTC) }
+	timestampLocation = func() *time.Location { return time.FixedZone("CET", 3600) }
+	t.Cleanup(func() { timestampNow, timestampLocation = prevNow, prevLoc })
+
+	v := New(resources.ResourceItem{Name: "api"}, resources.NewPods())
+	v.SetSize(120, 20)
+	v.setLines([]string{
+		"2026-01-01T10:00:00Z first",
+		"2026-01-01T10:00:00.250Z second",
+		"2026-01-01T10:00:48Z third",
+	})
+	v.refreshWindow()
+
+	cases := []struct {
+		mode  string
+		lines []string
+	}{
+		{"local", []string{"2026-01-01T11:00:00.000+01:00  first", "2026-01-01T11:00:00.250+01:00  second", "2026-01-01T11:00:48.000+01:00  third"}},
+		{"relative", []string{"   1m ago  first", "  59s ago  second", "  12s ago  third"}},
+		{"delta", []string{"      +0s  first", "  +0.250s  second", " +47.750s  third"}},
+	}
+	for _, tc := range cases {
+		v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'T'}})
+		for i, want := range tc.lines {
+			if got := ansi.Strip(v.lines[i]); got != want {
+				t.Fatalf("%s mode line %d = %q, want %q", tc.mode, i, got, want)
+			}
+		}
+		if footer := ansi.Strip(v.Footer()); !strings.Contains(footer, "ts "+tc.mode) {
+			t.Fatalf("expected footer to show ts %s, got %q", tc.mode, footer)
+		}
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'T'}})
+	if got := ansi.Strip(v.lines[0]); got != "2026-01-01T10:00:00Z  first" {
+		t.Fatalf("expected T to cycle back to UTC, got %q", got)
+	}
+}
+
+func TestRelativeTimestampsRefreshOnTick(t *testing.T) {
+	now := time.Date(2026, 1, 1, 10, 1, 0, 0, time.UTC)
+	prevNow := timestampNow
+	timestampNow = func() time.Time { return now }
+	t.Cleanup(func() { timestampNow = prevNow })
+
+	v := New(resources.ResourceItem{Name: "api"}, resources.NewPods())
+	v.SetSize(120, 20)
+	v.setLines([]string{"2026-01-01T10:00:30Z first"})
+	v.refreshWindow()
+	for v.tsMode != timestampLocal {
+		v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'T'}})
+	}
+	update := v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'T'}})
+	if v.tsMode != timestampRelative || update.Cmd == nil {
+		t.Fatalf("expected relative mode to start ticking, mode=%s cmd=%v", v.tsMode, update.Cmd != nil)
+	}
+	if got := ansi.Strip(v.lines[0]); got != "  30s ago  first" {
+		t.Fatalf("unexpected relative stamp %q", got)
+	}
+
+	now = now.Add(2 * time.Minute)
+	v.Update(timestampTickMsg{gen: v.tsTick - 1})
+	if got := ansi.Strip(v.lines[0]); got != "  30s ago  first" {
+		t.Fatalf("expected a stale tick to be ignored, got %q", got)
+	}
+	update = v.Update(timestampTickMsg{gen: v.tsTick})
+	if got := ansi.Strip(v.lines[0]); got != "   2m ago  first" {
+		t.Fatalf("expected the tick to recompute the stamp, got %q", got)
+	}
+	if update.Cmd == nil {
+		t.Fatal("expected the tick to schedule the next one")
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'T'}})
+	if update := v.Update(timestampTickMsg{gen: v.tsTick}); update.Cmd != nil {
+		t.Fatal("expected ticks to stop outside relative mode")
+	}
+}
+
+func TestTimestampDeltaOutOfOrderLines(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "api"}, resources.NewPods())
+	v.SetSize(120, 20)
+	v.setLines([]string{
+		"2026-01-01T10:00:01Z first",
+		"2026-01-01T10:00:00.500Z late",
+		"2026-01-01T10:00:02Z next",
+	})
+	v.refreshWindow()
+	for v.tsMode != timestampDelta {
+		v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'T'}})
+	}
+
+	want := []string{"      +0s  first", "  -0.500s  late", "  +1.500s  next"}
+	for i, w := range want {
+		if got := ansi.Strip(v.lines[i]); got != w {
+			t.Fatalf("line %d = %q, want %q", i, got, w)
+		}
+	}
+}
+
+func TestSeekLineScrollsToHitAfterSizing(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "api"}, resources.NewPods())
+	lines := make([]string, 0, 60)
+	for i := 0; i < 60; i++ {
+		lines = append(lines, "quiet")
+	}
+	lines[10] = "ERROR connection refused"
+	lines[40] = "ERROR connection refused"
+	v.setLines(lines)
+	v.refreshWindow()
+
+	// The grep saw the hit at 12, but the view holds a different window.
+	v.SeekLine("connection refused", 12)
+	v.SetSize(80, 10)
+	if v.viewport.YOffset != 10 {
+		t.Fatalf("expected view to scroll to the nearest hit line, got offset %d", v.viewport.YOffset)
+	}
+
+	v.SeekLine("connection refused", 45)
+	if v.viewport.YOffset != 40 {
+		t.Fatalf("expected the later occurrence, got offset %d", v.viewport.YOffset)
+	}
+}
+
+func TestSeekLineAccountsForFoldedTraces(t *testing.T) {
+	lines := []string{
+		"ERROR request failed",
+		"\tat com.example.Api.handle(Api.java:42)",
+		"\tat com.example.Server.run(Server.java:7)",
+		"\tat com.example.Main.main(Main.java:3)",
+	}
+	for len(lines) < 60 {
+		lines = append(lines, "quiet")
+	}
+	lines[20] = "WARN slow request"
+	v := New(resources.ResourceItem{Name: "api"}, resources.NewPods())
+	v.SetSize(80, 10)
+	v.setLines(lines)
+	v.refreshWindow()
+	v.refreshContent()
+
+	// The collapsed trace shows two rows for its four lines.
+	v.SeekLine("slow request", 20)
+	if v.viewport.YOffset != 18 {
+		t.Fatalf("expected offset 18 past the collapsed trace, got %d", v.viewport.YOffset)
+	}
+
+	v.SeekLine("Server.run", 2)
+	if !strings.Contains(ansi.Strip(v.renderedContent()), "Server.java") {
+		t.Fatal("expected seeking into the trace to expand it")
+	}
+	if v.viewport.YOffset != 2 {
+		t.Fatalf("expected offset 2 inside the expanded trace, got %d", v.viewport.YOffset)
+	}
+}
+
+func TestSearchBackWrapsToLastMatchInLiveTail(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "api"}, resources.NewPods())
+	v.SetSize(80, 20)
+	v.buf = newLineBuffer(4)
+	defer v.Dispose()
+	v.setLines([]string{"boom early", "quiet", "boom one", "quiet", "boom two", "quiet"})
+	v.refreshWindow()
+	v.refreshContent()
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'/'}})
+	for _, r := range "boom" {
+		v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{r}})
+	}
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if len(v.matchLines) != 2 || len(v.spillHits) != 1 {
+		t.Fatalf("expected 2 live matches and 1 spilled hit, got %v / %v", v.matchLines, v.spillHits)
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune{'b'}})
+	if v.inHistory {
+		t.Fatal("expected b at the first match to stay in the live tail")
+	}
+	if v.matchIndex != 1 {
+		t.Fatalf("expected b to wrap to the last match, got index %d", v.matchIndex)
+	}
+}
@@ -0,0 +1,140 @@
+package logview
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+)
+
+// timestampMode controls how visible timestamp prefixes are rendered. The API
+// always returns RFC3339 in UTC; the other modes reformat it for reading
+// alongside local-time sources or for spotting gaps between lines.
+type timestampMode int
+
+const (
+	timestampUTC timestampMode = iota
+	timestampLocal
+	timestampRelative
+	timestampDelta
+)
+
+var timestampModes = []timestampMode{timestampUTC, timestampLocal, timestampRelative, timestampDelta}
+
+func (m timestampMode) String() string {
+	switch m {
+	case timestampLocal:
+		return "local"
+	case timestampRelative:
+		return "relative"
+	case timestampDelta:
+		return "delta"
+	default:
+		return "utc"
+	}
+}
+
+func (m timestampMode) next() timestampMode {
+	return timestampModes[(int(m)+1)%len(timestampModes)]
+}
+
+// relativeWidth pads relative and delta prefixes so messages stay aligned.
+const relativeWidth = 9
+
+// localTimestampLayout has a fixed width, unlike RFC3339Nano, so messages stay
+// aligned when fractional seconds end in zeros.
+const localTimestampLayout = "2006-01-02T15:04:05.000-07:00"
+
+// relativeRefresh is how often relative stamps are recomputed, matching the
+// one-second resolution they show under a minute.
+const relativeRefresh = time.Second
+
+// Package variables so tests can pin the clock and zone.
+var (
+	timestampNow      = time.Now
+	timestampLocation = func() *time.Location { return time.Local }
+)
+
+// formatTimestamp renders ts for mode. prev is the timestamp of the previous
+// line that had one, used by the delta mode.
+func formatTimestamp(ts, prev time.Time, mode timestampMode, now time.Time) string {
+	switch mode {
+	case timestampLocal:
+		return ts.In(timestampLocation()).Format(localTimestampLayout)
+	case timestampRelative:
+		return padLeft(relativeAge(now.Sub(ts))+" ago", relativeWidth)
+	case timestampDelta:
+		if prev.IsZero() {
+			return padLeft("+0s", relativeWidth)
+		}
+		// Lines can be out of order; deltaString signs those gaps itself.
+		d := ts.Sub(prev)
+		if d < 0 {
+			return padLeft(deltaString(d), relativeWidth)
+		}
+		return padLeft("+"+deltaString(d), relativeWidth)
+	default:
+		return ts.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// relativeAge formats d in the coarse units of the AGE columns, with seconds
+// added since log lines are usually recent.
+func relativeAge(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	switch {
+	case d < time.Minute:
+		return strconv.Itoa(int(d.Seconds())) + "s"
+	case d < time.Hour:
+		return strconv.Itoa(int(d.Minutes())) + "m"
+	case d < 24*time.Hour:
+		return strconv.Itoa(int(d.Hours())) + "h"
+	default:
+		return strconv.Itoa(int(d.Hours()/24)) + "d"
+	}
+}
+
+// deltaString keeps millisecond precision under a minute, where latency gaps
+// are interesting, and drops to whole seconds above it.
+func deltaString(d time.Duration) string {
+	if d < 0 {
+		return "-" + deltaString(-d)
+	}
+	if d < time.Minute {
+		return strconv.FormatFloat(d.Seconds(), 'f', 3, 64) + "s"
+	}
+	return d.Truncate(time.Second).String()
+}
+
+func padLeft(s string, width int) string {
+	if n := len(s); n < width {
+		return strings.Repeat(" ", width-n) + s
+	}
+	return s
+}
+
+// timestampTickMsg asks the view to recompute relative stamps. gen ties it to
+// the tick chain that sent it, so a restarted chain replaces the old one.
+type timestampTickMsg struct{ gen int }
+
+// timestampTickCmd starts a tick chain while relative stamps are shown, and
+// returns nil otherwise.
+func (v *View) timestampTickCmd() bubbletea.Cmd {
+	if !v.timestamps || v.tsMode != timestampRelative {
+		return nil
+	}
+	v.tsTick++
+	gen := v.tsTick
+	return bubbletea.Tick(relativeRefresh, func(time.Time) bubbletea.Msg {
+		return timestampTickMsg{gen: gen}
+	})
+}
+
+// Resume restarts the relative stamp ticks, which stop while another view
+// covers this one.
+func (v *View) Resume() bubbletea.Cmd {
+	return v.timestampTickCmd()
+}
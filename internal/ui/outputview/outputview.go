@@ -0,0 +1,74 @@
+// Package outputview shows the captured output of a command that ran in the
+// background, such as a plugin.
+package outputview
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/dloss/podji/internal/ui/style"
+	"github.com/dloss/podji/internal/ui/viewstate"
+)
+
+// View is a scrollable page of command output. The first line names the
+// command and how it ended.
+type View struct {
+	name     string
+	viewport viewport.Model
+}
+
+// New returns a view of output, titled name. A non-nil err is the reason the
+// command failed, such as its exit status.
+func New(name, command string, output []byte, err error) *View {
+	header := style.Muted.Render("$ " + command)
+	if err != nil {
+		header += "  " + style.Error.Render(err.Error())
+	}
+	body := strings.TrimRight(string(output), "\n")
+	if body == "" {
+		body = style.Muted.Render("(no output)")
+	}
+	vp := viewport.New(0, 0)
+	vp.SetContent(header + "\n" + body)
+	return &View{name: name, viewport: vp}
+}
+
+func (v *View) Init() bubbletea.Cmd { return nil }
+
+func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
+	if key, ok := msg.(bubbletea.KeyMsg); ok {
+		switch key.String() {
+		case "home", "g":
+			v.viewport.GotoTop()
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "end", "G":
+			v.viewport.GotoBottom()
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		}
+	}
+	updated, cmd := v.viewport.Update(msg)
+	v.viewport = updated
+	return viewstate.Update{Action: viewstate.None, Next: v, Cmd: cmd}
+}
+
+func (v *View) View() string {
+	return v.viewport.View()
+}
+
+func (v *View) Breadcrumb() string {
+	return v.name
+}
+
+func (v *View) Footer() string {
+	bindings := []style.Binding{style.B("g/G", "top/bottom"), style.B("←", "back")}
+	return "\n" + style.ActionFooter(bindings, v.viewport.Width)
+}
+
+func (v *View) SetSize(width, height int) {
+	if width == 0 || height == 0 {
+		return
+	}
+	v.viewport.Width = width
+	v.viewport.Height = height
+}
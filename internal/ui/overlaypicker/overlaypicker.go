@@ -6,6 +6,7 @@ import (
 
 	bubbletea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dloss/podji/internal/ui/style"
 	"github.com/dloss/podji/internal/ui/viewstate"
 )
 
@@ -189,23 +190,19 @@ func (p *Picker) View() string {
 			item = string([]rune(item)[:innerWidth-3]) + "…"
 		}
 		if i == p.cursor {
-			sel := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("0")).
-				Background(lipgloss.Color("250")).
-				Bold(true)
-			lines = append(lines, sel.Render(" "+item+" "))
+			lines = append(lines, style.Highlighted(" "+item+" "))
 		} else {
 			lines = append(lines, " "+item)
 		}
 	}
 
 	if len(filtered) == 0 {
-		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("  no matches"))
+		lines = append(lines, style.Muted.Render("  no matches"))
 	}
 
 	box := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("241")).
+		BorderForeground(style.Border).
 		Width(innerWidth).
 		Render(strings.Join(lines, "\n"))
 
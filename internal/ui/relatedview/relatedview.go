@@ -130,11 +130,8 @@ func (p *Picker) View() string {
 	}
 
 	titleStyle := lipgloss.NewStyle().Bold(true)
-	selStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("0")).
-		Background(lipgloss.Color("250")).
-		Bold(true)
-	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	selStyle := style.Highlight
+	mutedStyle := style.Muted
 
 	title := "  Related"
 	if p.source != "" {
@@ -193,7 +190,7 @@ func (p *Picker) View() string {
 
 	box := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("241")).
+		BorderForeground(style.Border).
 		Width(innerWidth).
 		Render(strings.Join(lines, "\n"))
 
@@ -264,10 +261,11 @@ func newRelationList(resource resources.ResourceType, registry *resources.Regist
 	listItems := make([]list.Item, 0, len(items))
 	for idx, res := range items {
 		listItems = append(listItems, relationItem{
-			data:   res,
-			row:    rows[idx],
-			status: res.Status,
-			widths: widths,
+			data:         res,
+			row:          rows[idx],
+			status:       res.Status,
+			statusColumn: relationStatusColumn(columns),
+			widths:       widths,
 		})
 	}
 
@@ -447,10 +445,11 @@ func (v *relationList) refreshItems() {
 	listItems := make([]list.Item, 0, len(items))
 	for idx, res := range items {
 		listItems = append(listItems, relationItem{
-			data:   res,
-			row:    rows[idx],
-			status: res.Status,
-			widths: v.colWidths,
+			data:         res,
+			row:          rows[idx],
+			status:       res.Status,
+			statusColumn: relationStatusColumn(v.columns),
+			widths:       v.colWidths,
 		})
 	}
 
@@ -473,10 +472,11 @@ func (v *relationList) NextBreadcrumb() string {
 }
 
 type relationItem struct {
-	data   resources.ResourceItem
-	row    []string
-	status string
-	widths []int
+	data         resources.ResourceItem
+	row          []string
+	status       string
+	statusColumn int // STATUS column, which shows a severity symbol; -1 if none
+	widths       []int
 }
 
 func (i relationItem) Title() string {
@@ -484,14 +484,39 @@ func (i relationItem) Title() string {
 	for idx, value := range i.row {
 		width := i.widths[idx]
 		cellValue := relationPadCell(value, width)
-		if idx > 0 && i.status != "" && i.row[idx] == i.status {
-			cellValue = style.Status(cellValue)
+		if i.isStatusCell(idx) {
+			cellValue = i.statusCell(idx, value, width)
 		}
 		cells = append(cells, cellValue)
 	}
 	return strings.Join(cells, relationColumnSeparator)
 }
 
+// isStatusCell reports whether the cell at idx shows the item's status.
+func (i relationItem) isStatusCell(idx int) bool {
+	return idx > 0 && i.status != "" && i.row[idx] == i.status
+}
+
+// statusCell renders the status cell at idx in its severity color. In the
+// STATUS column the status is preceded by its severity symbol.
+func (i relationItem) statusCell(idx int, value string, width int) string {
+	if idx != i.statusColumn || width <= style.StatusSymbolWidth {
+		return style.StatusStyle(value).Render(relationPadCell(value, width))
+	}
+	cell := style.StatusSymbol(value) + " " + relationPadCell(value, width-style.StatusSymbolWidth)
+	return style.StatusStyle(value).Render(cell)
+}
+
+// relationStatusColumn returns the index of the STATUS column, or -1.
+func relationStatusColumn(columns []resources.TableColumn) int {
+	for idx, col := range columns {
+		if col.ID == "status" {
+			return idx
+		}
+	}
+	return -1
+}
+
 func (i relationItem) Description() string { return "" }
 func (i relationItem) FilterValue() string {
 	return i.data.Name
@@ -548,6 +573,9 @@ func relationColumnWidthsForRows(columns []resources.TableColumn, rows [][]strin
 				continue
 			}
 			cellWidth := len([]rune(strings.TrimSpace(row[idx])))
+			if cellWidth > 0 && col.ID == "status" {
+				cellWidth += style.StatusSymbolWidth
+			}
 			if cellWidth > maxContent {
 				maxContent = cellWidth
 			}
@@ -823,11 +851,12 @@ func relatedEntriesLegacy(source resources.ResourceItem, resource resources.Reso
 			open:        openResource(resources.NewWorkloadPods(source, registry)),
 		})
 		if source.Kind == "CJ" {
+			jobs := resources.NewJobsForCronJob(source, resource, registry)
 			entries = append(entries, entry{
 				name:        "jobs",
-				count:       2,
-				description: "Owned jobs",
-				open:        openResource(resources.NewJobsForCronJob(source.Name)),
+				count:       len(jobs.Items()),
+				description: "Job runs, newest first",
+				open:        openResource(jobs),
 			})
 		}
 		entries = append(entries, entry{
@@ -961,11 +990,12 @@ func relatedEntriesWithIndex(source resources.ResourceItem, resource resources.R
 			open:        openResourceIndexed("pods", resources.NewWorkloadPods(source, registry)),
 		})
 		if source.Kind == "CJ" {
+			jobs := resources.NewJobsForCronJob(source, resource, registry)
 			entries = append(entries, entry{
 				name:        "jobs",
-				count:       2,
-				description: "Owned jobs",
-				open:        openResource(resources.NewJobsForCronJob(source.Name)),
+				count:       len(jobs.Items()),
+				description: "Job runs, newest first",
+				open:        openResource(jobs),
 			})
 		}
 		entries = append(entries, entry{
@@ -16,10 +16,7 @@ func newRelatedTableDelegate(findMode *bool, findTargets *map[int]bool) relatedT
 	delegate.SetHeight(1)
 	delegate.SetSpacing(0)
 	delegate.ShowDescription = false
-	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Bold(true).
-		Foreground(lipgloss.Color("15")).
-		Background(lipgloss.Color("236")).
+	delegate.Styles.SelectedTitle = style.SelectedRow(delegate.Styles.SelectedTitle).
 		BorderLeft(true).
 		BorderStyle(lipgloss.Border{Left: "▌"})
 	return relatedTableDelegate{DefaultDelegate: delegate, findMode: findMode, findTargets: findTargets}
@@ -90,8 +87,8 @@ func renderRelationRow(
 		if idx == 0 && findTarget {
 			cellValue = underlineFirstChar(cellValue)
 		}
-		if idx > 0 && it.status != "" && it.row[idx] == it.status {
-			cellValue = style.Status(cellValue)
+		if it.isStatusCell(idx) {
+			cellValue = it.statusCell(idx, value, width)
 		}
 		cells = append(cells, cellValue)
 	}
@@ -103,5 +100,5 @@ func underlineFirstChar(value string) string {
 	if len(runes) == 0 {
 		return value
 	}
-	return "\x1b[4;1;97m" + string(runes[0]) + "\x1b[22;24;39m" + string(runes[1:])
+	return style.SGR(style.Current().FindTarget, "4", "1") + string(runes[0]) + "\x1b[22;24;39m" + string(runes[1:])
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/ui/style"
 )
 
 func newBrowserDelegate(findMode *bool, findTargets *map[int]bool) browserDelegate {
@@ -15,10 +16,7 @@ func newBrowserDelegate(findMode *bool, findTargets *map[int]bool) browserDelega
 	delegate.SetHeight(1)
 	delegate.SetSpacing(0)
 	delegate.ShowDescription = false
-	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Bold(true).
-		Foreground(lipgloss.Color("15")).
-		Background(lipgloss.Color("236")).
+	delegate.Styles.SelectedTitle = style.SelectedRow(delegate.Styles.SelectedTitle).
 		BorderLeft(true).
 		BorderStyle(lipgloss.Border{Left: "▌"})
 	return browserDelegate{DefaultDelegate: delegate, findMode: findMode, findTargets: findTargets}
@@ -97,5 +95,5 @@ func underlineFirstChar(value string) string {
 	if len(runes) == 0 {
 		return value
 	}
-	return "\x1b[4;1;97m" + string(runes[0]) + "\x1b[22;24;39m" + string(runes[1:])
+	return style.SGR(style.Current().FindTarget, "4", "1") + string(runes[0]) + "\x1b[22;24;39m" + string(runes[1:])
 }
@@ -0,0 +1,159 @@
+package secretview
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// now is a variable so tests can pin certificate expiry text.
+var now = time.Now
+
+const mask = "••••••••"
+
+// certLines summarizes the PEM certificates in value. Certificates are public,
+// so they are described even while the value is hidden.
+func certLines(value []byte) []string {
+	var lines []string
+	rest := value
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			lines = append(lines, "certificate: "+err.Error())
+			continue
+		}
+		lines = append(lines, "subject: "+cert.Subject.String())
+		if cert.Issuer.String() != cert.Subject.String() {
+			lines = append(lines, "issuer:  "+cert.Issuer.String())
+		} else {
+			lines = append(lines, "issuer:  self-signed")
+		}
+		if names := append(append([]string{}, cert.DNSNames...), ipStrings(cert)...); len(names) > 0 {
+			lines = append(lines, "names:   "+strings.Join(names, ", "))
+		}
+		lines = append(lines, fmt.Sprintf("valid:   %s → %s (%s)",
+			cert.NotBefore.UTC().Format("2006-01-02"), cert.NotAfter.UTC().Format("2006-01-02"), expiry(cert.NotAfter)))
+	}
+	return lines
+}
+
+func ipStrings(cert *x509.Certificate) []string {
+	out := make([]string, len(cert.IPAddresses))
+	for i, ip := range cert.IPAddresses {
+		out[i] = ip.String()
+	}
+	return out
+}
+
+func expiry(notAfter time.Time) string {
+	days := int(notAfter.Sub(now()).Hours() / 24)
+	switch {
+	case notAfter.Before(now()):
+		return fmt.Sprintf("expired %dd ago", -days)
+	case days == 0:
+		return "expires today"
+	}
+	return fmt.Sprintf("expires in %dd", days)
+}
+
+// expiringSoon reports whether a certificate summary line warns about expiry.
+func expiringSoon(line string) bool {
+	if strings.Contains(line, "expired") || strings.Contains(line, "expires today") {
+		return true
+	}
+	var days int
+	if idx := strings.Index(line, "expires in "); idx >= 0 {
+		fmt.Sscanf(line[idx:], "expires in %dd", &days)
+		return days < 30
+	}
+	return false
+}
+
+type dockerAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+// dockerConfigLines lists the registries of a .dockerconfigjson value, with
+// credentials only when revealed.
+func dockerConfigLines(value []byte, revealed bool) []string {
+	var cfg struct {
+		Auths map[string]dockerAuth `json:"auths"`
+	}
+	if err := json.Unmarshal(value, &cfg); err != nil || len(cfg.Auths) == 0 {
+		return nil
+	}
+	registries := make([]string, 0, len(cfg.Auths))
+	for registry := range cfg.Auths {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+	lines := make([]string, 0, len(registries))
+	for _, registry := range registries {
+		if !revealed {
+			lines = append(lines, "registry: "+registry)
+			continue
+		}
+		auth := cfg.Auths[registry]
+		user, pass := auth.Username, auth.Password
+		if decoded, err := base64.StdEncoding.DecodeString(auth.Auth); err == nil {
+			if u, p, ok := strings.Cut(string(decoded), ":"); ok {
+				if user == "" {
+					user = u
+				}
+				if pass == "" {
+					pass = p
+				}
+			}
+		}
+		lines = append(lines, fmt.Sprintf("registry: %s  user: %s  password: %s", registry, user, pass))
+	}
+	return lines
+}
+
+// valueLines renders a revealed value: text as is, JSON pretty-printed and
+// binary data as a hex preview.
+func valueLines(value []byte) []string {
+	if !utf8.Valid(value) || strings.ContainsRune(string(value), 0) {
+		preview := value
+		if len(preview) > 32 {
+			preview = preview[:32]
+		}
+		return []string{fmt.Sprintf("binary, %d bytes: %x…", len(value), preview)}
+	}
+	text := strings.TrimRight(string(value), "\n")
+	if strings.HasPrefix(strings.TrimSpace(text), "{") {
+		var parsed any
+		if json.Unmarshal(value, &parsed) == nil {
+			if pretty, err := json.MarshalIndent(parsed, "", "  "); err == nil {
+				text = string(pretty)
+			}
+		}
+	}
+	return strings.Split(text, "\n")
+}
+
+func sizeString(n int) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%dB", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1fKiB", float64(n)/1024)
+	}
+	return fmt.Sprintf("%.1fMiB", float64(n)/(1024*1024))
+}
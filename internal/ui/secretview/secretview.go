@@ -0,0 +1,234 @@
+package secretview
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/viewport"
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/resources"
+	"github.com/dloss/podji/internal/ui/style"
+	"github.com/dloss/podji/internal/ui/viewstate"
+)
+
+type clearCopiedMsg struct{}
+
+// copyToClipboard is a variable so tests can capture copies.
+var copyToClipboard = clipboard.WriteAll
+
+// View lists the keys of a secret with their sizes. Values stay masked until
+// revealed one key at a time; reveal and copy are refused for secrets read
+// from protected contexts.
+type View struct {
+	item     resources.ResourceItem
+	resource resources.ResourceType
+	viewport viewport.Model
+
+	data     resources.SecretData
+	err      string
+	keys     []string
+	revealed map[string]bool
+	cursor   int
+	rowOf    []int // first rendered line of each key
+
+	copiedMsg string
+}
+
+func New(item resources.ResourceItem, resource resources.ResourceType) *View {
+	v := &View{item: item, resource: resource, viewport: viewport.New(0, 0), revealed: map[string]bool{}}
+	reader, ok := resource.(resources.SecretDataReader)
+	if !ok {
+		v.err = "secret values are not available for " + resource.Name()
+	} else if data, err := reader.SecretData(item); err != nil {
+		v.err = err.Error()
+	} else {
+		v.data = data
+		for key := range data.Data {
+			v.keys = append(v.keys, key)
+		}
+		sort.Strings(v.keys)
+	}
+	v.render()
+	return v
+}
+
+func (v *View) render() {
+	if v.err != "" {
+		v.viewport.SetContent(style.Error.Render("Cannot read secret: " + v.err))
+		return
+	}
+	if len(v.keys) == 0 {
+		v.viewport.SetContent(style.Muted.Render("No data."))
+		return
+	}
+	keyWidth := len("KEY")
+	for _, key := range v.keys {
+		keyWidth = max(keyWidth, len(key))
+	}
+	pad := func(s string, w int) string { return s + strings.Repeat(" ", max(0, w-len(s))) }
+	indent := strings.Repeat(" ", keyWidth+14)
+
+	lines := []string{style.Muted.Render("  " + pad("KEY", keyWidth) + "  " + pad("SIZE", 8) + "  VALUE")}
+	v.rowOf = make([]int, len(v.keys))
+	for i, key := range v.keys {
+		value := v.data.Data[key]
+		revealed := v.revealed[key] && !v.data.Protected
+		var below []string
+		summary := mask
+		switch {
+		case v.data.Type == "kubernetes.io/dockerconfigjson" && key == ".dockerconfigjson":
+			below = dockerConfigLines(value, revealed)
+			if revealed && below == nil {
+				below = valueLines(value)
+			}
+		case revealed:
+			below = valueLines(value)
+		}
+		if revealed && len(below) == 1 {
+			summary, below = below[0], nil
+		} else if revealed {
+			summary = ""
+		}
+		certs := certLines(value)
+
+		v.rowOf[i] = len(lines)
+		row := "  " + pad(key, keyWidth) + "  " + pad(sizeString(len(value)), 8) + "  " + summary
+		if i == v.cursor {
+			row += strings.Repeat(" ", max(0, v.viewport.Width-ansi.StringWidth(row)))
+			row = style.CursorLine.Render(row)
+		}
+		lines = append(lines, row)
+		for _, line := range below {
+			lines = append(lines, indent+line)
+		}
+		for _, line := range certs {
+			rendered := style.Muted.Render(line)
+			if expiringSoon(line) {
+				rendered = style.Warning.Render(line)
+			}
+			lines = append(lines, indent+rendered)
+		}
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+func (v *View) Init() bubbletea.Cmd { return nil }
+
+func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
+	switch msg := msg.(type) {
+	case clearCopiedMsg:
+		v.copiedMsg = ""
+		return viewstate.Update{Action: viewstate.None, Next: v}
+	case bubbletea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			v.moveCursor(v.cursor - 1)
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "down", "j":
+			v.moveCursor(v.cursor + 1)
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "v", " ":
+			if key, ok := v.selectedKey(); ok && !v.data.Protected {
+				v.revealed[key] = !v.revealed[key]
+				v.render()
+			}
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "c":
+			key, ok := v.selectedKey()
+			if !ok || v.data.Protected {
+				return viewstate.Update{Action: viewstate.None, Next: v}
+			}
+			if err := copyToClipboard(string(v.data.Data[key])); err != nil {
+				v.copiedMsg = "clipboard error: " + err.Error()
+			} else {
+				v.copiedMsg = "copied value of " + key
+			}
+			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: clearCopiedCmd()}
+		}
+	}
+	updated, cmd := v.viewport.Update(msg)
+	v.viewport = updated
+	return viewstate.Update{Action: viewstate.None, Next: v, Cmd: cmd}
+}
+
+func (v *View) selectedKey() (string, bool) {
+	if v.cursor < 0 || v.cursor >= len(v.keys) {
+		return "", false
+	}
+	return v.keys[v.cursor], true
+}
+
+func (v *View) moveCursor(i int) {
+	if i >= len(v.keys) {
+		i = len(v.keys) - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	v.cursor = i
+	v.render()
+	if v.cursor >= len(v.rowOf) || v.viewport.Height <= 0 {
+		return
+	}
+	row := v.rowOf[v.cursor]
+	if row < v.viewport.YOffset {
+		v.viewport.SetYOffset(row)
+	} else if row >= v.viewport.YOffset+v.viewport.Height {
+		v.viewport.SetYOffset(row - v.viewport.Height + 1)
+	}
+}
+
+func (v *View) View() string {
+	return v.viewport.View()
+}
+
+func (v *View) Breadcrumb() string {
+	return "data"
+}
+
+func (v *View) Footer() string {
+	var indicators []style.Binding
+	if v.data.Type != "" {
+		indicators = append(indicators, style.B("type", v.data.Type))
+	}
+	if v.data.Protected {
+		indicators = append(indicators, style.B("protected context", "reveal disabled"))
+	}
+	if v.copiedMsg != "" {
+		indicators = append(indicators, style.B(v.copiedMsg, ""))
+	}
+	line1 := style.FormatBindings(indicators)
+	if v.viewport.Width > 0 {
+		line1 = ansi.Truncate(line1, v.viewport.Width-2, "…")
+	}
+
+	var actions []style.Binding
+	if !v.data.Protected && len(v.keys) > 0 {
+		label := "reveal"
+		if key, ok := v.selectedKey(); ok && v.revealed[key] {
+			label = "hide"
+		}
+		actions = append(actions, style.B("v", label), style.B("c", "copy"))
+	}
+	actions = append(actions, style.B("←", "back"))
+	return line1 + "\n" + style.ActionFooter(actions, v.viewport.Width)
+}
+
+func (v *View) SetSize(width, height int) {
+	if width == 0 || height == 0 {
+		return
+	}
+	v.viewport.Width = width
+	v.viewport.Height = height
+	v.render()
+}
+
+func clearCopiedCmd() bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		time.Sleep(1500 * time.Millisecond)
+		return clearCopiedMsg{}
+	}
+}
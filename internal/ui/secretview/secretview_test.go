@@ -0,0 +1,105 @@
+package secretview
+
+import (
+	"strings"
+	"testing"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/resources"
+)
+
+type protectedSecrets struct {
+	*resources.Secrets
+}
+
+func (p protectedSecrets) SecretData(item resources.ResourceItem) (resources.SecretData, error) {
+	data, err := p.Secrets.SecretData(item)
+	data.Protected = true
+	return data, err
+}
+
+func press(v *View, keys ...string) {
+	for _, k := range keys {
+		v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune(k)})
+	}
+}
+
+func stubClipboard(t *testing.T, copy func(string)) {
+	t.Helper()
+	prev := copyToClipboard
+	copyToClipboard = func(s string) error { copy(s); return nil }
+	t.Cleanup(func() { copyToClipboard = prev })
+}
+
+func content(v *View) string {
+	return ansi.Strip(v.viewport.View())
+}
+
+func TestSecretValuesStayMaskedUntilRevealed(t *testing.T) {
+	item := resources.ResourceItem{Name: "postgres-credentials", Kind: "Opaque"}
+	v := New(item, resources.NewSecrets())
+	v.SetSize(100, 20)
+
+	if got := content(v); strings.Contains(got, "mock-postgres-credentials") || !strings.Contains(got, mask) {
+		t.Fatalf("values should be masked:\n%s", got)
+	}
+	// Keys are sorted: password comes first.
+	press(v, "v")
+	if got := content(v); !strings.Contains(got, "mock-postgres-credentials-Zq8w") || !strings.Contains(got, mask) {
+		t.Fatalf("password should be revealed, username still masked:\n%s", got)
+	}
+	press(v, "v")
+	if strings.Contains(content(v), "mock-postgres-credentials") {
+		t.Fatal("v should hide the value again")
+	}
+
+	var copied string
+	stubClipboard(t, func(s string) { copied = s })
+	press(v, "j", "c")
+	if copied != "postgres" {
+		t.Fatalf("expected the username to be copied, got %q", copied)
+	}
+}
+
+func TestTLSSecretDescribesCertificateWithoutRevealingKey(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "api-gateway-tls", Kind: "kubernetes.io/tls"}, resources.NewSecrets())
+	v.SetSize(120, 20)
+	got := content(v)
+	for _, want := range []string{"tls.crt", "tls.key", "subject: CN=api-gateway-tls.example.com", "names:   api-gateway-tls.example.com", "expires in 75d"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "PRIVATE KEY") {
+		t.Fatal("the key must stay masked")
+	}
+}
+
+func TestDockerConfigListsRegistriesAndRevealsCredentials(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "docker-registry-creds", Kind: "kubernetes.io/dockerconfigjson"}, resources.NewSecrets())
+	v.SetSize(120, 20)
+	if got := content(v); !strings.Contains(got, "registry: ghcr.io") || strings.Contains(got, "ci-bot") {
+		t.Fatalf("expected registry without credentials:\n%s", got)
+	}
+	press(v, "v")
+	if got := content(v); !strings.Contains(got, "user: ci-bot  password: ghp_mockRegistryToken42") {
+		t.Fatalf("expected decoded credentials:\n%s", got)
+	}
+}
+
+func TestProtectedContextDisablesRevealAndCopy(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "redis-password", Kind: "Opaque"}, protectedSecrets{resources.NewSecrets()})
+	v.SetSize(100, 20)
+	copied := false
+	stubClipboard(t, func(string) { copied = true })
+
+	press(v, "v", "c")
+	if strings.Contains(content(v), "mock-redis") || copied {
+		t.Fatal("protected secrets must not be revealed or copied")
+	}
+	footer := ansi.Strip(v.Footer())
+	if !strings.Contains(footer, "reveal disabled") || strings.Contains(footer, "v reveal") {
+		t.Fatalf("footer should explain the protection, got %q", footer)
+	}
+}
@@ -7,28 +7,124 @@ import (
 )
 
 var (
-	Header           = lipgloss.NewStyle().Bold(true)
-	Separator        = lipgloss.NewStyle().Foreground(lipgloss.Color("236"))
-	Scope            = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
-	ScopeValue       = lipgloss.NewStyle().Foreground(lipgloss.Color("247"))
-	ScopeActive      = lipgloss.NewStyle().Foreground(lipgloss.Color("247"))
-	ScopeActiveValue = lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
-	ContextProd      = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
-	Crumb            = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
-	CrumbValue       = lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
-	CrumbSep         = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	Active           = lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
-	NavSep           = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	Footer           = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	FooterKey        = lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Bold(true)
-	FooterLabel      = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	Muted            = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	Warning          = lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Bold(true)
-	Error            = lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
-	Healthy          = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
-	FilterPrompt     = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	Header           lipgloss.Style
+	Separator        lipgloss.Style
+	Scope            lipgloss.Style
+	ScopeValue       lipgloss.Style
+	ScopeActive      lipgloss.Style
+	ScopeActiveValue lipgloss.Style
+	ContextProd      lipgloss.Style
+	Crumb            lipgloss.Style
+	CrumbValue       lipgloss.Style
+	CrumbSep         lipgloss.Style
+	Active           lipgloss.Style
+	NavSep           lipgloss.Style
+	Footer           lipgloss.Style
+	FooterKey        lipgloss.Style
+	FooterLabel      lipgloss.Style
+	Muted            lipgloss.Style
+	Warning          lipgloss.Style
+	Error            lipgloss.Style
+	Healthy          lipgloss.Style
+	FilterPrompt     lipgloss.Style
+	Strong           lipgloss.Style
+	Label            lipgloss.Style
+	Accent           lipgloss.Style
+	Info             lipgloss.Style
+
+	// Selected is the selected row of a table, Highlight the cursor of a
+	// picker or menu and CursorLine the cursor line of a text view.
+	Selected   lipgloss.Style
+	Highlight  lipgloss.Style
+	CursorLine lipgloss.Style
+
+	SyntaxKey     lipgloss.Style
+	SyntaxString  lipgloss.Style
+	SyntaxLiteral lipgloss.Style
+	SyntaxComment lipgloss.Style
+	SyntaxPunct   lipgloss.Style
+	DiffAdded     lipgloss.Style
+	DiffRemoved   lipgloss.Style
+	DiffHunk      lipgloss.Style
+
+	// Border colors the frames of overlays.
+	Border lipgloss.TerminalColor
 )
 
+func init() {
+	build(Dark)
+}
+
+// build derives the styles from a theme.
+func build(t Theme) {
+	fg := func(col Color) lipgloss.Style {
+		return lipgloss.NewStyle().Foreground(col.terminal())
+	}
+	// quiet is text that steps back; without colors it is drawn faint.
+	quiet := func(col Color) lipgloss.Style {
+		return fg(col).Faint(t.Mono)
+	}
+
+	Header = lipgloss.NewStyle().Bold(true)
+	Separator = quiet(t.Separator)
+	Scope = fg(t.Label)
+	ScopeValue = fg(t.Dim)
+	ScopeActive = fg(t.Dim)
+	ScopeActiveValue = fg(t.Subtle)
+	ContextProd = fg(t.Accent).Bold(true)
+	Crumb = fg(t.Label)
+	CrumbValue = fg(t.Subtle)
+	CrumbSep = quiet(t.Faint)
+	Active = fg(t.Subtle)
+	NavSep = quiet(t.Faint)
+	Footer = quiet(t.Muted)
+	FooterKey = fg(t.Text).Bold(true)
+	FooterLabel = quiet(t.Muted)
+	Muted = quiet(t.Muted)
+	Warning = fg(t.Warning).Bold(true)
+	Error = fg(t.Error).Bold(true)
+	Healthy = fg(t.Healthy)
+	FilterPrompt = quiet(t.Muted)
+	Strong = fg(t.Text).Bold(true)
+	Label = fg(t.Label)
+	Accent = fg(t.Accent)
+	Info = fg(t.Info)
+
+	Selected = fg(t.SelectionFg).Background(t.SelectionBg.terminal()).Bold(true).Reverse(t.Mono)
+	Highlight = fg(t.HighlightFg).Background(t.HighlightBg.terminal()).Bold(true).Reverse(t.Mono)
+	CursorLine = lipgloss.NewStyle().Background(t.Cursor.terminal()).Reverse(t.Mono)
+
+	SyntaxKey = fg(t.SyntaxKey)
+	SyntaxString = fg(t.SyntaxString)
+	SyntaxLiteral = fg(t.SyntaxLiteral)
+	SyntaxComment = quiet(t.Muted).Italic(true)
+	SyntaxPunct = fg(t.Label)
+	DiffAdded = fg(t.Added)
+	DiffRemoved = fg(t.Removed)
+	DiffHunk = fg(t.Info)
+
+	Border = t.Muted.terminal()
+}
+
+// SelectedRow applies the selected-row colors to a list delegate's title
+// style, keeping its border and padding.
+func SelectedRow(s lipgloss.Style) lipgloss.Style {
+	return s.Bold(true).
+		Foreground(current.SelectionFg.terminal()).
+		Background(current.SelectionBg.terminal()).
+		Reverse(current.Mono)
+}
+
+// Highlighted renders the cursor row of a picker or menu. Mono themes also
+// mark it with a leading ▌ in place of the row's first space, so the cursor
+// stays visible where attributes are not shown either.
+func Highlighted(row string) string {
+	if current.Mono && strings.HasPrefix(row, " ") {
+		row = "▌" + row[1:]
+	}
+	return Highlight.Render(row)
+}
+
 func SeparatorLine(width int) string {
 	if width <= 0 {
 		return Separator.Render("┈")
@@ -45,16 +141,60 @@ const (
 	statusError
 )
 
+// Status renders a status value in its severity color, preceded by a
+// severity symbol so it does not rely on color alone.
 func Status(value string) string {
+	return StatusStyle(value).Render(StatusSymbol(value) + " " + value)
+}
+
+// StatusStyle returns the style of a status's severity.
+func StatusStyle(value string) lipgloss.Style {
+	switch classifyStatus(value) {
+	case statusError:
+		return Error
+	case statusWarning:
+		return Warning
+	case statusNeutral:
+		return Muted
+	default:
+		return Healthy
+	}
+}
+
+// StatusSymbolWidth is the number of columns StatusSymbol and its trailing
+// space take in front of a status.
+const StatusSymbolWidth = 2
+
+// StatusSymbol returns the severity marker of a status: ✗ error, ▲ warning,
+// ○ neutral and ● healthy.
+func StatusSymbol(value string) string {
+	switch classifyStatus(value) {
+	case statusError:
+		return "✗"
+	case statusWarning:
+		return "▲"
+	case statusNeutral:
+		return "○"
+	default:
+		return "●"
+	}
+}
+
+// StatusSGR returns the raw escape sequence that starts a status in its
+// severity color, for rows whose background must survive; see SGR.
+func StatusSGR(value string) string {
 	switch classifyStatus(value) {
 	case statusError:
-		return Error.Render(value)
+		return SGR(current.Error, "1")
 	case statusWarning:
-		return Warning.Render(value)
+		return SGR(current.Warning, "1")
 	case statusNeutral:
-		return Muted.Render(value)
+		if current.Mono {
+			return SGR(current.Muted, "2")
+		}
+		return SGR(current.Muted)
 	default:
-		return Healthy.Render(value)
+		return SGR(current.Healthy)
 	}
 }
 
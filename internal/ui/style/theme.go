@@ -0,0 +1,329 @@
+package style
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Color is a palette entry: a 256-color number or #rrggbb value for capable
+// terminals, and a 16-color fallback (0–15). An empty Full means the
+// terminal's default color.
+type Color struct {
+	Full  string
+	Basic string
+}
+
+func c(full, basic string) Color {
+	return Color{Full: full, Basic: basic}
+}
+
+// terminal returns the lipgloss color for c, degrading to Basic on 16-color
+// terminals.
+func (c Color) terminal() lipgloss.TerminalColor {
+	if c.Full == "" {
+		return lipgloss.NoColor{}
+	}
+	basic := c.Basic
+	if basic == "" {
+		basic = c.Full
+	}
+	return lipgloss.CompleteColor{TrueColor: c.Full, ANSI256: c.Full, ANSI: basic}
+}
+
+// Theme is the palette every view draws from. Mono themes carry no colors
+// and mark selection and emphasis with reverse video, bold and faint text.
+type Theme struct {
+	Name string
+	Mono bool
+
+	Text      Color // keys in footers, emphasized values
+	Subtle    Color // active scope values and breadcrumbs
+	Dim       Color // scope values, generated pod name suffixes
+	Label     Color // scope labels, field names, punctuation
+	Muted     Color // footer labels, comments, borders
+	Faint     Color // breadcrumb and nav separators
+	Separator Color // horizontal rules
+	Cursor    Color // background of the cursor line in text views
+
+	SelectionFg Color // selected table row
+	SelectionBg Color
+	HighlightFg Color // cursor in pickers and menus
+	HighlightBg Color
+
+	Accent  Color // prod context, container names
+	Healthy Color
+	Warning Color
+	Error   Color
+	Info    Color // probes, diff hunk headers
+
+	SyntaxKey     Color
+	SyntaxString  Color
+	SyntaxLiteral Color
+	Timestamp     Color
+	Added         Color
+	Removed       Color
+	FindTarget    Color // jump letters in find mode
+}
+
+// Built-in theme names.
+const (
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+	ThemeMono         = "mono"
+)
+
+// Themes lists the built-in themes that can be picked by name.
+var Themes = []string{ThemeDark, ThemeLight, ThemeHighContrast, ThemeMono}
+
+// Dark is the default theme, tuned for dark terminal backgrounds.
+var Dark = Theme{
+	Name:          ThemeDark,
+	Text:          c("252", "15"),
+	Subtle:        c("250", "7"),
+	Dim:           c("247", "7"),
+	Label:         c("244", "8"),
+	Muted:         c("241", "8"),
+	Faint:         c("240", "8"),
+	Separator:     c("236", "8"),
+	Cursor:        c("237", "8"),
+	SelectionFg:   c("15", "15"),
+	SelectionBg:   c("236", "8"),
+	HighlightFg:   c("0", "0"),
+	HighlightBg:   c("250", "7"),
+	Accent:        c("214", "3"),
+	Healthy:       c("10", "10"),
+	Warning:       c("3", "3"),
+	Error:         c("1", "1"),
+	Info:          c("6", "6"),
+	SyntaxKey:     c("110", "4"),
+	SyntaxString:  c("150", "2"),
+	SyntaxLiteral: c("179", "3"),
+	Timestamp:     c("109", "6"),
+	Added:         c("2", "2"),
+	Removed:       c("1", "1"),
+	FindTarget:    c("15", "15"),
+}
+
+// Light suits light terminal backgrounds.
+var Light = Theme{
+	Name:          ThemeLight,
+	Text:          c("235", "0"),
+	Subtle:        c("238", "0"),
+	Dim:           c("240", "8"),
+	Label:         c("243", "8"),
+	Muted:         c("245", "8"),
+	Faint:         c("248", "7"),
+	Separator:     c("253", "7"),
+	Cursor:        c("254", "7"),
+	SelectionFg:   c("232", "0"),
+	SelectionBg:   c("253", "7"),
+	HighlightFg:   c("255", "15"),
+	HighlightBg:   c("238", "0"),
+	Accent:        c("166", "5"),
+	Healthy:       c("28", "2"),
+	Warning:       c("130", "3"),
+	Error:         c("160", "1"),
+	Info:          c("31", "6"),
+	SyntaxKey:     c("25", "4"),
+	SyntaxString:  c("28", "2"),
+	SyntaxLiteral: c("130", "5"),
+	Timestamp:     c("30", "6"),
+	Added:         c("28", "2"),
+	Removed:       c("160", "1"),
+	FindTarget:    c("232", "0"),
+}
+
+// HighContrast uses brighter text and a blue/orange/red severity scale that
+// stays distinguishable with red-green color blindness.
+var HighContrast = Theme{
+	Name:          ThemeHighContrast,
+	Text:          c("15", "15"),
+	Subtle:        c("15", "15"),
+	Dim:           c("253", "7"),
+	Label:         c("250", "7"),
+	Muted:         c("248", "7"),
+	Faint:         c("245", "7"),
+	Separator:     c("240", "8"),
+	Cursor:        c("238", "8"),
+	SelectionFg:   c("0", "0"),
+	SelectionBg:   c("15", "15"),
+	HighlightFg:   c("0", "0"),
+	HighlightBg:   c("11", "11"),
+	Accent:        c("214", "11"),
+	Healthy:       c("39", "12"),
+	Warning:       c("214", "11"),
+	Error:         c("197", "9"),
+	Info:          c("51", "14"),
+	SyntaxKey:     c("81", "14"),
+	SyntaxString:  c("230", "15"),
+	SyntaxLiteral: c("214", "11"),
+	Timestamp:     c("117", "14"),
+	Added:         c("39", "12"),
+	Removed:       c("197", "9"),
+	FindTarget:    c("11", "11"),
+}
+
+// Mono has no colors at all. It is used when NO_COLOR is set; picker
+// cursors are then marked with a symbol.
+var Mono = Theme{Name: ThemeMono, Mono: true}
+
+// ThemeNamed returns the built-in theme called name.
+func ThemeNamed(name string) (Theme, bool) {
+	switch name {
+	case ThemeDark:
+		return Dark, true
+	case ThemeLight:
+		return Light, true
+	case ThemeHighContrast:
+		return HighContrast, true
+	case ThemeMono:
+		return Mono, true
+	}
+	return Theme{}, false
+}
+
+// slots names the palette entries that can be overridden, in display order.
+var slots = []struct {
+	name  string
+	color func(*Theme) *Color
+}{
+	{"text", func(t *Theme) *Color { return &t.Text }},
+	{"subtle", func(t *Theme) *Color { return &t.Subtle }},
+	{"dim", func(t *Theme) *Color { return &t.Dim }},
+	{"label", func(t *Theme) *Color { return &t.Label }},
+	{"muted", func(t *Theme) *Color { return &t.Muted }},
+	{"faint", func(t *Theme) *Color { return &t.Faint }},
+	{"separator", func(t *Theme) *Color { return &t.Separator }},
+	{"cursor", func(t *Theme) *Color { return &t.Cursor }},
+	{"selectionFg", func(t *Theme) *Color { return &t.SelectionFg }},
+	{"selectionBg", func(t *Theme) *Color { return &t.SelectionBg }},
+	{"highlightFg", func(t *Theme) *Color { return &t.HighlightFg }},
+	{"highlightBg", func(t *Theme) *Color { return &t.HighlightBg }},
+	{"accent", func(t *Theme) *Color { return &t.Accent }},
+	{"healthy", func(t *Theme) *Color { return &t.Healthy }},
+	{"warning", func(t *Theme) *Color { return &t.Warning }},
+	{"error", func(t *Theme) *Color { return &t.Error }},
+	{"info", func(t *Theme) *Color { return &t.Info }},
+	{"syntaxKey", func(t *Theme) *Color { return &t.SyntaxKey }},
+	{"syntaxString", func(t *Theme) *Color { return &t.SyntaxString }},
+	{"syntaxLiteral", func(t *Theme) *Color { return &t.SyntaxLiteral }},
+	{"timestamp", func(t *Theme) *Color { return &t.Timestamp }},
+	{"added", func(t *Theme) *Color { return &t.Added }},
+	{"removed", func(t *Theme) *Color { return &t.Removed }},
+	{"findTarget", func(t *Theme) *Color { return &t.FindTarget }},
+}
+
+// ColorNames lists the palette entries Override accepts.
+func ColorNames() []string {
+	names := make([]string, len(slots))
+	for i, slot := range slots {
+		names[i] = slot.name
+	}
+	return names
+}
+
+// Override returns t with palette entries replaced. Keys are entry names
+// from ColorNames; values are 256-color numbers or #rrggbb. Overrides apply
+// on every terminal, so one value serves as its own 16-color fallback.
+func (t Theme) Override(colors map[string]string) (Theme, error) {
+	for _, slot := range slots {
+		value, ok := colors[slot.name]
+		if !ok {
+			continue
+		}
+		if !validColor(value) {
+			return Theme{}, fmt.Errorf("colors.%s: %q is not a color number 0–255 or #rrggbb", slot.name, value)
+		}
+		*slot.color(&t) = Color{Full: value}
+	}
+	for name := range colors {
+		if !containsName(name) {
+			return Theme{}, fmt.Errorf("colors.%s: unknown color; known: %s", name, strings.Join(ColorNames(), ", "))
+		}
+	}
+	return t, nil
+}
+
+func containsName(name string) bool {
+	for _, slot := range slots {
+		if slot.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func validColor(value string) bool {
+	if hex, ok := strings.CutPrefix(value, "#"); ok {
+		if len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+var (
+	current = Dark
+	// profile is the color profile of the raw escape sequences built by SGR.
+	// Until Apply runs it is 256 colors, so output does not depend on the
+	// terminal tests run in.
+	profile = termenv.ANSI256
+)
+
+// Current returns the theme in use.
+func Current() Theme {
+	return current
+}
+
+// Apply makes t the theme of all styles in this package. It is meant to be
+// called once at startup, before views are created. The profile sets how
+// colors are written: termenv.ANSI picks the 16-color fallbacks and
+// termenv.Ascii drops colors.
+func Apply(t Theme, p termenv.Profile) {
+	current = t
+	profile = p
+	lipgloss.SetColorProfile(p)
+	build(t)
+}
+
+// SGR returns the escape sequence that starts text with the given SGR
+// attributes ("1" bold, "4" underline) in color fg. It is for code that
+// styles part of a line without resetting the rest, such as a selected row's
+// background. It returns "" when there is nothing to set or the terminal
+// shows no styling.
+func SGR(fg Color, attrs ...string) string {
+	if profile == termenv.Ascii {
+		return ""
+	}
+	params := append([]string(nil), attrs...)
+	if seq := colorSequence(fg); seq != "" {
+		params = append(params, seq)
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+func colorSequence(col Color) string {
+	if col.Full == "" {
+		return ""
+	}
+	value := col.Full
+	if profile == termenv.ANSI && col.Basic != "" {
+		value = col.Basic
+	}
+	tc := profile.Color(value)
+	if tc == nil {
+		return ""
+	}
+	return tc.Sequence(false)
+}
@@ -0,0 +1,75 @@
+package style
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func applyForTest(t *testing.T, theme Theme, p termenv.Profile) {
+	t.Helper()
+	Apply(theme, p)
+	t.Cleanup(func() { Apply(Dark, termenv.ANSI256) })
+}
+
+func TestOverrideReplacesNamedColors(t *testing.T) {
+	theme, err := Dark.Override(map[string]string{"warning": "208", "selectionBg": "#334455"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Warning != (Color{Full: "208"}) || theme.SelectionBg != (Color{Full: "#334455"}) {
+		t.Fatalf("expected overrides applied, got %+v / %+v", theme.Warning, theme.SelectionBg)
+	}
+	if theme.Error != Dark.Error {
+		t.Fatal("expected other colors untouched")
+	}
+}
+
+func TestOverrideRejectsUnknownNamesAndBadValues(t *testing.T) {
+	if _, err := Dark.Override(map[string]string{"warnings": "1"}); err == nil || !strings.Contains(err.Error(), "colors.warnings") {
+		t.Fatalf("expected unknown color error, got %v", err)
+	}
+	for _, value := range []string{"256", "red", "#12345", ""} {
+		if _, err := Dark.Override(map[string]string{"error": value}); err == nil {
+			t.Fatalf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestSGRDegradesToBasicColorsAndDropsColorsOnAscii(t *testing.T) {
+	if got := SGR(Dark.SyntaxKey, "1"); got != "\x1b[1;38;5;110m" {
+		t.Fatalf("expected 256-color sequence, got %q", got)
+	}
+
+	applyForTest(t, Dark, termenv.ANSI)
+	if got := SGR(Dark.SyntaxKey, "1"); got != "\x1b[1;34m" {
+		t.Fatalf("expected 16-color fallback, got %q", got)
+	}
+
+	Apply(Mono, termenv.Ascii)
+	if got := SGR(Dark.SyntaxKey, "1"); got != "" {
+		t.Fatalf("expected no styling without colors, got %q", got)
+	}
+}
+
+func TestStatusSymbolsFollowSeverity(t *testing.T) {
+	cases := map[string]string{
+		"CrashLoopBackOff": "✗",
+		"Pending":          "▲",
+		"Suspended":        "○",
+		"Running":          "●",
+	}
+	for status, want := range cases {
+		if got := StatusSymbol(status); got != want {
+			t.Fatalf("StatusSymbol(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestMonoThemeMarksHighlightedRow(t *testing.T) {
+	applyForTest(t, Mono, termenv.Ascii)
+	if got := Highlighted(" default "); got != "▌default " {
+		t.Fatalf("expected marked cursor row, got %q", got)
+	}
+}
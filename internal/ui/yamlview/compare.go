@@ -0,0 +1,282 @@
+package yamlview
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/ui/style"
+	"github.com/dloss/podji/internal/ui/viewstate"
+	"sigs.k8s.io/yaml"
+)
+
+// CompareSide is one object of a comparison: where it came from and its YAML
+// as read at the time it was picked.
+type CompareSide struct {
+	Label string
+	YAML  string
+}
+
+// noiseMetadata are the metadata fields that differ between any two objects.
+var noiseMetadata = []string{"uid", "resourceVersion", "managedFields", "generation", "selfLink", "creationTimestamp"}
+
+// timestampFields are dropped wherever they appear, mostly in status
+// conditions, since they only record when something happened.
+var timestampFields = map[string]bool{
+	"creationTimestamp":  true,
+	"lastTransitionTime": true,
+	"lastUpdateTime":     true,
+	"lastProbeTime":      true,
+	"lastHeartbeatTime":  true,
+	"lastScheduleTime":   true,
+	"lastSuccessfulTime": true,
+	"startTime":          true,
+	"startedAt":          true,
+	"finishedAt":         true,
+	"completionTime":     true,
+}
+
+// compareRow is one line of the side-by-side view. Kind is ' ' for equal
+// lines, '~' for changed ones, '-' for lines only on the left and '+' for
+// lines only on the right.
+type compareRow struct {
+	kind        byte
+	left, right string
+}
+
+// CompareView shows a structural diff of two objects side by side.
+type CompareView struct {
+	left, right CompareSide
+	viewport    viewport.Model
+
+	showNoise   bool
+	rows        []compareRow
+	changes     []int
+	changeIndex int
+	err         string
+}
+
+func NewCompare(left, right CompareSide) *CompareView {
+	v := &CompareView{left: left, right: right, viewport: viewport.New(0, 0)}
+	v.load()
+	return v
+}
+
+// load normalizes both documents and pairs up their lines. Both sides are
+// re-marshalled with sorted keys, so field order does not count as a change.
+func (v *CompareView) load() {
+	v.rows, v.changes, v.changeIndex, v.err = nil, nil, 0, ""
+	a, err := normalizeForCompare(v.left.YAML, v.showNoise)
+	if err != nil {
+		v.err = v.left.Label + ": " + err.Error()
+		v.render()
+		return
+	}
+	b, err := normalizeForCompare(v.right.YAML, v.showNoise)
+	if err != nil {
+		v.err = v.right.Label + ": " + err.Error()
+		v.render()
+		return
+	}
+	v.rows = pairRows(diffLines(a, b))
+	for i, row := range v.rows {
+		if row.kind != ' ' && (i == 0 || v.rows[i-1].kind == ' ') {
+			v.changes = append(v.changes, i)
+		}
+	}
+	v.render()
+}
+
+func normalizeForCompare(text string, showNoise bool) ([]string, error) {
+	var obj map[string]any
+	if err := yaml.Unmarshal([]byte(text), &obj); err != nil {
+		return nil, err
+	}
+	if !showNoise {
+		if meta, ok := obj["metadata"].(map[string]any); ok {
+			for _, field := range noiseMetadata {
+				delete(meta, field)
+			}
+		}
+		dropTimestamps(obj)
+	}
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(string(out)), nil
+}
+
+func dropTimestamps(node any) {
+	switch n := node.(type) {
+	case map[string]any:
+		for key, val := range n {
+			if timestampFields[key] {
+				delete(n, key)
+				continue
+			}
+			dropTimestamps(val)
+		}
+	case []any:
+		for _, item := range n {
+			dropTimestamps(item)
+		}
+	}
+}
+
+// pairRows lines up a removal run with the addition run that follows it, so
+// a changed value sits next to its counterpart.
+func pairRows(ops []diffOp) []compareRow {
+	var rows []compareRow
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			rows = append(rows, compareRow{kind: ' ', left: ops[i].text, right: ops[i].text})
+			i++
+			continue
+		}
+		var removed, added []string
+		for ; i < len(ops) && ops[i].kind == '-'; i++ {
+			removed = append(removed, ops[i].text)
+		}
+		for ; i < len(ops) && ops[i].kind == '+'; i++ {
+			added = append(added, ops[i].text)
+		}
+		for k := 0; k < max(len(removed), len(added)); k++ {
+			switch {
+			case k < len(removed) && k < len(added):
+				rows = append(rows, compareRow{kind: '~', left: removed[k], right: added[k]})
+			case k < len(removed):
+				rows = append(rows, compareRow{kind: '-', left: removed[k]})
+			default:
+				rows = append(rows, compareRow{kind: '+', right: added[k]})
+			}
+		}
+	}
+	return rows
+}
+
+func (v *CompareView) render() {
+	if v.err != "" {
+		v.viewport.SetContent(style.Error.Render("Compare unavailable: " + v.err))
+		return
+	}
+	if len(v.changes) == 0 {
+		v.viewport.SetContent(style.Muted.Render("No differences."))
+		return
+	}
+	col := v.columnWidth()
+	out := make([]string, len(v.rows))
+	for i, row := range v.rows {
+		left := ansi.Truncate(row.left, col, "…")
+		right := ansi.Truncate(row.right, col, "…")
+		left += strings.Repeat(" ", max(0, col-ansi.StringWidth(left)))
+		switch row.kind {
+		case '~', '-':
+			left = style.DiffRemoved.Render(left)
+		}
+		switch row.kind {
+		case '~', '+':
+			right = style.DiffAdded.Render(right)
+		}
+		out[i] = left + style.Separator.Render(" │ ") + right
+	}
+	v.viewport.SetContent(strings.Join(out, "\n"))
+}
+
+func (v *CompareView) columnWidth() int {
+	if v.viewport.Width <= 3 {
+		return 40
+	}
+	return (v.viewport.Width - 3) / 2
+}
+
+func (v *CompareView) Init() bubbletea.Cmd { return nil }
+
+func (v *CompareView) Update(msg bubbletea.Msg) viewstate.Update {
+	if key, ok := msg.(bubbletea.KeyMsg); ok {
+		switch key.String() {
+		case "n":
+			if len(v.changes) > 0 {
+				v.changeIndex = (v.changeIndex + 1) % len(v.changes)
+				v.scrollToChange()
+			}
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "b":
+			if len(v.changes) > 0 {
+				v.changeIndex = (v.changeIndex - 1 + len(v.changes)) % len(v.changes)
+				v.scrollToChange()
+			}
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "a":
+			v.showNoise = !v.showNoise
+			v.load()
+			v.viewport.GotoTop()
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "home", "g":
+			v.viewport.GotoTop()
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "end", "G":
+			v.viewport.GotoBottom()
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		}
+	}
+	updated, cmd := v.viewport.Update(msg)
+	v.viewport = updated
+	return viewstate.Update{Action: viewstate.None, Next: v, Cmd: cmd}
+}
+
+// scrollToChange shows the current change with a little context above it.
+func (v *CompareView) scrollToChange() {
+	v.viewport.SetYOffset(max(0, v.changes[v.changeIndex]-2))
+}
+
+func (v *CompareView) View() string {
+	col := v.columnWidth()
+	left := ansi.Truncate(v.left.Label, col, "…")
+	left += strings.Repeat(" ", max(0, col-ansi.StringWidth(left)))
+	header := style.FooterKey.Render(left) + style.Separator.Render(" │ ") + style.FooterKey.Render(ansi.Truncate(v.right.Label, col, "…"))
+	return header + "\n" + v.viewport.View()
+}
+
+func (v *CompareView) Breadcrumb() string {
+	return "compare"
+}
+
+func (v *CompareView) Footer() string {
+	var indicators []style.Binding
+	if v.err == "" {
+		indicators = append(indicators, style.B("changes", strconv.Itoa(len(v.changes))))
+		if len(v.changes) > 0 {
+			indicators = append(indicators, style.B("at", matchSummary(v.changeIndex, len(v.changes))))
+		}
+	}
+	if !v.showNoise {
+		indicators = append(indicators, style.B("hidden", "uid, resourceVersion, timestamps, managedFields"))
+	}
+	line1 := style.FormatBindings(indicators)
+	if v.viewport.Width > 0 {
+		line1 = ansi.Truncate(line1, v.viewport.Width-2, "…")
+	}
+
+	noiseLabel := "show all fields"
+	if v.showNoise {
+		noiseLabel = "hide noise"
+	}
+	actions := []style.Binding{
+		style.B("n/b", "next/prev change"),
+		style.B("a", noiseLabel),
+		style.B("←", "back"),
+	}
+	return line1 + "\n" + style.ActionFooter(actions, v.viewport.Width)
+}
+
+func (v *CompareView) SetSize(width, height int) {
+	if width == 0 || height == 0 {
+		return
+	}
+	v.viewport.Width = width
+	v.viewport.Height = max(1, height-1)
+	v.render()
+}
@@ -0,0 +1,111 @@
+package yamlview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dloss/podji/internal/ui/style"
+)
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimRight(s, "\n"), "\n")
+}
+
+// diffOp is one line of a line diff: kept (' '), removed ('-') or added
+// ('+'), with the positions in both inputs where it occurs.
+type diffOp struct {
+	kind byte
+	text string
+	ai   int
+	bi   int
+}
+
+// diffLines computes a minimal line diff of a and b from their longest common
+// subsequence. Removals come before additions within a change.
+func diffLines(a, b []string) []diffOp {
+	// Longest common subsequence table, filled from the end.
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i], i, j})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, diffOp{'+', b[j], i, j})
+			j++
+		default:
+			ops = append(ops, diffOp{'-', a[i], i, j})
+			i++
+		}
+	}
+	return ops
+}
+
+// unifiedDiff renders the difference between a and b as unified diff hunks
+// with the given number of context lines. Equal inputs yield no lines.
+func unifiedDiff(a, b []string, context int) []string {
+	ops := diffLines(a, b)
+	var out []string
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+		// Grow the hunk while the next change is within two contexts.
+		end := start
+		for k := start; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				end = k
+			} else if k-end > 2*context {
+				break
+			}
+		}
+		from := max(0, start-context)
+		to := min(len(ops), end+context+1)
+		aCount, bCount := 0, 0
+		body := make([]string, 0, to-from)
+		for _, o := range ops[from:to] {
+			if o.kind != '+' {
+				aCount++
+			}
+			if o.kind != '-' {
+				bCount++
+			}
+			body = append(body, string(o.kind)+o.text)
+		}
+		out = append(out, fmt.Sprintf("@@ -%d,%d +%d,%d @@", ops[from].ai+1, aCount, ops[from].bi+1, bCount))
+		out = append(out, body...)
+		start = to
+	}
+	return out
+}
+
+func highlightDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++"):
+		return style.Header.Render(line)
+	case strings.HasPrefix(line, "@@"):
+		return style.DiffHunk.Render(line)
+	case strings.HasPrefix(line, "-"):
+		return style.DiffRemoved.Render(line)
+	case strings.HasPrefix(line, "+"):
+		return style.DiffAdded.Render(line)
+	}
+	return line
+}
@@ -0,0 +1,198 @@
+package yamlview
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dloss/podji/internal/resources"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+	helmReleaseAnnotation = "meta.helm.sh/release-name"
+	helmNSAnnotation      = "meta.helm.sh/release-namespace"
+	driftContext          = 3
+)
+
+// serverFields are set by the API server on every object and never appear in
+// an applied manifest, so they are left out of the live side of the diff.
+var serverFields = []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation", "selfLink"}
+
+// bookkeepingAnnotations are written by kubectl, Helm and controllers rather
+// than by whoever authored the manifest.
+var bookkeepingAnnotations = []string{
+	lastAppliedAnnotation,
+	"meta.helm.sh/",
+	"deployment.kubernetes.io/revision",
+}
+
+// drift is a unified diff of the desired state of an object against its live
+// state, together with a short description of where the desired state came
+// from.
+type drift struct {
+	source string
+	lines  []string
+}
+
+// computeDrift compares the live YAML with the last-applied-configuration
+// annotation or, failing that, with the object's document in the manifest of
+// the Helm release that owns it.
+func computeDrift(live string, helm resources.HelmManifestReader) (drift, error) {
+	var liveObj map[string]any
+	if err := yaml.Unmarshal([]byte(live), &liveObj); err != nil {
+		return drift{}, fmt.Errorf("cannot parse live object: %w", err)
+	}
+	meta, _ := liveObj["metadata"].(map[string]any)
+	annotations, _ := meta["annotations"].(map[string]any)
+
+	var desired map[string]any
+	var source string
+	if applied, ok := annotations[lastAppliedAnnotation].(string); ok && applied != "" {
+		if err := json.Unmarshal([]byte(applied), &desired); err != nil {
+			return drift{}, fmt.Errorf("cannot parse last-applied-configuration: %w", err)
+		}
+		source = "last-applied"
+	} else if release, ok := annotations[helmReleaseAnnotation].(string); ok && release != "" {
+		if helm == nil {
+			return drift{}, errors.New("helm release manifests are not available here")
+		}
+		namespace, _ := annotations[helmNSAnnotation].(string)
+		if namespace == "" {
+			namespace, _ = meta["namespace"].(string)
+		}
+		manifest, err := helm.HelmManifest(namespace, release)
+		if err != nil {
+			return drift{}, err
+		}
+		kind, _ := liveObj["kind"].(string)
+		name, _ := meta["name"].(string)
+		if desired = manifestDocument(manifest, kind, name); desired == nil {
+			return drift{}, fmt.Errorf("%s %s not found in helm release %s", kind, name, release)
+		}
+		source = "helm " + release
+	} else {
+		return drift{}, errors.New("no last-applied-configuration or helm release annotation")
+	}
+
+	pruneObject(desired)
+	pruneObject(liveObj)
+	projected, _ := project(liveObj, desired, "").(map[string]any)
+
+	want, err := yaml.Marshal(desired)
+	if err != nil {
+		return drift{}, err
+	}
+	got, err := yaml.Marshal(projected)
+	if err != nil {
+		return drift{}, err
+	}
+	return drift{
+		source: source,
+		lines:  unifiedDiff(splitLines(string(want)), splitLines(string(got)), driftContext),
+	}, nil
+}
+
+// manifestDocument finds the document for kind/name in a multi-document
+// manifest.
+func manifestDocument(manifest, kind, name string) map[string]any {
+	for _, doc := range splitDocuments(manifest) {
+		var obj map[string]any
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil || obj == nil {
+			continue
+		}
+		meta, _ := obj["metadata"].(map[string]any)
+		if obj["kind"] == kind && meta["name"] == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+func splitDocuments(manifest string) []string {
+	var docs []string
+	var cur []string
+	for _, line := range strings.Split(manifest, "\n") {
+		if strings.TrimRight(line, " ") == "---" {
+			docs = append(docs, strings.Join(cur, "\n"))
+			cur = nil
+			continue
+		}
+		cur = append(cur, line)
+	}
+	return append(docs, strings.Join(cur, "\n"))
+}
+
+// pruneObject drops status, server-managed metadata and bookkeeping
+// annotations, which differ from any manifest by design.
+func pruneObject(obj map[string]any) {
+	delete(obj, "status")
+	meta, ok := obj["metadata"].(map[string]any)
+	if !ok {
+		return
+	}
+	for _, field := range serverFields {
+		delete(meta, field)
+	}
+	annotations, ok := meta["annotations"].(map[string]any)
+	if !ok {
+		return
+	}
+	for key := range annotations {
+		for _, prefix := range bookkeepingAnnotations {
+			if strings.HasPrefix(key, prefix) {
+				delete(annotations, key)
+			}
+		}
+	}
+	if len(annotations) == 0 {
+		delete(meta, "annotations")
+	}
+}
+
+// project keeps the parts of live that desired declares, so defaults filled
+// in by the API server do not show up as drift. Labels and annotations are
+// kept whole because keys added there are usually manual edits.
+func project(live, desired any, path string) any {
+	switch d := desired.(type) {
+	case map[string]any:
+		l, ok := live.(map[string]any)
+		if !ok {
+			return live
+		}
+		if path == ".metadata.labels" || path == ".metadata.annotations" {
+			return l
+		}
+		out := make(map[string]any, len(d))
+		for key, dv := range d {
+			if lv, ok := l[key]; ok {
+				out[key] = project(lv, dv, path+"."+key)
+			}
+		}
+		if path == ".metadata" {
+			for _, key := range []string{"labels", "annotations"} {
+				if lv, ok := l[key]; ok {
+					out[key] = lv
+				}
+			}
+		}
+		return out
+	case []any:
+		l, ok := live.([]any)
+		if !ok {
+			return live
+		}
+		out := make([]any, len(l))
+		for i, lv := range l {
+			if i < len(d) {
+				out[i] = project(lv, d[i], path)
+			} else {
+				out[i] = lv
+			}
+		}
+		return out
+	}
+	return live
+}
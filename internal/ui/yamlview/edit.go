@@ -0,0 +1,266 @@
+package yamlview
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/dloss/podji/internal/resources"
+)
+
+// applyTimeout caps a dry-run or a real apply.
+const applyTimeout = 15 * time.Second
+
+type editorDoneMsg struct {
+	path string
+	err  error
+}
+
+type applyResultMsg struct {
+	dryRun bool
+	result string
+	err    error
+}
+
+// editSession is an edit in progress. The buffer outlives failed dry-runs and
+// applies, so the user can fix the YAML and retry without losing changes.
+type editSession struct {
+	original string   // what the editor was first opened with
+	buffer   string   // last saved edit
+	busy     string   // request in flight: "dry-run" or "apply"
+	diff     []string // dry-run diff awaiting confirmation
+	err      string
+	conflict bool // err is a field manager conflict that force can resolve
+	force    bool // take over fields owned by other managers
+}
+
+// runEditor suspends the TUI and edits path in the user's editor. It is a
+// variable so tests can stand in for the editor.
+var runEditor = func(path string) bubbletea.Cmd {
+	return bubbletea.ExecProcess(editorCommand(path), func(err error) bubbletea.Msg {
+		return editorDoneMsg{path: path, err: err}
+	})
+}
+
+// editorCommand runs $VISUAL or $EDITOR, which may carry arguments such as
+// "code --wait", falling back to vi.
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// startEdit opens the object in the editor: the pending buffer when retrying,
+// otherwise the live YAML without managedFields and status.
+func (v *View) startEdit() bubbletea.Cmd {
+	if _, ok := v.resource.(resources.ObjectApplier); !ok {
+		v.actionMsg = "edit unavailable for " + v.resource.Name()
+		return clearActionCmd()
+	}
+	if v.edit == nil {
+		text := v.full
+		if index := indexYAML(text); index != nil {
+			text = stripNoise(text, index)
+		}
+		v.edit = &editSession{original: text}
+	}
+	content := v.edit.buffer
+	if content == "" {
+		content = v.edit.original
+	}
+	f, err := os.CreateTemp("", "podji-"+v.item.Name+"-*.yaml")
+	if err != nil {
+		v.edit.err = err.Error()
+		v.load()
+		return nil
+	}
+	_, err = f.WriteString(content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		v.edit.err = err.Error()
+		v.load()
+		return nil
+	}
+	return runEditor(f.Name())
+}
+
+// finishEdit reads back the edited file and starts a dry-run apply. Quitting
+// the editor without changes cancels the edit.
+func (v *View) finishEdit(msg editorDoneMsg) bubbletea.Cmd {
+	content, readErr := os.ReadFile(msg.path)
+	os.Remove(msg.path)
+	if v.edit == nil {
+		return nil
+	}
+	switch {
+	case msg.err != nil:
+		v.edit.err = "editor: " + msg.err.Error()
+	case readErr != nil:
+		v.edit.err = readErr.Error()
+	case v.edit.buffer == "" && string(content) == v.edit.original:
+		v.edit = nil
+		v.load()
+		v.actionMsg = "edit cancelled: no changes"
+		return clearActionCmd()
+	default:
+		v.edit.buffer = string(content)
+		v.edit.busy = "dry-run"
+		v.edit.diff = nil
+		v.edit.err = ""
+		v.edit.conflict = false
+		v.edit.force = false
+		v.load()
+		return v.applyCmd(true)
+	}
+	v.load()
+	return nil
+}
+
+func (v *View) applyCmd(dryRun bool) bubbletea.Cmd {
+	applier, ok := v.resource.(resources.ObjectApplier)
+	if !ok || v.edit == nil {
+		return nil
+	}
+	item, manifest := v.item, v.edit.buffer
+	opts := resources.ApplyOptions{DryRun: dryRun, Force: v.edit.force}
+	return func() bubbletea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), applyTimeout)
+		defer cancel()
+		result, err := applier.ApplyYAML(ctx, item, manifest, opts)
+		return applyResultMsg{dryRun: dryRun, result: result, err: err}
+	}
+}
+
+func (v *View) handleApplyResult(msg applyResultMsg) bubbletea.Cmd {
+	if v.edit == nil {
+		return nil
+	}
+	v.edit.busy = ""
+	switch {
+	case msg.err != nil:
+		v.edit.err = msg.err.Error()
+		v.edit.conflict = errors.Is(msg.err, resources.ErrApplyConflict) && !v.edit.force
+		v.edit.diff = nil
+	case msg.dryRun:
+		v.edit.diff = editDiff(v.full, msg.result)
+		if len(v.edit.diff) == 0 {
+			v.edit = nil
+			v.load()
+			v.actionMsg = "nothing to apply: no changes"
+			return clearActionCmd()
+		}
+	default:
+		forced := v.edit.force
+		v.edit = nil
+		v.full = v.resource.YAML(v.item)
+		v.load()
+		v.actionMsg = "applied " + v.item.Name
+		if forced {
+			v.actionMsg = "force-applied " + v.item.Name
+		}
+		return clearActionCmd()
+	}
+	v.cursor = 0
+	v.load()
+	return nil
+}
+
+// editDiff compares the live object with the dry-run result, ignoring fields
+// that change on every write.
+func editDiff(live, result string) []string {
+	a, err := normalizeForCompare(live, false)
+	if err != nil {
+		a = splitLines(live)
+	}
+	b, err := normalizeForCompare(result, false)
+	if err != nil {
+		b = splitLines(result)
+	}
+	return unifiedDiff(a, b, driftContext)
+}
+
+func (s *editSession) lines() []string {
+	switch {
+	case s.busy == "dry-run":
+		return []string{"Running server-side dry run…"}
+	case s.busy == "apply":
+		return []string{"Applying…"}
+	case s.err != "":
+		lines := []string{"Apply failed:", ""}
+		for _, line := range strings.Split(s.err, "\n") {
+			lines = append(lines, "  "+line)
+		}
+		if s.conflict {
+			return append(lines, "", "Your edit is kept: press F to review a forced apply that takes these",
+				"fields over from their managers, e to fix the edit, esc to discard.")
+		}
+		return append(lines, "", "Your edit is kept: press e to fix it, esc to discard.")
+	}
+	if s.force {
+		return append([]string{"--- live", "+++ after forced apply (dry run)"}, s.diff...)
+	}
+	return append([]string{"--- live", "+++ after apply (dry run)"}, s.diff...)
+}
+
+// updateEdit handles keys while an edit is being reviewed.
+func (v *View) updateEdit(key string) (bubbletea.Cmd, bool) {
+	switch key {
+	case "y":
+		if v.edit.busy == "" && len(v.edit.diff) > 0 {
+			v.edit.busy = "apply"
+			v.load()
+			return v.applyCmd(false), true
+		}
+		return nil, true
+	case "e":
+		if v.edit.busy == "" {
+			return v.startEdit(), true
+		}
+		return nil, true
+	case "F":
+		// A forced dry run shows what taking the fields over changes; y
+		// then applies it with force.
+		if v.edit.busy == "" && v.edit.conflict {
+			v.edit.force = true
+			v.edit.conflict = false
+			v.edit.err = ""
+			v.edit.busy = "dry-run"
+			v.load()
+			return v.applyCmd(true), true
+		}
+		return nil, true
+	case "esc":
+		// An apply in flight goes through regardless, so its result is
+		// waited for rather than claiming the edit was discarded.
+		if v.edit.busy == "apply" {
+			return nil, true
+		}
+		v.edit = nil
+		v.load()
+		v.actionMsg = "edit discarded"
+		return clearActionCmd(), true
+	}
+	return nil, false
+}
+
+type clearActionMsg struct{}
+
+func clearActionCmd() bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		time.Sleep(1500 * time.Millisecond)
+		return clearActionMsg{}
+	}
+}
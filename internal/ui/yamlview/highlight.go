@@ -0,0 +1,99 @@
+package yamlview
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dloss/podji/internal/ui/style"
+)
+
+// highlightLine colors one line of YAML: keys, list dashes, and scalar values
+// by type. It works line by line, so block scalars are left as plain text
+// after their header line.
+func highlightLine(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if trimmed == "" {
+		return line
+	}
+	indent := line[:len(line)-len(trimmed)]
+	if strings.HasPrefix(trimmed, "#") {
+		return indent + style.SyntaxComment.Render(trimmed)
+	}
+
+	var b strings.Builder
+	b.WriteString(indent)
+	for strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+		b.WriteString(style.SyntaxPunct.Render("-"))
+		trimmed = strings.TrimPrefix(trimmed, "-")
+		rest := strings.TrimLeft(trimmed, " ")
+		b.WriteString(trimmed[:len(trimmed)-len(rest)])
+		trimmed = rest
+	}
+
+	if key, value, ok := splitKey(trimmed); ok {
+		b.WriteString(style.SyntaxKey.Render(key) + ":")
+		if value != "" {
+			b.WriteString(" " + highlightScalar(value))
+		}
+		return b.String()
+	}
+	b.WriteString(highlightScalar(trimmed))
+	return b.String()
+}
+
+// splitKey splits "key: value" or "key:" and rejects colons inside values such
+// as URLs or timestamps.
+func splitKey(s string) (key, value string, ok bool) {
+	if strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "'") {
+		quote := s[:1]
+		end := strings.Index(s[1:], quote)
+		if end < 0 {
+			return "", "", false
+		}
+		rest := s[end+2:]
+		if rest == ":" || strings.HasPrefix(rest, ": ") {
+			return s[:end+2], strings.TrimSpace(strings.TrimPrefix(rest, ":")), true
+		}
+		return "", "", false
+	}
+	if strings.HasSuffix(s, ":") && !strings.Contains(s, ": ") {
+		return strings.TrimSuffix(s, ":"), "", true
+	}
+	idx := strings.Index(s, ": ")
+	if idx <= 0 {
+		return "", "", false
+	}
+	return s[:idx], strings.TrimSpace(s[idx+2:]), true
+}
+
+func highlightScalar(value string) string {
+	body, comment := value, ""
+	if idx := strings.Index(value, " #"); idx >= 0 && !strings.HasPrefix(value, `"`) && !strings.HasPrefix(value, "'") {
+		body, comment = value[:idx], value[idx:]
+	}
+	styled := body
+	switch {
+	case body == "null" || body == "~":
+		styled = style.Muted.Render(body)
+	case body == "true" || body == "false" || isNumber(body):
+		styled = style.SyntaxLiteral.Render(body)
+	case body == "|" || body == ">" || body == "|-" || body == ">-" || body == "{}" || body == "[]":
+		styled = style.SyntaxPunct.Render(body)
+	default:
+		styled = style.SyntaxString.Render(body)
+	}
+	if comment != "" {
+		styled += style.SyntaxComment.Render(comment)
+	}
+	return styled
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+// HighlightLine colors one line of YAML for views outside this package.
+func HighlightLine(line string) string {
+	return highlightLine(line)
+}
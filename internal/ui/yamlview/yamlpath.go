@@ -0,0 +1,108 @@
+package yamlview
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// lineInfo is what the view knows about one line of the document: the
+// JSONPath of the field that starts there and its value. Value is the scalar
+// for leaf fields and the YAML of the subtree for maps and lists.
+type lineInfo struct {
+	path  string
+	value string
+}
+
+// indexYAML maps each line (0-based) that starts a field or list item to its
+// JSONPath. Documents that fail to parse yield an empty index; the view then
+// falls back to plain text.
+func indexYAML(text string) map[int]lineInfo {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	index := make(map[int]lineInfo)
+	walkYAML(doc.Content[0], "", index)
+	return index
+}
+
+func walkYAML(node *yaml.Node, path string, index map[int]lineInfo) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, val := node.Content[i], node.Content[i+1]
+			childPath := path + jsonPathKey(key.Value)
+			// A later entry on the same line (the first key of a "- key: v"
+			// list item) is more specific than the item itself.
+			index[key.Line-1] = lineInfo{path: childPath, value: nodeValue(val)}
+			walkYAML(val, childPath, index)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			childPath := path + "[" + strconv.Itoa(i) + "]"
+			index[item.Line-1] = lineInfo{path: childPath, value: nodeValue(item)}
+			walkYAML(item, childPath, index)
+		}
+	}
+}
+
+// jsonPathKey renders a map key for kubectl's JSONPath dialect, which escapes
+// dots inside keys such as "app.kubernetes.io/name".
+func jsonPathKey(key string) string {
+	return "." + strings.ReplaceAll(key, ".", `\.`)
+}
+
+func nodeValue(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(string(out), "\n")
+}
+
+// noisyPaths are the subtrees the slim toggle hides: server-side apply
+// bookkeeping and the status block.
+var noisyPaths = map[string]bool{
+	".metadata.managedFields": true,
+	".status":                 true,
+}
+
+// stripNoise removes the noisyPaths fields from text, keeping the remaining
+// lines byte for byte so the layout matches the full document.
+func stripNoise(text string, index map[int]lineInfo) string {
+	lines := strings.Split(text, "\n")
+	out := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		info, ok := index[i]
+		if !ok || !noisyPaths[info.path] {
+			out = append(out, lines[i])
+			continue
+		}
+		indent := lineIndent(lines[i])
+		for i+1 < len(lines) && belongsToField(lines[i+1], indent) {
+			i++
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// belongsToField reports whether line is inside a field declared at indent:
+// deeper lines, blank lines, and list items at the same indent (block
+// sequences are not indented under their key in kubectl-style YAML).
+func belongsToField(line string, indent int) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return true
+	}
+	n := lineIndent(line)
+	return n > indent || (n == indent && strings.HasPrefix(trimmed, "- "))
+}
+
+func lineIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
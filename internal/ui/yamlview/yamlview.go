@@ -1,28 +1,272 @@
 package yamlview
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/dloss/podji/internal/resources"
 	"github.com/dloss/podji/internal/ui/style"
 	"github.com/dloss/podji/internal/ui/viewstate"
 )
 
+type clearCopiedMsg struct{}
+
+// copyToClipboard is a variable so tests can capture copies.
+var copyToClipboard = clipboard.WriteAll
+
 type View struct {
 	item     resources.ResourceItem
 	resource resources.ResourceType
 	viewport viewport.Model
+
+	full  string
+	slim  bool
+	lines []string
+	hl    []string
+	index map[int]lineInfo
+
+	cursor int
+
+	searchActive bool
+	searchQuery  string
+	searchInput  textinput.Model
+	matchLines   []int
+	matchIndex   int
+
+	copyMode  bool
+	copiedMsg string
+
+	drift       bool
+	driftStatus string
+
+	edit      *editSession
+	actionMsg string
 }
 
 func New(item resources.ResourceItem, resource resources.ResourceType) *View {
-	vp := viewport.New(0, 0)
-	vp.SetContent(resource.YAML(item))
-	return &View{item: item, resource: resource, viewport: vp}
+	v := &View{item: item, resource: resource, viewport: viewport.New(0, 0)}
+	v.searchInput = textinput.New()
+	v.searchInput.Prompt = "/ "
+	v.searchInput.PromptStyle = style.FilterPrompt
+	v.searchInput.TextStyle = lipgloss.NewStyle()
+	v.searchInput.Blur()
+	v.full = resource.YAML(item)
+	v.load()
+	return v
+}
+
+// load rebuilds the line model from the full document, hiding managedFields
+// and status in slim mode. An edit under review or the drift diff replace the
+// document.
+func (v *View) load() {
+	if v.edit != nil && (v.edit.busy != "" || v.edit.err != "" || v.edit.diff != nil) {
+		v.index = nil
+		v.lines = v.edit.lines()
+		v.hl = make([]string, len(v.lines))
+		for i, line := range v.lines {
+			v.hl[i] = highlightDiffLine(line)
+		}
+	} else if v.drift {
+		v.index = nil
+		v.lines = v.driftLines()
+		v.hl = make([]string, len(v.lines))
+		for i, line := range v.lines {
+			v.hl[i] = highlightDiffLine(line)
+		}
+	} else {
+		text := v.full
+		index := indexYAML(text)
+		if v.slim && index != nil {
+			text = stripNoise(text, index)
+			index = indexYAML(text)
+		}
+		v.index = index
+		v.lines = strings.Split(text, "\n")
+		v.hl = make([]string, len(v.lines))
+		for i, line := range v.lines {
+			v.hl[i] = highlightLine(line)
+		}
+	}
+	if v.cursor >= len(v.lines) {
+		v.cursor = len(v.lines) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+	v.recomputeMatches()
+	v.render()
+}
+
+// driftLines diffs the desired state against the live object. Helm manifests
+// are only consulted when the resource can fetch them.
+func (v *View) driftLines() []string {
+	helm, _ := v.resource.(resources.HelmManifestReader)
+	d, err := computeDrift(v.full, helm)
+	if err != nil {
+		v.driftStatus = "unavailable"
+		return []string{"Drift unavailable: " + err.Error()}
+	}
+	v.driftStatus = d.source
+	if len(d.lines) == 0 {
+		v.driftStatus = d.source + ", none"
+		return []string{"No drift: the live object matches " + d.source + "."}
+	}
+	return append([]string{"--- " + d.source, "+++ live"}, d.lines...)
+}
+
+func (v *View) render() {
+	out := make([]string, len(v.hl))
+	copy(out, v.hl)
+	if v.cursor < len(v.lines) {
+		line := v.lines[v.cursor]
+		if pad := v.viewport.Width - ansi.StringWidth(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		out[v.cursor] = style.CursorLine.Render(line)
+	}
+	v.viewport.SetContent(strings.Join(out, "\n"))
 }
 
 func (v *View) Init() bubbletea.Cmd { return nil }
 
 func (v *View) Update(msg bubbletea.Msg) viewstate.Update {
+	if v.searchActive {
+		updated, cmd := v.searchInput.Update(msg)
+		v.searchInput = updated
+		v.searchQuery = v.searchInput.Value()
+		if key, ok := msg.(bubbletea.KeyMsg); ok {
+			switch key.String() {
+			case "enter":
+				v.searchActive = false
+				v.searchInput.Blur()
+				v.recomputeMatches()
+				if len(v.matchLines) > 0 {
+					v.matchIndex = 0
+					for i, line := range v.matchLines {
+						if line >= v.cursor {
+							v.matchIndex = i
+							break
+						}
+					}
+					v.moveCursor(v.matchLines[v.matchIndex])
+				}
+			case "esc":
+				v.clearSearch()
+			}
+		}
+		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: cmd}
+	}
+
+	switch msg := msg.(type) {
+	case clearCopiedMsg:
+		v.copiedMsg = ""
+		return viewstate.Update{Action: viewstate.None, Next: v}
+	case clearActionMsg:
+		v.actionMsg = ""
+		return viewstate.Update{Action: viewstate.None, Next: v}
+	case editorDoneMsg:
+		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.finishEdit(msg)}
+	case applyResultMsg:
+		return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.handleApplyResult(msg)}
+	case bubbletea.KeyMsg:
+		if v.edit != nil {
+			if cmd, handled := v.updateEdit(msg.String()); handled {
+				return viewstate.Update{Action: viewstate.None, Next: v, Cmd: cmd}
+			}
+		}
+		if v.copyMode {
+			v.copyMode = false
+			info, ok := v.index[v.cursor]
+			text := ""
+			switch msg.String() {
+			case "v":
+				text = info.value
+			case "p":
+				text = "{" + info.path + "}"
+			}
+			if !ok || text == "" || text == "{}" {
+				return viewstate.Update{Action: viewstate.None, Next: v}
+			}
+			if err := copyToClipboard(text); err != nil {
+				v.copiedMsg = "clipboard error: " + err.Error()
+			} else {
+				v.copiedMsg = "copied: " + firstLine(text)
+			}
+			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: clearCopiedCmd()}
+		}
+		switch msg.String() {
+		case "esc":
+			if len(v.matchLines) > 0 {
+				v.clearSearch()
+				return viewstate.Update{Action: viewstate.None, Next: v}
+			}
+		case "/":
+			v.searchActive = true
+			v.searchQuery = ""
+			v.searchInput.SetValue("")
+			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.searchInput.Focus()}
+		case "n":
+			if len(v.matchLines) > 0 {
+				v.matchIndex = (v.matchIndex + 1) % len(v.matchLines)
+				v.moveCursor(v.matchLines[v.matchIndex])
+			}
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "b":
+			if len(v.matchLines) > 0 {
+				v.matchIndex = (v.matchIndex - 1 + len(v.matchLines)) % len(v.matchLines)
+				v.moveCursor(v.matchLines[v.matchIndex])
+			}
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "e":
+			return viewstate.Update{Action: viewstate.None, Next: v, Cmd: v.startEdit()}
+		case "d":
+			v.drift = !v.drift
+			v.cursor = 0
+			v.load()
+			v.moveCursor(0)
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "s":
+			if v.drift {
+				return viewstate.Update{Action: viewstate.None, Next: v}
+			}
+			v.slim = !v.slim
+			path := v.index[v.cursor].path
+			v.load()
+			v.restoreCursor(path)
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "c":
+			if _, ok := v.index[v.cursor]; ok {
+				v.copyMode = true
+			}
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "up", "k":
+			v.moveCursor(v.cursor - 1)
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "down", "j":
+			v.moveCursor(v.cursor + 1)
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "pgup":
+			v.moveCursor(v.cursor - pageStep(v.viewport.Height))
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "pgdown", "pgdn", " ":
+			v.moveCursor(v.cursor + pageStep(v.viewport.Height))
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "home", "g":
+			v.moveCursor(0)
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		case "end", "G":
+			v.moveCursor(len(v.lines) - 1)
+			return viewstate.Update{Action: viewstate.None, Next: v}
+		}
+	}
+
 	updated, cmd := v.viewport.Update(msg)
 	v.viewport = updated
 	return viewstate.Update{Action: viewstate.None, Next: v, Cmd: cmd}
@@ -37,15 +281,207 @@ func (v *View) Breadcrumb() string {
 }
 
 func (v *View) Footer() string {
-	line1 := ""
-	line2 := style.ActionFooter([]style.Binding{style.B("←", "back")}, v.viewport.Width)
+	if v.searchActive {
+		line1 := style.FooterKey.Render("search") + "  " + v.searchInput.View()
+		line2 := style.FormatBindings([]style.Binding{
+			style.B("enter", "confirm"),
+			style.B("esc", "cancel"),
+		})
+		if v.viewport.Width > 0 {
+			line1 = ansi.Truncate(line1, v.viewport.Width-2, "…")
+			line2 = ansi.Truncate(line2, v.viewport.Width-2, "…")
+		}
+		return line1 + "\n" + line2
+	}
+
+	if v.edit != nil {
+		return v.editFooter()
+	}
+
+	var indicators []style.Binding
+	if v.drift {
+		indicators = append(indicators, style.B("drift", v.driftStatus))
+	} else if v.slim {
+		indicators = append(indicators, style.B("hidden", "managedFields, status"))
+	}
+	if len(v.matchLines) > 0 {
+		indicators = append(indicators, style.B("match", matchSummary(v.matchIndex, len(v.matchLines))))
+	}
+	if path := v.index[v.cursor].path; path != "" {
+		indicators = append(indicators, style.B("path", path))
+	}
+	if v.copiedMsg != "" {
+		indicators = append(indicators, style.B(v.copiedMsg, ""))
+	}
+	if v.actionMsg != "" {
+		indicators = append(indicators, style.B(v.actionMsg, ""))
+	}
+	line1 := style.FormatBindings(indicators)
+	if v.viewport.Width > 0 {
+		line1 = ansi.Truncate(line1, v.viewport.Width-2, "…")
+	}
+
+	if v.copyMode {
+		line2 := style.FooterKey.Render("copy") + "  " + style.FormatBindings([]style.Binding{
+			style.B("v", "value"),
+			style.B("p", "jsonpath"),
+			style.B("esc", "cancel"),
+		})
+		return line1 + "\n" + line2
+	}
+
+	actions := []style.Binding{style.B("/", "search")}
+	if len(v.matchLines) > 0 {
+		actions = append(actions, style.B("n/b", "next/prev"))
+	}
+	if v.drift {
+		actions = append(actions, style.B("d", "yaml"), style.B("←", "back"))
+		return line1 + "\n" + style.ActionFooter(actions, v.viewport.Width)
+	}
+	slimLabel := "hide managed/status"
+	if v.slim {
+		slimLabel = "show all"
+	}
+	actions = append(actions, style.B("s", slimLabel), style.B("c", "copy"), style.B("e", "edit"), style.B("d", "drift"), style.B("←", "back"))
+	line2 := style.ActionFooter(actions, v.viewport.Width)
 	return line1 + "\n" + line2
 }
 
+func (v *View) editFooter() string {
+	state := "review dry-run diff"
+	switch {
+	case v.edit.busy != "":
+		state = v.edit.busy + " in progress"
+	case v.edit.err != "":
+		state = "failed, edit kept"
+	}
+	line1 := style.FormatBindings([]style.Binding{style.B("edit", state)})
+	var actions []style.Binding
+	if v.edit.busy == "apply" {
+		return line1 + "\n" + style.ActionFooter(actions, v.viewport.Width)
+	}
+	if v.edit.busy == "" && len(v.edit.diff) > 0 {
+		label := "apply"
+		if v.edit.force {
+			label = "force apply"
+		}
+		actions = append(actions, style.B("y", label))
+	}
+	if v.edit.busy == "" && v.edit.conflict {
+		actions = append(actions, style.B("F", "force"))
+	}
+	actions = append(actions, style.B("e", "edit again"), style.B("esc", "discard"))
+	return line1 + "\n" + style.ActionFooter(actions, v.viewport.Width)
+}
+
 func (v *View) SetSize(width, height int) {
 	if width == 0 || height == 0 {
 		return
 	}
 	v.viewport.Width = width
 	v.viewport.Height = height
+	v.render()
+	v.scrollToCursor()
+}
+
+func (v *View) SuppressGlobalKeys() bool {
+	return v.searchActive || v.copyMode || len(v.matchLines) > 0 || v.edit != nil
+}
+
+func (v *View) moveCursor(line int) {
+	if line >= len(v.lines) {
+		line = len(v.lines) - 1
+	}
+	if line < 0 {
+		line = 0
+	}
+	v.cursor = line
+	v.render()
+	v.scrollToCursor()
+}
+
+func (v *View) scrollToCursor() {
+	if v.viewport.Height <= 0 {
+		return
+	}
+	if v.cursor < v.viewport.YOffset {
+		v.viewport.SetYOffset(v.cursor)
+	} else if v.cursor >= v.viewport.YOffset+v.viewport.Height {
+		v.viewport.SetYOffset(v.cursor - v.viewport.Height + 1)
+	}
+}
+
+// restoreCursor puts the cursor back on path after the document was reloaded,
+// or on the nearest enclosing field that is still shown.
+func (v *View) restoreCursor(path string) {
+	for path != "" {
+		for line, info := range v.index {
+			if info.path == path {
+				v.moveCursor(line)
+				return
+			}
+		}
+		idx := strings.LastIndexAny(path, ".[")
+		if idx < 0 {
+			break
+		}
+		path = path[:idx]
+	}
+	v.moveCursor(v.cursor)
+}
+
+func (v *View) clearSearch() {
+	v.searchActive = false
+	v.searchInput.Blur()
+	v.searchInput.SetValue("")
+	v.searchQuery = ""
+	v.matchLines = nil
+	v.matchIndex = 0
+}
+
+func (v *View) recomputeMatches() {
+	query := strings.ToLower(strings.TrimSpace(v.searchQuery))
+	if query == "" {
+		v.matchLines = nil
+		v.matchIndex = 0
+		return
+	}
+	matches := make([]int, 0)
+	for i, line := range v.lines {
+		if strings.Contains(strings.ToLower(line), query) {
+			matches = append(matches, i)
+		}
+	}
+	v.matchLines = matches
+	if v.matchIndex >= len(matches) {
+		v.matchIndex = 0
+	}
+}
+
+func clearCopiedCmd() bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		time.Sleep(1500 * time.Millisecond)
+		return clearCopiedMsg{}
+	}
+}
+
+func matchSummary(index, total int) string {
+	if total <= 0 {
+		return "0/0"
+	}
+	return strconv.Itoa(index+1) + "/" + strconv.Itoa(total)
+}
+
+func pageStep(height int) int {
+	if height <= 1 {
+		return 1
+	}
+	return height - 1
+}
+
+func firstLine(s string) string {
+	if idx := strings.IndexByte(s, '\n'); idx >= 0 {
+		return s[:idx] + " …"
+	}
+	return s
 }
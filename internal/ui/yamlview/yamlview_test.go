@@ -0,0 +1,526 @@
+package yamlview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dloss/podji/internal/resources"
+)
+
+const testYAML = `apiVersion: v1
+kind: Pod
+metadata:
+  labels:
+    app.kubernetes.io/name: api
+  managedFields:
+  - manager: kubectl
+    operation: Update
+  name: api-1
+spec:
+  containers:
+  - image: myco/api:v2
+    name: api
+status:
+  phase: Running`
+
+type yamlResource struct {
+	resources.ResourceType
+}
+
+func (y yamlResource) YAML(resources.ResourceItem) string { return testYAML }
+
+func newTestView(t *testing.T) *View {
+	t.Helper()
+	v := New(resources.ResourceItem{Name: "api-1"}, yamlResource{resources.NewPods()})
+	v.SetSize(80, 10)
+	return v
+}
+
+func press(v *View, keys ...string) {
+	for _, k := range keys {
+		switch k {
+		case "enter":
+			v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+		case "esc":
+			v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEsc})
+		default:
+			v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+}
+
+func TestIndexYAMLBuildsJSONPaths(t *testing.T) {
+	index := indexYAML(testYAML)
+	tests := map[int]string{
+		4:  `.metadata.labels.app\.kubernetes\.io/name`,
+		6:  ".metadata.managedFields[0].manager",
+		11: ".spec.containers[0].image",
+		12: ".spec.containers[0].name",
+		14: ".status.phase",
+	}
+	for line, want := range tests {
+		if got := index[line].path; got != want {
+			t.Fatalf("line %d path=%q, want %q", line, got, want)
+		}
+	}
+	if got := index[11].value; got != "myco/api:v2" {
+		t.Fatalf("expected scalar value, got %q", got)
+	}
+}
+
+func TestSlimToggleHidesManagedFieldsAndStatus(t *testing.T) {
+	v := newTestView(t)
+	press(v, "j", "j", "j", "j", "j", "j", "j", "j")
+	if got := v.index[v.cursor].path; got != ".metadata.name" {
+		t.Fatalf("expected cursor on .metadata.name, got %q", got)
+	}
+
+	press(v, "s")
+	text := strings.Join(v.lines, "\n")
+	if strings.Contains(text, "managedFields") || strings.Contains(text, "status:") || strings.Contains(text, "kubectl") {
+		t.Fatalf("expected managedFields and status to be hidden, got:\n%s", text)
+	}
+	if !strings.Contains(text, "  name: api-1\nspec:") {
+		t.Fatalf("expected remaining lines to be kept verbatim, got:\n%s", text)
+	}
+	if got := v.index[v.cursor].path; got != ".metadata.name" {
+		t.Fatalf("expected cursor to stay on .metadata.name, got %q", got)
+	}
+	if !strings.Contains(ansi.Strip(v.Footer()), "managedFields, status") {
+		t.Fatalf("expected footer to report hidden fields, got %q", ansi.Strip(v.Footer()))
+	}
+
+	press(v, "s")
+	if !strings.Contains(strings.Join(v.lines, "\n"), "managedFields") {
+		t.Fatal("expected second toggle to show the full document")
+	}
+}
+
+func TestSearchMovesCursorThroughMatches(t *testing.T) {
+	v := newTestView(t)
+	press(v, "/", "a", "p", "i", "enter")
+	if len(v.matchLines) != 5 {
+		t.Fatalf("expected 5 matches, got %v", v.matchLines)
+	}
+	if v.cursor != v.matchLines[0] {
+		t.Fatalf("expected cursor on first match, got %d", v.cursor)
+	}
+	press(v, "n")
+	if v.cursor != v.matchLines[1] {
+		t.Fatalf("expected n to move to second match, got %d", v.cursor)
+	}
+	press(v, "b", "b")
+	if v.cursor != v.matchLines[4] {
+		t.Fatalf("expected b to wrap to last match, got %d", v.cursor)
+	}
+	if !v.SuppressGlobalKeys() {
+		t.Fatal("expected active matches to keep esc local")
+	}
+	press(v, "esc")
+	if len(v.matchLines) != 0 || v.SuppressGlobalKeys() {
+		t.Fatal("expected esc to clear the search")
+	}
+}
+
+func TestCopyValueAndJSONPathAtCursor(t *testing.T) {
+	var copied []string
+	prev := copyToClipboard
+	copyToClipboard = func(s string) error { copied = append(copied, s); return nil }
+	t.Cleanup(func() { copyToClipboard = prev })
+
+	v := newTestView(t)
+	press(v, "G", "k", "k", "k") // .spec.containers[0].image
+	press(v, "c", "v")
+	press(v, "c", "p")
+	want := []string{"myco/api:v2", "{.spec.containers[0].image}"}
+	if strings.Join(copied, "|") != strings.Join(want, "|") {
+		t.Fatalf("copied %q, want %q", copied, want)
+	}
+}
+
+func TestHighlightLineKeepsText(t *testing.T) {
+	for _, line := range []string{
+		"  - name: api # main",
+		`    "quoted.key": "http://x:80"`,
+		"  replicas: 3",
+		"  url: http://example.com:8080",
+		"    - -c",
+	} {
+		if got := ansi.Strip(highlightLine(line)); got != line {
+			t.Fatalf("highlightLine(%q) changed text to %q", line, got)
+		}
+	}
+}
+
+const driftYAML = `apiVersion: v1
+kind: Service
+metadata:
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: |
+      {"apiVersion":"v1","kind":"Service","metadata":{"name":"api","namespace":"default"},"spec":{"ports":[{"port":80}],"selector":{"app":"api"}}}
+  creationTimestamp: "2026-02-10T14:00:00Z"
+  labels:
+    team: platform
+  name: api
+  namespace: default
+  resourceVersion: "4711"
+spec:
+  clusterIP: 10.0.0.12
+  ports:
+  - port: 8080
+    protocol: TCP
+  selector:
+    app: api
+status:
+  loadBalancer: {}`
+
+type driftResource struct {
+	resources.ResourceType
+	yaml string
+}
+
+func (d driftResource) YAML(resources.ResourceItem) string { return d.yaml }
+
+func TestDriftAgainstLastApplied(t *testing.T) {
+	v := New(resources.ResourceItem{Name: "api"}, driftResource{resources.NewPods(), driftYAML})
+	v.SetSize(80, 20)
+	press(v, "d")
+
+	got := strings.Join(v.lines, "\n")
+	for _, want := range []string{"--- last-applied", "-  - port: 80", "+  - port: 8080", "+    team: platform"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("drift missing %q:\n%s", want, got)
+		}
+	}
+	// Server defaults and metadata are not drift.
+	for _, unwanted := range []string{"clusterIP", "protocol", "resourceVersion", "status"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("drift should not mention %q:\n%s", unwanted, got)
+		}
+	}
+	if !strings.Contains(ansi.Strip(v.Footer()), "drift last-applied") {
+		t.Errorf("footer should name the drift source, got %q", ansi.Strip(v.Footer()))
+	}
+
+	press(v, "d")
+	if v.lines[0] != "apiVersion: v1" {
+		t.Fatalf("d should toggle back to the YAML, got %q", v.lines[0])
+	}
+}
+
+func TestDriftAgainstHelmManifest(t *testing.T) {
+	deployments := resources.NewDeployments()
+	v := New(resources.ResourceItem{Name: "api"}, deployments)
+	v.SetSize(80, 20)
+	press(v, "d")
+
+	got := strings.Join(v.lines, "\n")
+	if !strings.Contains(got, "--- helm api") || !strings.Contains(got, "-  replicas: 3") || !strings.Contains(got, "+  replicas: 2") {
+		t.Fatalf("expected replicas drift against the helm manifest:\n%s", got)
+	}
+}
+
+type helmManifest string
+
+func (h helmManifest) HelmManifest(namespace, release string) (string, error) {
+	return string(h), nil
+}
+
+func TestDriftAppsDeploymentMatchingHelmManifest(t *testing.T) {
+	live := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  annotations:
+    deployment.kubernetes.io/revision: "4"
+    meta.helm.sh/release-name: shop
+    meta.helm.sh/release-namespace: default
+  creationTimestamp: "2024-01-01T00:00:00Z"
+  generation: 4
+  name: api
+  namespace: default
+  resourceVersion: "812"
+  uid: 5b1c
+spec:
+  progressDeadlineSeconds: 600
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - image: myco/api:v2
+        name: api
+status:
+  readyReplicas: 2
+`
+	manifest := `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: api
+        image: myco/api:v2
+`
+	d, err := computeDrift(live, helmManifest(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.source != "helm shop" || len(d.lines) != 0 {
+		t.Fatalf("expected no drift against helm shop, got %q from %s", d.lines, d.source)
+	}
+}
+
+func TestDriftWithoutDesiredState(t *testing.T) {
+	v := newTestView(t)
+	press(v, "d")
+	if len(v.lines) != 1 || !strings.HasPrefix(v.lines[0], "Drift unavailable") {
+		t.Fatalf("expected an explanation, got %q", v.lines)
+	}
+}
+
+func TestCompareIgnoresNoiseAndPairsChanges(t *testing.T) {
+	left := CompareSide{Label: "pod/api-1 (prod/default)", YAML: `apiVersion: v1
+kind: Pod
+metadata:
+  name: api-1
+  uid: 1111
+  resourceVersion: "10"
+spec:
+  containers:
+  - image: myco/api:v2
+    name: api
+status:
+  conditions:
+  - type: Ready
+    status: "True"
+    lastTransitionTime: "2026-02-10T14:00:00Z"`}
+	right := CompareSide{Label: "pod/api-2 (staging/default)", YAML: `apiVersion: v1
+kind: Pod
+metadata:
+  resourceVersion: "99"
+  uid: 2222
+  name: api-2
+spec:
+  containers:
+  - name: api
+    image: myco/api:v3
+status:
+  conditions:
+  - type: Ready
+    status: "False"
+    lastTransitionTime: "2026-03-01T09:00:00Z"`}
+
+	v := NewCompare(left, right)
+	v.SetSize(100, 20)
+	var changed []string
+	for _, row := range v.rows {
+		if row.kind != ' ' {
+			changed = append(changed, strings.TrimSpace(row.left)+" => "+strings.TrimSpace(row.right))
+		}
+	}
+	want := []string{
+		"name: api-1 => name: api-2",
+		"- image: myco/api:v2 => - image: myco/api:v3",
+		`- status: "True" => - status: "False"`,
+	}
+	if strings.Join(changed, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected changes:\n%s", strings.Join(changed, "\n"))
+	}
+	if len(v.changes) != 3 {
+		t.Fatalf("expected 3 changes, got %d", len(v.changes))
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("a")})
+	found := false
+	for _, row := range v.rows {
+		if strings.Contains(row.left, "uid") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("a should show the noise fields")
+	}
+	if !strings.Contains(ansi.Strip(v.View()), "pod/api-2 (staging/default)") {
+		t.Fatal("header should name both sides")
+	}
+}
+
+type applyResource struct {
+	resources.ResourceType
+	yaml    string
+	applied []string
+	forced  []bool
+	dryRuns int
+	err     error
+	// conflict fails applies that are not forced with ErrApplyConflict.
+	conflict bool
+}
+
+func (a *applyResource) YAML(resources.ResourceItem) string { return a.yaml }
+
+func (a *applyResource) ApplyYAML(_ context.Context, _ resources.ResourceItem, manifest string, opts resources.ApplyOptions) (string, error) {
+	if a.err != nil {
+		return "", a.err
+	}
+	if a.conflict && !opts.Force {
+		return "", fmt.Errorf("%w: .spec.replicas is owned by kubectl", resources.ErrApplyConflict)
+	}
+	if opts.DryRun {
+		a.dryRuns++
+		return manifest, nil
+	}
+	a.applied = append(a.applied, manifest)
+	a.forced = append(a.forced, opts.Force)
+	return manifest, nil
+}
+
+// fakeEditor replaces the editor with one that rewrites the file.
+func fakeEditor(t *testing.T, edit func(string) string) {
+	t.Helper()
+	prev := runEditor
+	runEditor = func(path string) bubbletea.Cmd {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(edit(string(data))), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return func() bubbletea.Msg { return editorDoneMsg{path: path} }
+	}
+	t.Cleanup(func() { runEditor = prev })
+}
+
+// run feeds the commands' messages back into the view while the edit is in
+// progress, leaving out the delayed status clear that follows it.
+func run(v *View, cmd bubbletea.Cmd) {
+	for cmd != nil && v.edit != nil {
+		cmd = v.Update(cmd()).Cmd
+	}
+}
+
+func TestEditShowsDryRunDiffThenApplies(t *testing.T) {
+	res := &applyResource{ResourceType: resources.NewPods(), yaml: testYAML}
+	v := New(resources.ResourceItem{Name: "api-1"}, res)
+	v.SetSize(80, 20)
+	fakeEditor(t, func(s string) string {
+		if strings.Contains(s, "managedFields") || strings.Contains(s, "status:") {
+			t.Errorf("editor should not get managedFields or status:\n%s", s)
+		}
+		return strings.Replace(s, "myco/api:v2", "myco/api:v3", 1)
+	})
+
+	run(v, v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("e")}).Cmd)
+	got := strings.Join(v.lines, "\n")
+	if res.dryRuns != 1 || !strings.Contains(got, "-  - image: myco/api:v2") || !strings.Contains(got, "+  - image: myco/api:v3") {
+		t.Fatalf("expected dry-run diff, got %d dry runs:\n%s", res.dryRuns, got)
+	}
+	if !strings.Contains(ansi.Strip(v.Footer()), "y apply") {
+		t.Fatalf("footer should offer apply, got %q", ansi.Strip(v.Footer()))
+	}
+
+	run(v, v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("y")}).Cmd)
+	if len(res.applied) != 1 || !strings.Contains(res.applied[0], "myco/api:v3") {
+		t.Fatalf("expected the edit to be applied, got %q", res.applied)
+	}
+	if v.edit != nil || v.lines[0] != "apiVersion: v1" {
+		t.Fatal("expected the view to return to the YAML after applying")
+	}
+}
+
+func TestEditFailureKeepsBufferForRetry(t *testing.T) {
+	res := &applyResource{ResourceType: resources.NewPods(), yaml: testYAML, err: errors.New("Apply failed with 1 conflict")}
+	v := New(resources.ResourceItem{Name: "api-1"}, res)
+	v.SetSize(80, 20)
+	fakeEditor(t, func(s string) string { return strings.Replace(s, "name: api\n", "name: web\n", 1) })
+
+	run(v, v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("e")}).Cmd)
+	if v.edit == nil || !strings.Contains(strings.Join(v.lines, "\n"), "1 conflict") {
+		t.Fatalf("expected the conflict to be reported, got %q", v.lines)
+	}
+
+	res.err = nil
+	var reopened string
+	fakeEditor(t, func(s string) string { reopened = s; return s })
+	run(v, v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("e")}).Cmd)
+	if !strings.Contains(reopened, "name: web") {
+		t.Fatalf("retry should reopen the kept edit, got:\n%s", reopened)
+	}
+	if len(v.edit.diff) == 0 {
+		t.Fatal("expected a dry-run diff after the retry")
+	}
+
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEsc})
+	if v.edit != nil || len(res.applied) != 0 {
+		t.Fatal("esc should discard the edit without applying")
+	}
+}
+
+func TestEditEscWaitsForApplyInFlight(t *testing.T) {
+	res := &applyResource{ResourceType: resources.NewPods(), yaml: testYAML}
+	v := New(resources.ResourceItem{Name: "api-1"}, res)
+	v.SetSize(80, 20)
+	fakeEditor(t, func(s string) string { return strings.Replace(s, "myco/api:v2", "myco/api:v3", 1) })
+	run(v, v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("e")}).Cmd)
+
+	apply := v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("y")}).Cmd
+	v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEsc})
+	if v.edit == nil || v.edit.busy != "apply" || strings.Contains(v.actionMsg, "discarded") {
+		t.Fatalf("esc should not discard an apply in flight, got msg %q", v.actionMsg)
+	}
+	v.Update(apply())
+	if v.edit != nil || v.actionMsg != "applied api-1" {
+		t.Fatalf("expected the apply result to be reported, got %q", v.actionMsg)
+	}
+}
+
+func TestEditConflictOffersForcedApply(t *testing.T) {
+	res := &applyResource{ResourceType: resources.NewPods(), yaml: testYAML, conflict: true}
+	v := New(resources.ResourceItem{Name: "api-1"}, res)
+	v.SetSize(80, 20)
+	fakeEditor(t, func(s string) string { return strings.Replace(s, "myco/api:v2", "myco/api:v3", 1) })
+
+	run(v, v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("e")}).Cmd)
+	if got := strings.Join(v.lines, "\n"); !strings.Contains(got, "owned by kubectl") || !strings.Contains(got, "press F") {
+		t.Fatalf("expected the conflict with a force option, got:\n%s", got)
+	}
+	if footer := ansi.Strip(v.Footer()); !strings.Contains(footer, "F force") {
+		t.Fatalf("footer should offer force, got %q", footer)
+	}
+
+	run(v, v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("F")}).Cmd)
+	if got := strings.Join(v.lines, "\n"); !strings.Contains(got, "after forced apply") || !strings.Contains(got, "+  - image: myco/api:v3") {
+		t.Fatalf("expected a forced dry-run diff to confirm, got:\n%s", got)
+	}
+	if len(res.applied) != 0 {
+		t.Fatal("force should ask before applying")
+	}
+	run(v, v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("y")}).Cmd)
+	if len(res.forced) != 1 || !res.forced[0] || v.actionMsg != "force-applied api-1" {
+		t.Fatalf("expected a forced apply, got %v with %q", res.forced, v.actionMsg)
+	}
+}
+
+func TestEditWithoutChangesIsCancelled(t *testing.T) {
+	res := &applyResource{ResourceType: resources.NewPods(), yaml: testYAML}
+	v := New(resources.ResourceItem{Name: "api-1"}, res)
+	fakeEditor(t, func(s string) string { return s })
+	run(v, v.Update(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("e")}).Cmd)
+	if v.edit != nil || res.dryRuns != 0 || !strings.Contains(v.actionMsg, "no changes") {
+		t.Fatalf("expected edit to be cancelled, got msg %q", v.actionMsg)
+	}
+}